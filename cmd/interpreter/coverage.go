@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/dimbata23/golang-scheme-interpreter/pkg/interpreter"
+)
+
+// loads the files recording the coverage and writes them annotated with it,
+// every line is prefixed with the fewest hits of the forms and branches
+// starting on it, `#####` if one of them wasn't executed
+func coverFiles(i *interpreter.Interpreter, files []string, w io.Writer) {
+	i.SetCoverage(true)
+	for _, file := range files {
+		i.Interpret(fmt.Sprintf("(load %q)", file))
+	}
+
+	hits := map[string]map[int]int{} // fewest hits of the points by file and line
+	executed, total := 0, 0
+	for _, point := range i.Coverage() {
+		lines, isKnown := hits[point.Where.File]
+		if !isKnown {
+			lines = map[int]int{}
+			hits[point.Where.File] = lines
+		}
+
+		line := point.Where.Pos.Line
+		if prev, isSet := lines[line]; !isSet || point.Hits < prev {
+			lines[line] = point.Hits
+		}
+
+		total++
+		if point.Hits > 0 {
+			executed++
+		}
+	}
+
+	for _, file := range files {
+		src, err := ioutil.ReadFile(file)
+		if err != nil {
+			fmt.Fprintf(w, "%s: %s\n", file, err)
+			continue
+		}
+
+		fmt.Fprintf(w, "== %s ==\n", file)
+		for idx, text := range strings.Split(strings.TrimSuffix(string(src), "\n"), "\n") {
+			count, isPoint := hits[file][idx+1]
+			mark := ""
+			switch {
+			case isPoint && count == 0:
+				mark = "#####"
+			case isPoint:
+				mark = fmt.Sprint(count)
+			}
+
+			fmt.Fprintf(w, "%9s:%5d: %s\n", mark, idx+1, text)
+		}
+	}
+
+	fmt.Fprintf(w, "%d of %d forms and branches executed\n", executed, total)
+}
+
+// writes the coverage report of the files to the named file
+func writeCoverage(i *interpreter.Interpreter, name string, files []string) {
+	if name == "-" {
+		coverFiles(i, files, os.Stdout)
+		return
+	}
+
+	out, err := os.Create(name)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer out.Close()
+
+	coverFiles(i, files, out)
+}
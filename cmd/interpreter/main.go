@@ -1,27 +1,179 @@
 package main
 
 import (
-	"bufio"
+	"flag"
 	"fmt"
+	"io"
 	"os"
+	"strings"
 
 	"github.com/dimbata23/golang-scheme-interpreter/pkg/interpreter"
+	"github.com/dimbata23/golang-scheme-interpreter/pkg/parser"
+)
+
+const (
+	expandCommand     = ",expand"     // prints the expansion of the rest of the line
+	envCommand        = ",env"        // prints the global bindings
+	backtraceCommand  = ",backtrace"  // prints the stack of the last error
+	whereCommand      = ",where"      // prints where the named binding was defined
+	checkpointCommand = ",checkpoint" // saves the global bindings for a later rollback
+	rollbackCommand   = ",rollback"   // restores the global bindings saved by the last checkpoint
 )
 
 func main() {
+	warnings := flag.Bool("warnings", false, "print analyzer warnings before evaluating")
+	docs := flag.Bool("docs", false, "print the reference of the builtins in markdown and exit")
+	cover := flag.String("coverage", "", "load the files given as arguments and write them annotated with the coverage to the file, - for the standard output")
+	flag.Parse()
+
 	i := interpreter.MakeInterpreter()
+	if *docs {
+		printDocs(&i)
+		return
+	}
+
+	if *cover != "" {
+		writeCoverage(&i, *cover, flag.Args())
+		shutdown(&i, 0)
+	}
+
+	if flag.Arg(0) == runCommand {
+		runProgram(&i, flag.Args()[1:])
+	}
+
+	i.SetWarnings(*warnings)
+	var checkpoint *interpreter.Snapshot
+	stdin := i.StdinPort() // read by scheme code as well, e.g. by read-line
 	for {
-		reader := bufio.NewReader(os.Stdin)
 		fmt.Print("> ")
 
-		input, err := reader.ReadString('\n')
+		input, err := stdin.ReadLine()
+		if err == io.EOF {
+			shutdown(&i, 0)
+		}
+
+		// the input can't be read any further
 		if err != nil {
-			fmt.Printf("DEBUG: ERR READING: %s\n", err)
+			fmt.Println("reading the input failed:", err)
+			shutdown(&i, 1)
+		}
+
+		if strings.HasPrefix(input, expandCommand) {
+			expand(&i, strings.TrimPrefix(input, expandCommand))
+			continue
+		}
+
+		if strings.TrimSpace(input) == envCommand {
+			printBindings(&i)
+			continue
+		}
+
+		if strings.TrimSpace(input) == backtraceCommand {
+			printBacktrace(&i)
+			continue
+		}
+
+		if strings.HasPrefix(input, whereCommand) {
+			printWhere(&i, strings.TrimSpace(strings.TrimPrefix(input, whereCommand)))
+			continue
+		}
+
+		if strings.TrimSpace(input) == checkpointCommand {
+			checkpoint = i.Snapshot()
+			fmt.Printf("checkpoint saved, %d bindings\n", checkpoint.Len())
+			continue
+		}
+
+		if strings.TrimSpace(input) == rollbackCommand {
+			rollback(&i, checkpoint)
+			continue
 		}
 
 		status := i.Interpret(input)
-		if status != interpreter.StatusOk {
-			break
+		if status.Code == interpreter.StatusExitted {
+			shutdown(&i, status.ExitCode)
+		}
+	}
+}
+
+// closes the interpreter, printing the errors of its exit thunks,
+// and exits with the code
+func shutdown(i *interpreter.Interpreter, code int) {
+	for _, err := range i.Close() {
+		fmt.Println(err.String())
+	}
+
+	os.Exit(code)
+}
+
+// prints the expansions of the expressions in the input
+func expand(i *interpreter.Interpreter, input string) {
+	for _, res := range i.ExpandString(input) {
+		if res.Err != nil {
+			fmt.Println(res.Err.String())
+		} else {
+			fmt.Println(parser.CodeString(res.Value))
+		}
+	}
+}
+
+// prints the global bindings with their kinds, arities and docstrings
+func printBindings(i *interpreter.Interpreter) {
+	for _, info := range i.Bindings() {
+		line := fmt.Sprintf("%s\t%s", info.Name, info.Kind)
+		if info.Arity >= 0 {
+			line += fmt.Sprintf("\t%d", info.Arity)
+		}
+		if len(info.Doc) != 0 {
+			line += "\t" + info.Doc
+		}
+		fmt.Println(line)
+	}
+}
+
+// prints the last error with the procedures being applied when it occured
+func printBacktrace(i *interpreter.Interpreter) {
+	err := i.LastError()
+	if err == nil {
+		fmt.Println("no errors so far")
+		return
+	}
+
+	fmt.Println(err.String())
+	for _, frame := range err.Stack {
+		fmt.Println("  in " + frame)
+	}
+}
+
+// restores the global bindings saved by the checkpoint, if there is one
+func rollback(i *interpreter.Interpreter, checkpoint *interpreter.Snapshot) {
+	if checkpoint == nil {
+		fmt.Println("no checkpoint to roll back to, save one with " + checkpointCommand)
+		return
+	}
+
+	i.Restore(checkpoint)
+	fmt.Printf("rolled back to the checkpoint, %d bindings\n", checkpoint.Len())
+}
+
+// prints where the global binding with the given name was defined
+func printWhere(i *interpreter.Interpreter, name string) {
+	if loc, ok := i.Where(name); ok {
+		fmt.Println(loc)
+	} else {
+		fmt.Printf("%s: definition location unknown\n", name)
+	}
+}
+
+// prints the reference of the builtin procedures as a markdown table
+func printDocs(i *interpreter.Interpreter) {
+	fmt.Println("| Usage | Contract | Description |")
+	fmt.Println("| --- | --- | --- |")
+	for _, info := range i.Bindings() {
+		if info.Kind != interpreter.BindingBuiltin {
+			continue
 		}
+		usage := strings.ReplaceAll(info.Usage, "|", "\\|")
+		fmt.Printf("| `%s` | `%s` | %s |\n", usage, info.Contract, info.Doc)
 	}
 }
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/dimbata23/golang-scheme-interpreter/pkg/interpreter"
+)
+
+// runs the program given as a directory with a main.scm, or as a file
+const runCommand = "run"
+
+// runs the program given by the first argument with the rest of the
+// arguments as its command-line arguments and exits with its exit code,
+// e.g. `interpreter run ./src input.txt`
+func runProgram(i *interpreter.Interpreter, args []string) {
+	if len(args) == 0 {
+		fmt.Println("usage: interpreter run <directory or file> [args...]")
+		shutdown(i, 2)
+	}
+
+	code, err := i.Run(args[0], args[1:])
+	if err != nil {
+		fmt.Println(err.String())
+	}
+
+	shutdown(i, code)
+}
@@ -2,15 +2,16 @@ package main
 
 import (
 	"fmt"
-	"io/ioutil"
+	"os"
 
 	"github.com/dimbata23/golang-scheme-interpreter/pkg/lexer"
 )
 
 func main() {
-	str, err := ioutil.ReadFile("test/testfile.scm")
+	in, err := os.Open("test/testfile.scm")
 	if err == nil {
-		l := lexer.NewLexer(string(str))
+		defer in.Close()
+		l := lexer.NewLexerFromReader(in)
 		fmt.Println("Lexing...")
 		for {
 			token := l.NextToken()
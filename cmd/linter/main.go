@@ -0,0 +1,60 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/dimbata23/golang-scheme-interpreter/pkg/analyzer"
+	"github.com/dimbata23/golang-scheme-interpreter/pkg/parser"
+)
+
+// analyzes the files given as arguments printing the found problems
+// as `file:line:col: warning: message`, exits with 1 if there are any
+// the syntax errors are printed as `file:line:col: error: message`
+// with -closures the variables captured by every closure are printed too
+func main() {
+	closures := flag.Bool("closures", false, "print the local variables captured by every closure")
+	flag.Parse()
+
+	found := false
+	for _, file := range flag.Args() {
+		in, err := os.Open(file)
+		if err != nil {
+			fmt.Println(err.Error())
+			found = true
+			continue
+		}
+
+		p := parser.NewParserFromReader(in)
+		p.SetRecovery(true)
+		for {
+			expr, _ := p.Next()
+			if expr == nil {
+				break
+			}
+
+			for _, diag := range analyzer.Analyze(expr) {
+				found = true
+				fmt.Printf("%s:%s\n", file, diag)
+			}
+
+			if *closures {
+				for _, closure := range analyzer.Closures(expr) {
+					fmt.Printf("%s:%s\n", file, closure)
+				}
+			}
+		}
+		in.Close()
+
+		// the syntax errors of the file after its warnings
+		for _, err := range p.Errors() {
+			found = true
+			fmt.Printf("%s:%s: error: %s\n", file, err.Pos, err.Val)
+		}
+	}
+
+	if found {
+		os.Exit(1)
+	}
+}
@@ -2,30 +2,45 @@ package main
 
 import (
 	"fmt"
-	"io/ioutil"
+	"os"
 
 	"github.com/dimbata23/golang-scheme-interpreter/pkg/parser"
 )
 
+// parses the file given as an argument, test/testfile.scm by default,
+// printing every top-level expression and error prefixed by its location
+// as `file:line:col:` so the output can be used by editors
 func main() {
-	str, err := ioutil.ReadFile("test/testfile.scm")
-	if err == nil {
-		p := parser.NewParser(string(str))
-		fmt.Println("Parsing...")
-		for {
-			expr, err := p.Next()
-			if expr == nil {
-				break
-			}
+	file := "test/testfile.scm"
+	if len(os.Args) > 1 {
+		file = os.Args[1]
+	}
 
-			if err != nil {
-				fmt.Println(err.String())
-			} else {
-				fmt.Println(expr.String(0))
-			}
-		}
-		fmt.Println("Done.")
-	} else {
+	in, err := os.Open(file)
+	if err != nil {
 		fmt.Println(err.Error())
+		os.Exit(1)
+	}
+	defer in.Close()
+
+	p := parser.NewParserFromReader(in)
+	failed := false
+	for {
+		expr, err := p.Next()
+		if expr == nil {
+			break
+		}
+
+		if err != nil {
+			failed = true
+			fmt.Printf("%s:%s: %s\n", file, p.Pos(), err.String())
+		} else {
+			fmt.Printf("%s:%s: %s\n", file, p.Pos(), parser.CodeString(expr))
+		}
+	}
+
+	if failed {
+		in.Close()
+		os.Exit(1)
 	}
 }
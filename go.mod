@@ -1,3 +1,5 @@
 module github.com/dimbata23/golang-scheme-interpreter
 
 go 1.17
+
+require golang.org/x/text v0.3.7
@@ -0,0 +1,207 @@
+// An analysis pass over parsed scheme code reporting likely mistakes
+// as warnings without evaluating the code
+package analyzer
+
+import (
+	"fmt"
+
+	"github.com/dimbata23/golang-scheme-interpreter/pkg/lexer"
+	p "github.com/dimbata23/golang-scheme-interpreter/pkg/parser"
+)
+
+/// ------------------------------------------------------------------------ ///
+/// -------------------------- Public definitions -------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// a warning about the analyzed code
+type Diagnostic struct {
+	Pos lexer.Position // where the problem is in the input
+	Msg string         // description of the problem
+}
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------- Public functions and methods --------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// analyzes the expression and all expressions inside of it
+// returns the found problems in the order they appear in
+func Analyze(expr p.Expression) []Diagnostic {
+	an := analyzer{}
+	an.expr(expr)
+	return an.diags
+}
+
+// returns the diagnostic as `line:col: warning: message`
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%s: warning: %s", d.Pos, d.Msg)
+}
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------------- Inner structure ---------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// state of an analysis
+type analyzer struct {
+	diags []Diagnostic // the problems found so far
+}
+
+/// ------------------------------------------------------------------------ ///
+/// -------------------- Private functions and methods --------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// records a problem at the given position
+func (an *analyzer) warn(pos lexer.Position, format string, args ...interface{}) {
+	an.diags = append(an.diags, Diagnostic{Pos: pos, Msg: fmt.Sprintf(format, args...)})
+}
+
+// analyzes an expression
+func (an *analyzer) expr(expr p.Expression) {
+	lst, isCode := codeList(expr)
+	if !isCode || len(lst.Lst) == 0 {
+		return
+	}
+
+	head, _ := lst.Lst[0].(*p.Variable)
+	if head == nil {
+		an.exprs(lst.Lst)
+		return
+	}
+
+	switch head.Val {
+	case "if":
+		an.ifForm(lst)
+	case "cond":
+		an.condForm(lst)
+	case "begin":
+		an.body(lst.Lst[1:])
+	case "lambda":
+		if len(lst.Lst) > 2 {
+			an.body(lst.Lst[2:])
+		}
+	case "define":
+		an.defineForm(lst)
+	default:
+		an.exprs(lst.Lst[1:])
+	}
+}
+
+// analyzes every expression
+func (an *analyzer) exprs(exprs []interface{ p.Expression }) {
+	for _, expr := range exprs {
+		an.expr(expr)
+	}
+}
+
+// analyzes a sequence of expressions evaluated one after another
+// reporting the ones after a guaranteed error as unreachable
+func (an *analyzer) body(exprs []interface{ p.Expression }) {
+	for i, expr := range exprs {
+		an.expr(expr)
+
+		if isErrorCall(expr) && i+1 < len(exprs) {
+			an.warn(posOf(exprs[i+1], posOf(expr, lexer.Position{})), "unreachable code after a call to `error`")
+			an.exprs(exprs[i+1:])
+			return
+		}
+	}
+}
+
+// (define <identifier> <expression>)
+// or
+// (define (<lambda name> [args...]) <lambda body expressions...>)
+func (an *analyzer) defineForm(lst *p.ExprList) {
+	if len(lst.Lst) > 2 {
+		if _, isSign := codeList(lst.Lst[1]); isSign {
+			an.body(lst.Lst[2:])
+			return
+		}
+	}
+
+	an.exprs(lst.Lst[1:])
+}
+
+// (if <condition> <true case> [false case])
+func (an *analyzer) ifForm(lst *p.ExprList) {
+	if len(lst.Lst) > 1 {
+		if val, isConst := constantTruth(lst.Lst[1]); isConst {
+			an.warn(lst.Pos, "the condition of `if` is always %s", truthName(val))
+		}
+	}
+
+	an.exprs(lst.Lst[1:])
+}
+
+// (cond (<clause condition> <clause result>) ... [(else <clause result>)])
+func (an *analyzer) condForm(lst *p.ExprList) {
+	afterElse := false
+	for _, ex := range lst.Lst[1:] {
+		clause, isCode := codeList(ex)
+		if !isCode || len(clause.Lst) == 0 {
+			continue
+		}
+
+		if afterElse {
+			an.warn(clause.Pos, "`cond` clause after `else` is never reached")
+		}
+
+		if test, isVar := clause.Lst[0].(*p.Variable); isVar && test.Val == "else" {
+			afterElse = true
+		} else {
+			an.expr(clause.Lst[0])
+		}
+
+		an.body(clause.Lst[1:])
+	}
+}
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------------- Helper functions --------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// returns the expression as a list of code (not quoted data)
+func codeList(expr p.Expression) (lst *p.ExprList, isCode bool) {
+	lst, isLst := expr.(*p.ExprList)
+	return lst, isLst && lst.Qlevel == 0
+}
+
+// returns the position of the expression if it is a list or the default
+func posOf(expr p.Expression, def lexer.Position) lexer.Position {
+	if lst, isLst := expr.(*p.ExprList); isLst {
+		return lst.Pos
+	}
+
+	return def
+}
+
+// tests whether the expression is a call to `error` which never returns
+func isErrorCall(expr p.Expression) bool {
+	lst, isCode := codeList(expr)
+	if !isCode || len(lst.Lst) == 0 {
+		return false
+	}
+
+	head, isVar := lst.Lst[0].(*p.Variable)
+	return isVar && head.Val == "error"
+}
+
+// returns whether a constant counts as true and whether the expression is a constant
+func constantTruth(expr p.Expression) (val bool, isConst bool) {
+	switch ex := expr.(type) {
+	case *p.ExprList:
+		return true, ex.Qlevel > 0
+
+	case *p.Number, *p.String, *p.Char, *p.Symbol, *p.Boolean:
+		return p.Truthy(ex), true
+	}
+
+	return false, false
+}
+
+// returns the name of the truth value
+func truthName(val bool) string {
+	if val {
+		return "true"
+	}
+
+	return "false"
+}
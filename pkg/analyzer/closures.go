@@ -0,0 +1,456 @@
+package analyzer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dimbata23/golang-scheme-interpreter/pkg/lexer"
+	p "github.com/dimbata23/golang-scheme-interpreter/pkg/parser"
+)
+
+/// ------------------------------------------------------------------------ ///
+/// -------------------------- Public definitions -------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// a lambda capturing local variables of the scopes around it,
+// the variables are what a flat closure of it has to hold
+type Closure struct {
+	Pos      lexer.Position // where the lambda is in the input
+	Name     string         // name the lambda is defined with, empty if it isn't
+	Captured []string       // the captured variables in the order they first appear in
+}
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------- Public functions and methods --------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// returns the free variables of a lambda with the parameters and body,
+// the variables its body refers to which it doesn't bind, globals included,
+// in the order they first appear in
+func FreeVariables(params *p.ExprList, body []interface{ p.Expression }) []string {
+	return freeNames(paramsOf(params), body)
+}
+
+// returns the lambdas in the expression which capture local variables,
+// in the order they appear in
+func Closures(expr p.Expression) []Closure {
+	fv := freeVars{seen: map[string]bool{}, closures: &[]Closure{}}
+	fv.expr(expr, nil)
+	return *fv.closures
+}
+
+// returns the closure as `line:col: note: message`
+func (c Closure) String() string {
+	what := "lambda"
+	if c.Name != "" {
+		what = "`" + c.Name + "`"
+	}
+
+	count := fmt.Sprintf("%d variables", len(c.Captured))
+	if len(c.Captured) == 1 {
+		count = "1 variable"
+	}
+
+	return fmt.Sprintf("%s: note: %s captures %s: %s", c.Pos, what, count, strings.Join(c.Captured, ", "))
+}
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------------- Inner structure ---------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// local variables bound around the analyzed expression, the innermost
+// scope first, a nil scope is the global one
+type scope struct {
+	parent *scope
+	names  map[string]bool
+}
+
+// walk collecting the free variables of the expressions
+type freeVars struct {
+	names    []string        // the free variables in the order they first appear in
+	seen     map[string]bool // the free variables found so far
+	closures *[]Closure      // the capturing lambdas found so far, nil if they aren't collected
+}
+
+/// ------------------------------------------------------------------------ ///
+/// -------------------- Private functions and methods --------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// creates a scope inside the given one binding the names
+func newScope(parent *scope, names ...string) *scope {
+	sc := &scope{parent: parent, names: map[string]bool{}}
+	for _, name := range names {
+		sc.names[name] = true
+	}
+
+	return sc
+}
+
+// tests whether the name is bound by a local scope
+func (sc *scope) binds(name string) bool {
+	for ; sc != nil; sc = sc.parent {
+		if sc.names[name] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// records a reference to the variable unless it is bound
+func (fv *freeVars) ref(name string, sc *scope) {
+	if sc.binds(name) || fv.seen[name] {
+		return
+	}
+
+	fv.seen[name] = true
+	fv.names = append(fv.names, name)
+}
+
+// collects the free variables of an expression
+func (fv *freeVars) expr(expr p.Expression, sc *scope) {
+	if v, isVar := expr.(*p.Variable); isVar {
+		fv.ref(v.Val, sc)
+		return
+	}
+
+	lst, isCode := codeList(expr)
+	if !isCode || len(lst.Lst) == 0 {
+		return
+	}
+
+	head, _ := lst.Lst[0].(*p.Variable)
+	if head == nil || sc.binds(head.Val) {
+		fv.exprs(lst.Lst, sc)
+		return
+	}
+
+	args := lst.Lst[1:]
+	switch head.Val {
+	case "quote", "define-syntax", "define-macro", "define-record-type":
+	case "if", "begin", "set!", "and", "or", "load", "with-continuation-mark", "delay":
+		fv.exprs(args, sc)
+	case "lambda":
+		if len(args) > 0 {
+			fv.closure(lst.Pos, "", paramsOf(args[0]), args[1:], sc)
+		}
+	case "define":
+		fv.define(lst, sc)
+	case "let":
+		fv.let(args, sc)
+	case "let*":
+		fv.letStar(args, sc)
+	case "letrec", "letrec*":
+		fv.letrec(args, sc)
+	case "cond":
+		fv.cond(args, sc)
+	case "cond-expand":
+		for _, clause := range args {
+			if lst, isCode := codeList(clause); isCode && len(lst.Lst) > 0 {
+				fv.body(lst.Lst[1:], sc)
+			}
+		}
+	case "match":
+		fv.match(args, sc)
+	default:
+		fv.exprs(lst.Lst, sc)
+	}
+}
+
+// collects the free variables of every expression
+func (fv *freeVars) exprs(exprs []interface{ p.Expression }, sc *scope) {
+	for _, expr := range exprs {
+		fv.expr(expr, sc)
+	}
+}
+
+// collects the free variables of a body, the definitions in it
+// are bound in the whole body
+func (fv *freeVars) body(exprs []interface{ p.Expression }, sc *scope) {
+	names := []string{}
+	for _, expr := range exprs {
+		if name, isDef := definedName(expr); isDef {
+			names = append(names, name)
+		}
+		names = append(names, recordNames(expr)...)
+	}
+
+	if len(names) != 0 {
+		sc = newScope(sc, names...)
+	}
+
+	fv.exprs(exprs, sc)
+}
+
+// collects the free variables of a lambda with the parameters,
+// which are bound in its body
+func (fv *freeVars) lambda(params []string, body []interface{ p.Expression }, sc *scope) {
+	fv.body(body, newScope(sc, params...))
+}
+
+// collects the free variables of a lambda in the given scope and records it
+// as a closure if it captures any of the local variables of the scope
+func (fv *freeVars) closure(pos lexer.Position, name string, params []string, body []interface{ p.Expression }, sc *scope) {
+	if fv.closures == nil || sc == nil {
+		fv.lambda(params, body, sc)
+		return
+	}
+
+	captured := []string{}
+	for _, v := range freeNames(params, body) {
+		if sc.binds(v) {
+			captured = append(captured, v)
+		}
+	}
+
+	if len(captured) != 0 {
+		*fv.closures = append(*fv.closures, Closure{Pos: pos, Name: name, Captured: captured})
+	}
+
+	// the lambdas inside of it are recorded after it
+	fv.lambda(params, body, sc)
+}
+
+// (define <identifier> <expression>)
+// or
+// (define (<lambda name> [args...]) <lambda body expressions...>)
+func (fv *freeVars) define(lst *p.ExprList, sc *scope) {
+	if len(lst.Lst) < 2 {
+		return
+	}
+
+	sign, isSign := codeList(lst.Lst[1])
+	if !isSign || len(sign.Lst) == 0 {
+		fv.exprs(lst.Lst[2:], sc)
+		return
+	}
+
+	name := ""
+	if v, isVar := sign.Lst[0].(*p.Variable); isVar {
+		name = v.Val
+	}
+
+	params := &p.ExprList{Lst: sign.Lst[1:], Tail: sign.Tail}
+	fv.closure(lst.Pos, name, paramsOf(params), lst.Lst[2:], sc)
+}
+
+// (let [name] ((<variable> <expression>) ...) <body expressions...>)
+func (fv *freeVars) let(args []interface{ p.Expression }, sc *scope) {
+	name := ""
+	if len(args) > 0 {
+		if v, isVar := args[0].(*p.Variable); isVar {
+			name, args = v.Val, args[1:]
+		}
+	}
+
+	if len(args) == 0 {
+		return
+	}
+
+	names, inits := bindingsOf(args[0])
+	fv.exprs(inits, sc)
+	if name != "" {
+		names = append(names, name)
+	}
+	fv.body(args[1:], newScope(sc, names...))
+}
+
+// (let* ((<variable> <expression>) ...) <body expressions...>)
+// every binding is in the scope of the ones before it
+func (fv *freeVars) letStar(args []interface{ p.Expression }, sc *scope) {
+	if len(args) == 0 {
+		return
+	}
+
+	names, inits := bindingsOf(args[0])
+	for i, init := range inits {
+		fv.expr(init, sc)
+		sc = newScope(sc, names[i])
+	}
+	fv.body(args[1:], sc)
+}
+
+// (letrec ((<variable> <expression>) ...) <body expressions...>)
+// every binding is in the scope of all of them
+func (fv *freeVars) letrec(args []interface{ p.Expression }, sc *scope) {
+	if len(args) == 0 {
+		return
+	}
+
+	names, inits := bindingsOf(args[0])
+	sc = newScope(sc, names...)
+	fv.exprs(inits, sc)
+	fv.body(args[1:], sc)
+}
+
+// (cond (<clause condition> <clause result>) ... [(else <clause result>)])
+func (fv *freeVars) cond(clauses []interface{ p.Expression }, sc *scope) {
+	for _, ex := range clauses {
+		clause, isCode := codeList(ex)
+		if !isCode {
+			continue
+		}
+
+		for _, item := range clause.Lst {
+			if v, isVar := item.(*p.Variable); isVar && (v.Val == "else" || v.Val == "=>") {
+				continue
+			}
+			fv.expr(item, sc)
+		}
+	}
+}
+
+// (match <expression> (<pattern> <body expressions...>) ...)
+// every identifier in a pattern is taken to be bound by it
+func (fv *freeVars) match(args []interface{ p.Expression }, sc *scope) {
+	if len(args) == 0 {
+		return
+	}
+
+	fv.expr(args[0], sc)
+	for _, ex := range args[1:] {
+		clause, isCode := codeList(ex)
+		if !isCode || len(clause.Lst) == 0 {
+			continue
+		}
+
+		fv.body(clause.Lst[1:], newScope(sc, patternNames(clause.Lst[0])...))
+	}
+}
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------------- Helper functions --------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// returns the names of the parameters of a lambda,
+// a list of them with an optional rest parameter or a single rest parameter
+func paramsOf(expr p.Expression) []string {
+	if v, isVar := expr.(*p.Variable); isVar {
+		return []string{v.Val}
+	}
+
+	lst, isCode := codeList(expr)
+	if !isCode {
+		return nil
+	}
+
+	names := []string{}
+	for _, param := range append(lst.Lst, lst.Tail) {
+		if v, isVar := param.(*p.Variable); isVar {
+			names = append(names, v.Val)
+		}
+	}
+
+	return names
+}
+
+// returns the names and the expressions of the bindings of a let form
+func bindingsOf(expr p.Expression) (names []string, inits []interface{ p.Expression }) {
+	lst, isCode := codeList(expr)
+	if !isCode {
+		return nil, nil
+	}
+
+	for _, ex := range lst.Lst {
+		binding, isCode := codeList(ex)
+		if !isCode || len(binding.Lst) == 0 {
+			continue
+		}
+
+		if v, isVar := binding.Lst[0].(*p.Variable); isVar {
+			names = append(names, v.Val)
+			inits = append(inits, &p.NullSym)
+			if len(binding.Lst) > 1 {
+				inits[len(inits)-1] = binding.Lst[1]
+			}
+		}
+	}
+
+	return names, inits
+}
+
+// returns the name defined by the expression if it is a definition
+func definedName(expr p.Expression) (name string, isDef bool) {
+	lst, isCode := codeList(expr)
+	if !isCode || len(lst.Lst) < 2 {
+		return "", false
+	}
+
+	head, isVar := lst.Lst[0].(*p.Variable)
+	if !isVar || head.Val != "define" && head.Val != "define-syntax" && head.Val != "define-macro" {
+		return "", false
+	}
+
+	target := lst.Lst[1]
+	if sign, isSign := codeList(target); isSign && len(sign.Lst) > 0 {
+		target = sign.Lst[0]
+	}
+
+	v, isVar := target.(*p.Variable)
+	if !isVar {
+		return "", false
+	}
+
+	return v.Val, true
+}
+
+// returns the names defined by the expression if it is a record type
+// definition, the name of the type and of its procedures
+func recordNames(expr p.Expression) (names []string) {
+	lst, isCode := codeList(expr)
+	if !isCode || len(lst.Lst) < 4 {
+		return nil
+	}
+
+	if head, isVar := lst.Lst[0].(*p.Variable); !isVar || head.Val != "define-record-type" {
+		return nil
+	}
+
+	add := func(expr p.Expression) {
+		if v, isVar := expr.(*p.Variable); isVar {
+			names = append(names, v.Val)
+		}
+	}
+
+	add(lst.Lst[1])
+	if ctor, isCode := codeList(lst.Lst[2]); isCode && len(ctor.Lst) > 0 {
+		add(ctor.Lst[0])
+	}
+	add(lst.Lst[3])
+
+	for _, field := range lst.Lst[4:] {
+		if spec, isCode := codeList(field); isCode && len(spec.Lst) > 1 {
+			for _, proc := range spec.Lst[1:] {
+				add(proc)
+			}
+		}
+	}
+
+	return names
+}
+
+// returns the identifiers of a match pattern, leaving out quoted data
+func patternNames(expr p.Expression) []string {
+	if v, isVar := expr.(*p.Variable); isVar {
+		return []string{v.Val}
+	}
+
+	lst, isCode := codeList(expr)
+	if !isCode {
+		return nil
+	}
+
+	names := []string{}
+	for _, item := range lst.Lst {
+		names = append(names, patternNames(item)...)
+	}
+
+	return names
+}
+
+// returns the free variables of a lambda with the parameters and body
+func freeNames(params []string, body []interface{ p.Expression }) []string {
+	fv := freeVars{seen: map[string]bool{}}
+	fv.lambda(params, body, nil)
+	return fv.names
+}
@@ -0,0 +1,239 @@
+package interpreter
+
+import (
+	"math"
+	"math/big"
+
+	p "github.com/dimbata23/golang-scheme-interpreter/pkg/parser"
+)
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------------- Inner structure ---------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// rank of a number in the promotion lattice, the operands of an operation
+// are promoted to the highest rank among them before it is applied, so an
+// operation with an inexact operand has an inexact result
+// exact integers are fixnums while they fit exactly in a float64 and
+// bignums once they don't, promoting between the two is automatic
+type numKind int
+
+const (
+	kindInteger  numKind = iota // exact integer, a fixnum or a bignum
+	kindRational                // exact fraction which isn't an integer
+	kindReal                    // inexact real number, computed with floats
+)
+
+// arithmetic operation applied by arith
+type arithOp int
+
+const (
+	opAdd arithOp = iota
+	opSub
+	opMul
+	opDiv
+)
+
+// the largest magnitude of a fixnum, every integer up to it is exactly a float64
+const maxFixnum = 1 << 53
+
+// the largest magnitude of the fixnums whose product is always a fixnum
+const maxFixnumFactor = 1 << 26
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------------- Helper functions --------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// returns the given expressions as numbers or an error
+func toNumbers(procName string, args []interface{ p.Expression }) (nums []*p.Number, err *p.Error) {
+	nums = make([]*p.Number, len(args))
+	for i, arg := range args {
+		num, isNum := arg.(*p.Number)
+		if !isNum {
+			return nil, newError(errContractViolation, procName, "number?", errString(arg))
+		}
+		nums[i] = num
+	}
+
+	return nums, nil
+}
+
+// returns the rank of the number in the promotion lattice
+func kindOf(num *p.Number) numKind {
+	switch {
+	case num.Inexact:
+		return kindReal
+	case num.Ratio != nil:
+		return kindRational
+	}
+
+	return kindInteger
+}
+
+// applies the operation to the numbers promoted to the kind of the higher
+// ranked one, errors on an exact division by zero
+func arith(procName string, op arithOp, lhs *p.Number, rhs *p.Number) (res *p.Number, err *p.Error) {
+	switch maxKind(lhs, rhs) {
+	case kindReal:
+		return realArith(op, lhs.Val, rhs.Val), nil
+	case kindRational:
+		return rationalArith(procName, op, lhs, rhs)
+	}
+
+	return integerArith(procName, op, lhs, rhs)
+}
+
+// applies the operation to the exact integers, the quotient of integers
+// which don't divide each other is a rational
+func integerArith(procName string, op arithOp, lhs *p.Number, rhs *p.Number) (res *p.Number, err *p.Error) {
+	if lhs.Exact == nil && rhs.Exact == nil {
+		a, b := int64(lhs.Val), int64(rhs.Val)
+		switch {
+		case op == opAdd:
+			return newInteger64(a + b), nil
+		case op == opSub:
+			return newInteger64(a - b), nil
+		case op == opMul && abs64(a) < maxFixnumFactor && abs64(b) < maxFixnumFactor:
+			return newInteger64(a * b), nil
+		}
+	}
+
+	a, b := toBigInt(lhs), toBigInt(rhs)
+	switch op {
+	case opAdd:
+		return p.NewInteger(a.Add(a, b)), nil
+	case opSub:
+		return p.NewInteger(a.Sub(a, b)), nil
+	case opMul:
+		return p.NewInteger(a.Mul(a, b)), nil
+	}
+
+	if b.Sign() == 0 {
+		return nil, &p.Error{Val: procName + ": division by zero"}
+	}
+
+	return p.NewRational(new(big.Rat).SetFrac(a, b)), nil
+}
+
+// applies the operation to the exact rationals
+func rationalArith(procName string, op arithOp, lhs *p.Number, rhs *p.Number) (res *p.Number, err *p.Error) {
+	a, b := toBigRat(lhs), toBigRat(rhs)
+	switch op {
+	case opAdd:
+		return p.NewRational(a.Add(a, b)), nil
+	case opSub:
+		return p.NewRational(a.Sub(a, b)), nil
+	case opMul:
+		return p.NewRational(a.Mul(a, b)), nil
+	}
+
+	if b.Sign() == 0 {
+		return nil, &p.Error{Val: procName + ": division by zero"}
+	}
+
+	return p.NewRational(a.Quo(a, b)), nil
+}
+
+// applies the operation to the reals, the result is inexact
+func realArith(op arithOp, lhs float64, rhs float64) *p.Number {
+	switch op {
+	case opAdd:
+		return p.NewReal(lhs + rhs)
+	case opSub:
+		return p.NewReal(lhs - rhs)
+	case opMul:
+		return p.NewReal(lhs * rhs)
+	}
+
+	return p.NewReal(lhs / rhs)
+}
+
+// compares the numbers promoted to the kind of the higher ranked one
+// returns -1, 0 or 1 as lhs is less than, equal to or greater than rhs,
+// ok is false if the numbers are unordered, i.e. one of them is NaN
+func compareNumbers(lhs *p.Number, rhs *p.Number) (cmp int, ok bool) {
+	if math.IsNaN(lhs.Val) || math.IsNaN(rhs.Val) {
+		return 0, false
+	}
+
+	if lhs.Exact == nil && rhs.Exact == nil && lhs.Ratio == nil && rhs.Ratio == nil {
+		switch {
+		case lhs.Val < rhs.Val:
+			return -1, true
+		case lhs.Val > rhs.Val:
+			return 1, true
+		}
+		return 0, true
+	}
+
+	if kindOf(lhs) == kindInteger && kindOf(rhs) == kindInteger {
+		return toBigInt(lhs).Cmp(toBigInt(rhs)), true
+	}
+
+	// compared exactly unless one of them is infinite
+	if math.IsInf(lhs.Val, 0) || math.IsInf(rhs.Val, 0) {
+		return compareNumbers(p.NewReal(lhs.Val), p.NewReal(rhs.Val))
+	}
+
+	return toBigRat(lhs).Cmp(toBigRat(rhs)), true
+}
+
+// returns the higher rank of the two numbers in the promotion lattice
+func maxKind(lhs *p.Number, rhs *p.Number) numKind {
+	if kind := kindOf(lhs); kind > kindOf(rhs) {
+		return kind
+	}
+
+	return kindOf(rhs)
+}
+
+// returns the value of the exact integer as a big integer
+func toBigInt(num *p.Number) *big.Int {
+	if num.Exact != nil {
+		return new(big.Int).Set(num.Exact)
+	}
+
+	return big.NewInt(int64(num.Val))
+}
+
+// returns the value of the finite number as a big rational
+func toBigRat(num *p.Number) *big.Rat {
+	switch {
+	case num.Ratio != nil:
+		return new(big.Rat).Set(num.Ratio)
+	case num.Exact != nil:
+		return new(big.Rat).SetInt(num.Exact)
+	}
+
+	return new(big.Rat).SetFloat64(num.Val)
+}
+
+// creates an exact integer, a bignum if it doesn't fit in a fixnum
+func newInteger64(val int64) *p.Number {
+	if abs64(val) > maxFixnum {
+		return p.NewInteger(big.NewInt(val))
+	}
+
+	return p.NewNumber(float64(val))
+}
+
+// tests whether the number is an integer, exact or inexact
+func isInteger(num *p.Number) bool {
+	switch {
+	case num.Ratio != nil:
+		return false
+	case num.Inexact:
+		return !math.IsInf(num.Val, 0) && num.Val == math.Trunc(num.Val)
+	}
+
+	return true
+}
+
+// returns the absolute value of the integer
+func abs64(val int64) int64 {
+	if val < 0 {
+		return -val
+	}
+
+	return val
+}
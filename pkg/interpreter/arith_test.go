@@ -0,0 +1,172 @@
+package interpreter
+
+import (
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	p "github.com/dimbata23/golang-scheme-interpreter/pkg/parser"
+)
+
+// the operations of arith and the big rationals computing them exactly
+var exactOps = []struct {
+	name string
+	op   arithOp
+	rat  func(z, x, y *big.Rat) *big.Rat
+}{
+	{"+", opAdd, (*big.Rat).Add},
+	{"-", opSub, (*big.Rat).Sub},
+	{"*", opMul, (*big.Rat).Mul},
+	{"/", opDiv, (*big.Rat).Quo},
+}
+
+func TestArithPromotion(t *testing.T) {
+	checkEvals(t, []evalTest{
+		{"(+ 9007199254740992 1)", "9007199254740993"},
+		{"(* 9007199254740992 9007199254740992)", "81129638414606681695789005144064"},
+		{"(- 9007199254740993 2)", "9007199254740991"},
+		{"(exact? (* 4294967296 4294967296))", "#t"},
+		{"(- (* 4294967296 4294967296) 18446744073709551615)", "1"},
+		{"(/ 6 3)", "2"},
+		{"(/ 1 3)", "1/3"},
+		{"(+ 1/3 2/3)", "1"},
+		{"(* 1/9007199254740993 9007199254740993)", "1"},
+		{"(+ 1/2 9007199254740993)", "18014398509481987/2"},
+		{"(+ 1/2 0.5)", "1.0"},
+		{"(* 9007199254740993 1.0)", "9007199254740992.0"},
+		{"(inexact? (- 1.5 1/2))", "#t"},
+		{"(/ 1.0 0)", "+inf.0"},
+		{"(/ 1 0)", "/: division by zero"},
+		{"(/ 1/2 0)", "/: division by zero"},
+		{"(= 9007199254740993 9007199254740992.0)", "#f"},
+		{"(< 9007199254740992 9007199254740993)", "#t"},
+		{"(< 1/3 0.3333333333333333)", "#f"},
+		{"(< 1/3 +inf.0)", "#t"},
+		{"(= 1/2 0.5)", "#t"},
+		{"(< 1 +nan.0)", "#f"},
+	})
+}
+
+// the results of the operations on exact numbers are the exact results
+// in their smallest representation, with an inexact operand they are
+// the results computed with floats
+func TestArithProperties(t *testing.T) {
+	rnd := rand.New(rand.NewSource(4))
+	for n := 0; n < 5000; n++ {
+		lhs, rhs := randomOperand(rnd), randomOperand(rnd)
+		for _, test := range exactOps {
+			res, err := arith(test.name, test.op, lhs, rhs)
+			call := "(" + test.name + " " + p.WriteString(lhs) + " " + p.WriteString(rhs) + ")"
+			if lhs.Inexact || rhs.Inexact {
+				if err != nil || !res.Inexact {
+					t.Fatalf("%s isn't inexact", call)
+				}
+				if want := realArith(test.op, lhs.Val, rhs.Val); !equalFloats(res.Val, want.Val) {
+					t.Fatalf("%s = %s, want %s", call, p.WriteString(res), p.WriteString(want))
+				}
+				continue
+			}
+
+			if test.op == opDiv && toBigRat(rhs).Sign() == 0 {
+				if err == nil {
+					t.Fatalf("%s = %s, want a division by zero", call, p.WriteString(res))
+				}
+				continue
+			}
+
+			if err != nil {
+				t.Fatalf("%s failed: %s", call, err.Val)
+			}
+			want := test.rat(new(big.Rat), toBigRat(lhs), toBigRat(rhs))
+			checkExact(t, call, res, want)
+		}
+
+		cmp, ok := compareNumbers(lhs, rhs)
+		switch {
+		case math.IsNaN(lhs.Val) || math.IsNaN(rhs.Val):
+			if ok {
+				t.Fatalf("%s and %s are ordered", p.WriteString(lhs), p.WriteString(rhs))
+			}
+		case math.IsInf(lhs.Val, 0) || math.IsInf(rhs.Val, 0):
+		case !ok || cmp != toBigRat(lhs).Cmp(toBigRat(rhs)):
+			t.Fatalf("comparing %s and %s gives %d, %t", p.WriteString(lhs), p.WriteString(rhs), cmp, ok)
+		}
+	}
+}
+
+// reports the number if it isn't the exact value in its smallest
+// representation, a fixnum, a bignum or a fraction
+func checkExact(t *testing.T, call string, num *p.Number, want *big.Rat) {
+	t.Helper()
+
+	wantFloat, _ := want.Float64()
+	switch {
+	case num.Inexact:
+		t.Fatalf("%s = %s, want the exact %s", call, p.WriteString(num), want.RatString())
+	case !want.IsInt():
+		if num.Ratio == nil || num.Ratio.Cmp(want) != 0 {
+			t.Fatalf("%s = %s, want %s", call, p.WriteString(num), want.RatString())
+		}
+	case num.Ratio != nil:
+		t.Fatalf("%s = %s is a fraction, want the integer %s", call, p.WriteString(num), want.RatString())
+	case want.Num().CmpAbs(big.NewInt(maxFixnum)) > 0:
+		if num.Exact == nil || num.Exact.Cmp(want.Num()) != 0 {
+			t.Fatalf("%s = %s, want the bignum %s", call, p.WriteString(num), want.RatString())
+		}
+	case num.Exact != nil || num.Val != wantFloat:
+		t.Fatalf("%s = %s, want the fixnum %s", call, p.WriteString(num), want.RatString())
+	}
+}
+
+// tests whether the floats are the same, NaN being the same as itself
+func equalFloats(lhs float64, rhs float64) bool {
+	return lhs == rhs || math.IsNaN(lhs) && math.IsNaN(rhs)
+}
+
+// returns a random number for the operations, sometimes a fixnum next to
+// the largest one, zero or a special real
+func randomOperand(rnd *rand.Rand) *p.Number {
+	switch rnd.Intn(8) {
+	case 0:
+		return p.NewNumber(float64((maxFixnum - rnd.Int63n(4)) * (1 - 2*rnd.Int63n(2))))
+	case 1:
+		return p.NewNumber(float64(rnd.Int63n(2*maxFixnumFactor) - maxFixnumFactor))
+	case 2:
+		return p.NewNumber(float64(rnd.Int63n(3) - 1))
+	case 3:
+		return p.NewReal([]float64{0, math.Inf(1), math.Inf(-1), math.NaN()}[rnd.Intn(4)])
+	}
+
+	return randomNumber(rnd)
+}
+
+// the math functions give exact results at the exact arguments whose
+// results are exact, inexact ones otherwise
+func TestMathExactness(t *testing.T) {
+	checkEvals(t, []evalTest{
+		{"(exp 0)", "1"},
+		{"(exp 0.0)", "1.0"},
+		{"(exp 1)", "2.718281828459045"},
+		{"(log 1)", "0"},
+		{"(log 1.0)", "0.0"},
+		{"(log 1 10)", "0"},
+		{"(log 1 10.0)", "0.0"},
+		{"(log 1 1)", "+nan.0"},
+		{"(log 8 2)", "3.0"},
+		{"(sin 0)", "0"},
+		{"(sin 0.0)", "0.0"},
+		{"(cos 0)", "1"},
+		{"(cos 0.0)", "1.0"},
+		{"(tan 0)", "0"},
+		{"(asin 0)", "0"},
+		{"(acos 1)", "0"},
+		{"(acos 1.0)", "0.0"},
+		{"(atan 0)", "0"},
+		{"(atan 0 1)", "0"},
+		{"(atan 0 -1)", "3.141592653589793"},
+		{"(atan 0 1.0)", "0.0"},
+		{"(exact? (sin 1))", "#f"},
+		{"(exp 9007199254740993)", "+inf.0"},
+	})
+}
@@ -0,0 +1,137 @@
+package interpreter
+
+import (
+	"sort"
+
+	"github.com/dimbata23/golang-scheme-interpreter/pkg/lexer"
+	p "github.com/dimbata23/golang-scheme-interpreter/pkg/parser"
+)
+
+/// ------------------------------------------------------------------------ ///
+/// -------------------------- Public definitions -------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// kind of the value a binding is bound to
+type BindingKind int
+
+const (
+	BindingBuiltin BindingKind = iota // a procedure implemented by the interpreter
+	BindingLambda                     // a lambda defined in scheme
+	BindingValue                      // any other value
+)
+
+// description of a global binding
+type BindingInfo struct {
+	Name     string      // name of the binding
+	Kind     BindingKind // kind of the bound value
+	Arity    int         // number of parameters, -1 if unknown or variable
+	Doc      string      // docstring of a lambda or the documentation of a builtin
+	Usage    string      // how a call of a builtin is written
+	Contract string      // contracts of the arguments and the result of a builtin
+	Where    Location    // where the binding was defined, zero for builtins
+}
+
+// place in the source where something is defined
+type Location struct {
+	File string         // name of the loaded file, empty for interpreted input
+	Pos  lexer.Position // position in the file or the input
+}
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------- Public functions and methods --------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// returns the descriptions of all global bindings sorted by their names
+// the docstring of a lambda is a string literal starting a body
+// which has other expressions after it, builtins are described by
+// the registry of the interpreter
+func (i *Interpreter) Bindings() []BindingInfo {
+	res := make([]BindingInfo, 0, len(i.genv.vars))
+	for name, val := range i.genv.vars {
+		res = append(res, i.bindingInfo(name, val))
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Name < res[j].Name
+	})
+
+	return res
+}
+
+// returns where the global binding with the given name was last defined
+// and whether it is known, it isn't for builtins and bindings made from Go
+func (i *Interpreter) Where(name string) (loc Location, ok bool) {
+	loc, ok = i.definitions[name]
+	return loc, ok
+}
+
+/// ------------------------------------------------------------------------ ///
+/// -------------------- Private functions and methods --------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// returns the description of the global binding of the name to the value
+func (i *Interpreter) bindingInfo(name string, val p.Expression) BindingInfo {
+	info := BindingInfo{Name: name, Kind: BindingValue, Arity: -1, Where: i.definitions[name]}
+
+	switch v := val.(type) {
+	case *p.Procedure:
+		info.Kind = BindingBuiltin
+		if b, isBuiltin := i.registry[v.Name]; isBuiltin {
+			if b.minArgs == b.maxArgs {
+				info.Arity = b.minArgs
+			}
+			info.Doc = b.doc
+			info.Usage = b.usage()
+			info.Contract = b.contract
+		}
+
+	case *p.Lambda:
+		info.Kind = BindingLambda
+		info.Arity = len(v.Params.Lst)
+		if v.Params.Tail != nil {
+			info.Arity = -1
+		}
+		if body := v.Body.Lst; len(body) > 1 {
+			if doc, isStr := p.AsString(body[0]); isStr {
+				info.Doc = doc
+			}
+		}
+	}
+
+	return info
+}
+
+// records that the global binding has been defined at the given position
+// of the source being interpreted
+func (i *Interpreter) defined(name string, pos lexer.Position) {
+	if i.definitions == nil {
+		i.definitions = make(map[string]Location)
+	}
+
+	i.definitions[name] = Location{File: i.source, Pos: pos}
+}
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------------- String() methods --------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+func (kind BindingKind) String() string {
+	switch kind {
+	case BindingBuiltin:
+		return "builtin"
+	case BindingLambda:
+		return "lambda"
+	}
+
+	return "value"
+}
+
+// printed as `<file>:<line>:<column>`, `<input>` stands for interpreted input
+func (loc Location) String() string {
+	file := loc.File
+	if len(file) == 0 {
+		file = "<input>"
+	}
+
+	return file + ":" + loc.Pos.String()
+}
@@ -0,0 +1,333 @@
+package interpreter
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	p "github.com/dimbata23/golang-scheme-interpreter/pkg/parser"
+)
+
+// declaration of a builtin procedure, the registry of the interpreter is
+// made of them and both the global bindings and the help are produced from it
+type builtin struct {
+	name     string                                     // name the procedure is bound to
+	fn       func(*p.ExprList) (p.Expression, *p.Error) // the implementation
+	minArgs  int                                        // least number of arguments
+	maxArgs  int                                        // most number of arguments, or variadic
+	args     string                                     // the arguments as they are written in a call
+	contract string                                     // contracts of the arguments and the result
+	doc      string                                     // what the procedure does
+}
+
+// maximum number of arguments of a builtin taking any number of them
+const variadic = -1
+
+/// ------------------------------------------------------------------------ ///
+/// -------------------- Private functions and methods --------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// returns the declarations of the builtin procedures of the interpreter
+func (i *Interpreter) builtinRegistry() []builtin {
+	return []builtin{
+		{"+", procAdd, 0, variadic, "[numbers...]", "number? ... -> number?", "adds the numbers"},
+		{"*", procMultiply, 0, variadic, "[numbers...]", "number? ... -> number?", "multiplies the numbers"},
+		{"-", procSubtract, 0, variadic, "[numbers...]", "number? ... -> number?", "subtracts the rest of the numbers from the first one, negates a single number"},
+		{"/", procDivide, 0, variadic, "[numbers...]", "number? ... -> number?", "divides the first number by the rest of them, inverts a single number"},
+		{"=", procEquals, 0, variadic, "[numbers...]", "number? ... -> boolean?", "tests whether the numbers are equal"},
+		{"<", procLess, 0, variadic, "[numbers...]", "number? ... -> boolean?", "tests whether the numbers are increasing"},
+		{"<=", procLessEq, 0, variadic, "[numbers...]", "number? ... -> boolean?", "tests whether the numbers are non-decreasing"},
+		{">", procGreater, 0, variadic, "[numbers...]", "number? ... -> boolean?", "tests whether the numbers are decreasing"},
+		{">=", procGreaterEq, 0, variadic, "[numbers...]", "number? ... -> boolean?", "tests whether the numbers are non-increasing"},
+		{"number?", procIsNumber, 1, 1, "<expression>", "any/c -> boolean?", "tests whether the expression is a number"},
+		{"null?", procIsNull, 1, 1, "<expression>", "any/c -> boolean?", "tests whether the expression is the empty list"},
+		{"boolean?", procIsBoolean, 1, 1, "<expression>", "any/c -> boolean?", "tests whether the expression is #t or #f"},
+		{"eq?", procIsEq, 2, 2, "<expression> <expression>", "any/c any/c -> boolean?", "tests whether the expressions are the same object"},
+		{"eqv?", procIsEqv, 2, 2, "<expression> <expression>", "any/c any/c -> boolean?", "tests whether the expressions are the same object or equal numbers or characters"},
+		{"equal?", procIsEqual, 2, 2, "<expression> <expression>", "any/c any/c -> boolean?", "tests whether the expressions are structurally equal"},
+		{"remainder", procRemainder, 2, 2, "<dividend> <divisor>", "integer? integer? -> integer?", "returns the remainder of the integer division"},
+		{"quotient", procQuotient, 2, 2, "<dividend> <divisor>", "integer? integer? -> integer?", "returns the quotient of the integer division"},
+		{"expt", i.procExpt, 2, 2, "<base> <exponent>", "number? number? -> number?", "raises the base to the exponent, integer powers of exact numbers are computed exactly"},
+		{"list", i.procList, 0, variadic, "[args...]", "any/c ... -> list?", "returns a list of the arguments"},
+		{"cons", i.procCons, 2, 2, "<first> <second>", "any/c any/c -> pair?", "returns a pair of the arguments"},
+		{"car", procCar, 1, 1, "<pair>", "pair? -> any/c", "returns the first element of the pair"},
+		{"cdr", procCdr, 1, 1, "<pair>", "pair? -> any/c", "returns the second element of the pair"},
+		{"set-car!", procSetCar, 2, 2, "<pair> <value>", "pair? any/c -> void?", "changes the first element of the pair"},
+		{"set-cdr!", procSetCdr, 2, 2, "<pair> <value>", "pair? any/c -> void?", "changes the second element of the pair"},
+		{"pair?", procIsPair, 1, 1, "<expression>", "any/c -> boolean?", "tests whether the expression is a pair"},
+		{"list?", procIsList, 1, 1, "<expression>", "any/c -> boolean?", "tests whether the expression is a proper list"},
+		{"length", procLength, 1, 1, "<list>", "list? -> exact-nonnegative-integer?", "returns the number of items of the list"},
+		{"append", i.procAppend, 0, variadic, "[lists...] [tail]", "list? ... any/c -> any/c", "returns a list of the items of the lists ending with the last argument"},
+		{"reverse", i.procReverse, 1, 1, "<list>", "list? -> list?", "returns a list of the items of the list in reverse order"},
+		{"list-ref", procListRef, 2, 2, "<list> <index>", "list? exact-nonnegative-integer? -> any/c", "returns the item of the list at the index"},
+		{"list-tail", procListTail, 2, 2, "<list> <count>", "any/c exact-nonnegative-integer? -> any/c", "returns the list without its first count pairs"},
+		{"memq", procMemq, 2, 2, "<expression> <list>", "any/c list? -> (or/c pair? #f)", "returns the first pair of the list whose car is eq? to the expression"},
+		{"memv", procMemv, 2, 2, "<expression> <list>", "any/c list? -> (or/c pair? #f)", "returns the first pair of the list whose car is eqv? to the expression"},
+		{"member", i.procMember, 2, 3, "<expression> <list> [equality procedure]", "any/c list? (any/c any/c -> any/c) -> (or/c pair? #f)", "returns the first pair of the list whose car is equal? to the expression"},
+		{"assq", procAssq, 2, 2, "<key> <association list>", "any/c (listof pair?) -> (or/c pair? #f)", "returns the first pair of the list whose car is eq? to the key"},
+		{"assv", procAssv, 2, 2, "<key> <association list>", "any/c (listof pair?) -> (or/c pair? #f)", "returns the first pair of the list whose car is eqv? to the key"},
+		{"assoc", i.procAssoc, 2, 3, "<key> <association list> [equality procedure]", "any/c (listof pair?) (any/c any/c -> any/c) -> (or/c pair? #f)", "returns the first pair of the list whose car is equal? to the key"},
+		{"sort", i.procSort, 2, variadic, "<list> <less-than procedure> [#:key <extract-key procedure>]", "list? (any/c any/c -> any/c) #:key (any/c -> any/c) -> list?", "returns the items of the list sorted stably by the procedure, comparing the keys extracted from them if #:key is given"},
+		{"map", i.procMap, 2, variadic, "<procedure> <list> [lists...]", "procedure? list? list? ... -> list?", "returns the results of the procedure applied to the items of the lists at the same positions"},
+		{"for-each", i.procForEach, 2, variadic, "<procedure> <list> [lists...]", "procedure? list? list? ... -> void?", "applies the procedure to the items of the lists at the same positions in order"},
+		{"apply", i.procApply, 2, variadic, "<procedure> [args...] <list>", "procedure? any/c ... list? -> any", "calls the procedure with the arguments followed by the items of the list"},
+		{"call/cc", i.procCallCC, 1, 1, "<procedure>", "(-> procedure? any) -> any", "calls the procedure with the escape continuation of the call, applying it returns the value from the call"},
+		{"call-with-current-continuation", i.procCallCC, 1, 1, "<procedure>", "(-> procedure? any) -> any", "calls the procedure with the escape continuation of the call, applying it returns the value from the call"},
+		{"values", procValues, 0, variadic, "[values...]", "any/c ... -> any", "returns the values, a single value as it is"},
+		{"call-with-values", i.procCallWithValues, 2, 2, "<producer> <consumer>", "(-> any) procedure? -> any", "calls the consumer with the values returned by the producer"},
+		{"max", procMax, 1, variadic, "<numbers...>", "number? ... -> number?", "returns the largest of the numbers"},
+		{"min", procMin, 1, variadic, "<numbers...>", "number? ... -> number?", "returns the smallest of the numbers"},
+		{"sqrt", procSqrt, 1, 1, "<number>", "(>=/c 0) -> number?", "returns the square root of the number, exact for exact squares"},
+		{"exp", procExp, 1, 1, "<number>", "number? -> number?", "returns e raised to the number"},
+		{"log", procLog, 1, 2, "<number> [base]", "(>=/c 0) (>=/c 0) -> number?", "returns the logarithm of the number in the base, e by default"},
+		{"sin", procSin, 1, 1, "<number>", "number? -> number?", "returns the sine of the angle in radians"},
+		{"cos", procCos, 1, 1, "<number>", "number? -> number?", "returns the cosine of the angle in radians"},
+		{"tan", procTan, 1, 1, "<number>", "number? -> number?", "returns the tangent of the angle in radians"},
+		{"asin", procAsin, 1, 1, "<number>", "(real-in -1 1) -> number?", "returns the arcsine of the number in radians"},
+		{"acos", procAcos, 1, 1, "<number>", "(real-in -1 1) -> number?", "returns the arccosine of the number in radians"},
+		{"atan", procAtan, 1, 2, "<number> or <y> <x>", "number? number? -> number?", "returns the arctangent of the number, or the angle of the point (x, y), in radians"},
+		{"floor", procFloor, 1, 1, "<number>", "number? -> number?", "returns the largest integer not greater than the number"},
+		{"ceiling", procCeiling, 1, 1, "<number>", "number? -> number?", "returns the smallest integer not less than the number"},
+		{"truncate", procTruncate, 1, 1, "<number>", "number? -> number?", "returns the integer closest to the number towards zero"},
+		{"round", procRound, 1, 1, "<number>", "number? -> number?", "returns the closest integer to the number, halfway cases are rounded to even"},
+		{"abs", procAbs, 1, 1, "<number>", "number? -> number?", "returns the absolute value of the number"},
+		{"modulo", procModulo, 2, 2, "<dividend> <divisor>", "integer? integer? -> integer?", "returns the modulo of the integer division, which has the sign of the divisor"},
+		{"gcd", procGcd, 0, variadic, "[integers...]", "integer? ... -> integer?", "returns the greatest common divisor of the integers"},
+		{"lcm", procLcm, 0, variadic, "[integers...]", "integer? ... -> integer?", "returns the least common multiple of the integers"},
+		{"zero?", procIsZero, 1, 1, "<number>", "number? -> boolean?", "tests whether the number is zero"},
+		{"positive?", procIsPositive, 1, 1, "<number>", "number? -> boolean?", "tests whether the number is greater than zero"},
+		{"negative?", procIsNegative, 1, 1, "<number>", "number? -> boolean?", "tests whether the number is less than zero"},
+		{"even?", procIsEven, 1, 1, "<integer>", "integer? -> boolean?", "tests whether the integer is even"},
+		{"odd?", procIsOdd, 1, 1, "<integer>", "integer? -> boolean?", "tests whether the integer is odd"},
+		{"integer?", procIsInteger, 1, 1, "<expression>", "any/c -> boolean?", "tests whether the expression is an integer, exact or inexact"},
+		{"rational?", procIsRational, 1, 1, "<expression>", "any/c -> boolean?", "tests whether the expression is a finite number"},
+		{"real?", procIsReal, 1, 1, "<expression>", "any/c -> boolean?", "tests whether the expression is a real number"},
+		{"exact?", procIsExact, 1, 1, "<number>", "number? -> boolean?", "tests whether the number is exact"},
+		{"inexact?", procIsInexact, 1, 1, "<number>", "number? -> boolean?", "tests whether the number is inexact"},
+		{"exact->inexact", procExactToInexact, 1, 1, "<number>", "number? -> inexact?", "returns the inexact number closest to the number"},
+		{"inexact->exact", procInexactToExact, 1, 1, "<number>", "rational? -> exact?", "returns the exact number equal to the number"},
+
+		{"number->string", i.procNumberToString, 1, 2, "<number> [radix]", "number? (or/c 2 8 10 16) -> string?", "returns the number written in the radix"},
+		{"string->number", procStringToNumber, 1, 2, "<string> [radix]", "string? (or/c 2 8 10 16) -> (or/c number? #f)", "reads a number from the string, returns #f if it isn't a number"},
+
+		{"make-string", procMakeString, 1, 2, "<length> [char]", "exact-nonnegative-integer? char? -> string?", "returns a mutable string of the length filled with the char, a space by default"},
+		{"string?", procIsString, 1, 1, "<expression>", "any/c -> boolean?", "tests whether the expression is a string"},
+		{"string-length", procStringLength, 1, 1, "<string>", "string? -> exact-nonnegative-integer?", "returns the number of characters of the string"},
+		{"string-ref", procStringRef, 2, 2, "<string> <index>", "string? exact-nonnegative-integer? -> char?", "returns the character at the index"},
+		{"string-append", procStringAppend, 0, variadic, "[strings...]", "string? ... -> string?", "returns a new string of the characters of the strings in order"},
+		{"substring", procSubstring, 2, 3, "<string> <start> [end]", "string? exact-nonnegative-integer? exact-nonnegative-integer? -> string?", "returns a new string of the characters from start up to end"},
+		{"string=?", procStringEqual, 1, variadic, "<strings...>", "string? string? ... -> boolean?", "tests whether the strings are equal"},
+		{"string<?", procStringLess, 1, variadic, "<strings...>", "string? string? ... -> boolean?", "tests whether the strings are in strictly increasing lexicographic order"},
+		{"string->list", procStringToList, 1, 3, "<string> [start] [end]", "string? exact-nonnegative-integer? exact-nonnegative-integer? -> (listof char?)", "returns the characters of the part of the string"},
+		{"list->string", procListToString, 1, 1, "<list of chars>", "(listof char?) -> string?", "returns a new string of the characters"},
+		{"string-copy", procStringCopy, 1, 3, "<string> [start] [end]", "string? exact-nonnegative-integer? exact-nonnegative-integer? -> string?", "returns a mutable copy of the part of the string"},
+		{"string-set!", procStringSet, 3, 3, "<string> <index> <char>", "string? exact-nonnegative-integer? char? -> void?", "changes the character at the index of the mutable string"},
+		{"string-fill!", procStringFill, 2, 4, "<string> <char> [start] [end]", "string? char? exact-nonnegative-integer? exact-nonnegative-integer? -> void?", "fills the part of the mutable string with the character"},
+		{"string-copy!", procStringCopyTo, 3, 5, "<to> <at> <from> [start] [end]", "string? exact-nonnegative-integer? string? exact-nonnegative-integer? exact-nonnegative-integer? -> void?", "copies the part of a string into the mutable string at the index"},
+		{"string-builder", procStringBuilder, 0, 0, "", "-> string-builder?", "returns an empty string builder"},
+		{"string-builder-add!", procStringBuilderAdd, 1, variadic, "<builder> [strings or chars...]", "string-builder? (or/c string? char?) ... -> void?", "appends the strings and characters to the builder"},
+		{"string-builder->string", procStringBuilderToString, 1, 1, "<builder>", "string-builder? -> string?", "returns the string built so far"},
+		{"string-upcase", procStringUpcase, 1, 1, "<string>", "string? -> string?", "returns the string in upper case"},
+		{"string-downcase", procStringDowncase, 1, 1, "<string>", "string? -> string?", "returns the string in lower case"},
+		{"string-normalize-nfc", procStringNormalizeNFC, 1, 1, "<string>", "string? -> string?", "returns the string in unicode normalization form C"},
+		{"string-normalize-nfd", procStringNormalizeNFD, 1, 1, "<string>", "string? -> string?", "returns the string in unicode normalization form D"},
+		{"string->utf8", procStringToUTF8, 1, 3, "<string> [start] [end]", "string? exact-nonnegative-integer? exact-nonnegative-integer? -> (listof byte?)", "returns the utf-8 encoding of the part of the string as a list of bytes"},
+		{"utf8->string", procUTF8ToString, 1, 1, "<list of bytes>", "(listof byte?) -> string?", "decodes the utf-8 bytes into a string"},
+
+		{"vector", procVector, 0, variadic, "[items...]", "any/c ... -> vector?", "returns a new mutable vector of the items"},
+		{"make-vector", procMakeVector, 1, 2, "<length> [fill]", "exact-nonnegative-integer? any/c -> vector?", "returns a mutable vector of the length filled with the fill, 0 by default"},
+		{"vector?", procIsVector, 1, 1, "<expression>", "any/c -> boolean?", "tests whether the expression is a vector"},
+		{"vector-length", procVectorLength, 1, 1, "<vector>", "vector? -> exact-nonnegative-integer?", "returns the number of items of the vector"},
+		{"vector-ref", procVectorRef, 2, 2, "<vector> <index>", "vector? exact-nonnegative-integer? -> any/c", "returns the item at the index"},
+		{"vector-set!", procVectorSet, 3, 3, "<vector> <index> <value>", "vector? exact-nonnegative-integer? any/c -> void?", "changes the item at the index of the mutable vector"},
+		{"vector->list", i.procVectorToList, 1, 1, "<vector>", "vector? -> list?", "returns a list of the items of the vector"},
+		{"list->vector", procListToVector, 1, 1, "<list>", "list? -> vector?", "returns a new mutable vector of the items of the list"},
+		{"make-hash", procMakeHash, 0, 1, "[association list]", "(listof pair?) -> hash?", "returns a hash table holding the pairs of the association list"},
+		{"hash?", procIsHash, 1, 1, "<expression>", "any/c -> boolean?", "tests whether the expression is a hash table"},
+		{"hash-set!", procHashSet, 3, 3, "<hash> <key> <value>", "hash? any/c any/c -> void?", "sets the value of the key"},
+		{"hash-ref", procHashRef, 2, 3, "<hash> <key> [failure value]", "hash? any/c any/c -> any/c", "returns the value of the key, or the failure value if it isn't in the table"},
+		{"hash-remove!", procHashRemove, 2, 2, "<hash> <key>", "hash? any/c -> void?", "removes the key from the table"},
+		{"hash-count", procHashCount, 1, 1, "<hash>", "hash? -> exact-nonnegative-integer?", "returns the number of keys in the table"},
+		{"hash->list", procHashToList, 1, 1, "<hash>", "hash? -> (listof pair?)", "returns the pairs of the table sorted by their keys"},
+
+		{"keyword?", procIsKeyword, 1, 1, "<expression>", "any/c -> boolean?", "tests whether the expression is a keyword"},
+		{"keyword->string", procKeywordToString, 1, 1, "<keyword>", "keyword? -> string?", "returns the name of the keyword without the #: prefix"},
+		{"string->keyword", i.procStringToKeyword, 1, 1, "<string>", "string? -> keyword?", "returns the keyword with the name"},
+		{"plist-get", procPlistGet, 2, 3, "<plist> <key> [default]", "list? any/c any/c -> any/c", "returns the value after the key in the list of alternating keys and values, or the default (#f) if the key isn't in it"},
+		{"plist-put", procPlistPut, 3, 3, "<plist> <key> <value>", "list? any/c any/c -> list?", "returns a copy of the list of alternating keys and values with the value of the key set"},
+
+		{"char?", procIsChar, 1, 1, "<expression>", "any/c -> boolean?", "tests whether the expression is a character"},
+		{"char->integer", procCharToInteger, 1, 1, "<char>", "char? -> exact-integer?", "returns the unicode code point of the character"},
+		{"integer->char", procIntegerToChar, 1, 1, "<code point>", "exact-integer? -> char?", "returns the character of the unicode code point"},
+		{"char=?", procCharEqual, 1, variadic, "<chars...>", "char? char? ... -> boolean?", "tests whether the characters are equal"},
+		{"char-alphabetic?", procIsCharAlphabetic, 1, 1, "<char>", "char? -> boolean?", "tests whether the character is a letter"},
+		{"char-numeric?", procIsCharNumeric, 1, 1, "<char>", "char? -> boolean?", "tests whether the character is a digit"},
+		{"char-whitespace?", procIsCharWhitespace, 1, 1, "<char>", "char? -> boolean?", "tests whether the character is whitespace"},
+		{"char-upper-case?", procIsCharUpperCase, 1, 1, "<char>", "char? -> boolean?", "tests whether the character is an upper case letter"},
+		{"char-lower-case?", procIsCharLowerCase, 1, 1, "<char>", "char? -> boolean?", "tests whether the character is a lower case letter"},
+		{"char-upcase", procCharUpcase, 1, 1, "<char>", "char? -> char?", "returns the character in upper case"},
+		{"char-downcase", procCharDowncase, 1, 1, "<char>", "char? -> char?", "returns the character in lower case"},
+
+		{"current-input-port", i.procCurrentInputPort, 0, 0, "", "-> input-port?", "returns the current input port, the standard input by default"},
+		{"current-output-port", i.procCurrentOutputPort, 0, 0, "", "-> output-port?", "returns the current output port, the standard output by default"},
+		{"input-port?", procIsInputPort, 1, 1, "<expression>", "any/c -> boolean?", "tests whether the expression is an input port"},
+		{"output-port?", procIsOutputPort, 1, 1, "<expression>", "any/c -> boolean?", "tests whether the expression is an output port"},
+		{"write-char", i.procWriteChar, 1, 2, "<char> [port]", "char? output-port? -> void?", "writes the character to the port"},
+		{"display", i.procDisplay, 1, 2, "<expression> [port]", "any/c output-port? -> void?", "writes the expression to the port with strings and characters as their characters"},
+		{"write", i.procWrite, 1, 2, "<expression> [port]", "any/c output-port? -> void?", "writes the expression to the port in the syntax read reads back"},
+		{"newline", i.procNewline, 0, 1, "[port]", "output-port? -> void?", "writes a newline to the port"},
+		{"write-string", i.procWriteString, 1, 2, "<string> [port]", "string? output-port? -> void?", "writes the characters of the string to the port"},
+		{"char-ready?", i.procCharReady, 0, 1, "[port]", "input-port? -> boolean?", "tests whether a character can be read from the port without blocking"},
+		{"read-char", i.procReadChar, 0, 1, "[port]", "input-port? -> (or/c char? eof-object? #f)", "reads a character, #f if the port has a timeout and no character arrived in time"},
+		{"peek-char", i.procPeekChar, 0, 1, "[port]", "input-port? -> (or/c char? eof-object? #f)", "returns the next character without reading it, #f if the port timed out"},
+		{"read-line", i.procReadLine, 0, 1, "[port]", "input-port? -> (or/c string? eof-object?)", "returns the characters up to the next newline without it"},
+		{"read", i.procRead, 0, 1, "[port]", "input-port? -> any/c", "returns the next datum of the port or the eof object"},
+		{"read-all", i.procReadAll, 0, 1, "[port]", "input-port? -> list?", "returns a list of all the data left in the port"},
+		{"with-input-from-string", i.procWithInputFromString, 2, 2, "<string> <thunk>", "string? (-> any) -> any", "calls the thunk with a port reading the string as the current input port"},
+		{"open-input-string", procOpenInputString, 1, 1, "<string>", "string? -> input-port?", "returns a port reading the characters of the string"},
+		{"open-output-string", procOpenOutputString, 0, 0, "", "-> output-port?", "returns a port collecting the written characters for get-output-string"},
+		{"get-output-string", procGetOutputString, 1, 1, "<port>", "output-port? -> string?", "returns the characters written to the string port so far"},
+		{"open-input-file", i.procOpenInputFile, 1, 1, "<path>", "string? -> input-port?", "returns a port reading the file"},
+		{"open-output-file", i.procOpenOutputFile, 1, variadic, "<path> [#:exists <mode>]", "string? #:exists symbol? -> output-port?", "returns a port writing to the file, the mode is 'error, 'replace, 'truncate or 'append"},
+		{"with-input-from-file", i.procWithInputFromFile, 2, 2, "<path> <thunk>", "string? (-> any) -> any", "calls the thunk with a port reading the file as the current input port"},
+		{"with-output-to-file", i.procWithOutputToFile, 2, variadic, "<path> <thunk> [#:exists <mode>]", "string? (-> any) #:exists symbol? -> any", "calls the thunk with a port writing to the file as the current output port"},
+		{"close-port", i.procClosePort, 1, 1, "<port>", "port? -> void?", "closes the port and the file it reads or writes"},
+		{"set-port-read-timeout!", procSetPortReadTimeout, 2, 2, "<port> <seconds or #f>", "input-port? (or/c (>=/c 0) #f) -> void?", "sets how long reads from the port wait for input, #f waits forever"},
+		{"eof-object", procEOFObject, 0, 0, "", "-> eof-object?", "returns the end-of-file object"},
+		{"eof-object?", procIsEOFObject, 1, 1, "<expression>", "any/c -> boolean?", "tests whether the expression is the end-of-file object"},
+
+		{"error", procError, 1, variadic, "<message> [irritants...]", "(or/c string? symbol?) any/c ... -> none/c", "raises an error with the message followed by the irritants written"},
+		{"error-object?", procIsErrorObject, 1, 1, "<expression>", "any/c -> boolean?", "tests whether the expression is an error object"},
+		{"error-object-message", procErrorObjectMessage, 1, 1, "<error object>", "error-object? -> string?", "returns the message of the error"},
+		{"error-object-stack", procErrorObjectStack, 1, 1, "<error object>", "error-object? -> (listof string?)", "returns the procedures being applied when the error occured, innermost first"},
+
+		{"current-continuation-marks", i.procCurrentContinuationMarks, 0, 0, "", "-> continuation-mark-set?", "returns the marks set by the enclosing with-continuation-mark forms"},
+		{"continuation-mark-set?", procIsContinuationMarkSet, 1, 1, "<expression>", "any/c -> boolean?", "tests whether the expression is a continuation mark set"},
+		{"continuation-mark-set->list", i.procContinuationMarkSetToList, 2, 2, "<mark set> <key>", "continuation-mark-set? any/c -> list?", "returns the values marked under the key, innermost first"},
+		{"continuation-mark-set-first", i.procContinuationMarkSetFirst, 2, 3, "<mark set or #f> <key> [default]", "(or/c continuation-mark-set? #f) any/c any/c -> any/c", "returns the innermost value marked under the key or the default, #f stands for the current marks"},
+
+		{"current-directory", i.procCurrentDirectory, 0, 1, "[path]", "string? -> (or/c string? void?)", "returns the directory relative paths are resolved against or, given a path, changes it"},
+		{"build-path", procBuildPath, 1, variadic, "<base> [parts...]", "string? string? ... -> string?", "joins the parts of a path with the separator of the system"},
+		{"path-directory", procPathDirectory, 1, 1, "<path>", "string? -> string?", "returns the path without its last element"},
+		{"path-extension", procPathExtension, 1, 1, "<path>", "string? -> (or/c string? #f)", "returns the extension of the path with its dot, or #f if it has none"},
+
+		{"make-environment", i.procMakeEnvironment, 0, 1, "[parent environment]", "environment? -> environment?", "returns a new environment inheriting the global definitions by default"},
+		{"interaction-environment", i.procInteractionEnvironment, 0, 0, "", "-> environment?", "returns the global environment"},
+		{"eval", i.procEval, 1, 2, "<expression> [environment]", "any/c environment? -> any", "evaluates the expression in the environment, the global one by default"},
+		{"eval-string", i.procEvalString, 1, 2, "<string> [environment]", "string? environment? -> any", "evaluates the expressions in the string returning the result of the last one"},
+		{"load-string", i.procLoadString, 1, 1, "<string>", "string? -> void?", "interprets the string like load interprets a file"},
+		{"environment?", procIsEnvironment, 1, 1, "<expression>", "any/c -> boolean?", "tests whether the expression is an environment"},
+		{"environment-define!", procEnvironmentDefine, 3, 3, "<environment> <symbol> <value>", "environment? symbol? any/c -> void?", "defines the symbol in the environment"},
+		{"environment-ref", procEnvironmentRef, 2, 2, "<environment> <symbol>", "environment? symbol? -> any/c", "returns the value of the symbol in the environment"},
+		{"gensym", i.procGensym, 0, 1, "[prefix]", "(or/c symbol? string?) -> symbol?", "returns a new symbol made of the prefix (g by default) and a count kept by the interpreter"},
+
+		{"make-generator", i.procMakeGenerator, 1, 1, "<procedure>", "(-> any) -> generator?", "returns a generator producing the values the procedure yields and then the value it returns unless it is void"},
+		{"generator-next", i.procGeneratorNext, 1, 1, "<generator>", "generator? -> any/c", "returns the next value of the generator or the eof object once it is done"},
+		{"yield", i.procYield, 1, 1, "<value>", "any/c -> void?", "produces the next value of the generator being run"},
+		{"generator?", procIsGenerator, 1, 1, "<expression>", "any/c -> boolean?", "tests whether the expression is a generator"},
+		{"generator-done?", procIsGeneratorDone, 1, 1, "<generator>", "generator? -> boolean?", "tests whether the generator has finished"},
+
+		{"force", procForce, 1, 1, "<promise>", "any/c -> any", "returns the value of the promise, evaluating it only the first time"},
+		{"make-promise", procMakePromise, 1, 1, "<value>", "any/c -> promise?", "returns a promise already forced to the value"},
+		{"promise?", procIsPromise, 1, 1, "<expression>", "any/c -> boolean?", "tests whether the expression is a promise"},
+
+		{"profile", i.procProfile, 1, 1, "<thunk>", "(-> any) -> any", "calls the thunk printing the calls and the time spent in each procedure"},
+		{"procedure-free-variables", procProcedureFreeVariables, 1, 1, "<procedure>", "procedure? -> (listof symbol?)", "returns the variables the body of the procedure refers to which it doesn't bind"},
+
+		{"runtime-statistics", i.procRuntimeStatistics, 0, 0, "", "-> (listof pair?)", "returns an association list of the evaluator and memory statistics"},
+
+		{"features", i.procFeatures, 0, 0, "", "-> (listof symbol?)", "returns the features of the interpreter"},
+
+		{"exit", i.procExit, 0, 1, "[code]", "(or/c exact-integer? boolean?) -> none/c", "ends the program with the code, 0 by default"},
+		{"on-exit", i.procOnExit, 1, 1, "<thunk>", "(-> any) -> void?", "calls the thunk when the interpreter is closed, e.g. when the REPL exits"},
+
+		{"sleep", i.procSleep, 1, 1, "<seconds>", "(>=/c 0) -> void?", "waits for the seconds, stops early if the evaluation is cancelled"},
+		{"with-timeout", i.procWithTimeout, 2, 3, "<seconds> <thunk> [on-timeout thunk]", "(>=/c 0) (-> any) (-> any) -> any", "calls the thunk cancelling it after the seconds, then calls on-timeout or returns #f"},
+
+		{"help", i.procHelp, 0, 1, "[procedure or symbol]", "(or/c procedure? symbol?) -> void?", "prints the documentation of the procedure, or lists the builtins"},
+	}
+}
+
+// returns the number of arguments a builtin takes as it's written in errors
+func (b *builtin) arity() string {
+	switch {
+	case b.maxArgs == variadic:
+		return "at least " + strconv.Itoa(b.minArgs)
+	case b.minArgs == b.maxArgs:
+		return strconv.Itoa(b.minArgs)
+	case b.minArgs+1 == b.maxArgs:
+		return fmt.Sprintf("%d or %d", b.minArgs, b.maxArgs)
+	}
+
+	return fmt.Sprintf("%d to %d", b.minArgs, b.maxArgs)
+}
+
+// returns how a call of the builtin is written
+func (b *builtin) usage() string {
+	if len(b.args) == 0 {
+		return "(" + b.name + ")"
+	}
+
+	return "(" + b.name + " " + b.args + ")"
+}
+
+/// ------------------------------------------------------------------------ ///
+/// ------------------------- Help procedure methods ----------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// (help [procedure or symbol])
+// without an argument lists the names of the builtins
+func (i *Interpreter) procHelp(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen > 1 {
+		return &p.Void, newError(errArityMismatch, "help", "0 or 1", strconv.Itoa(argsLen))
+	}
+
+	if argsLen == 0 {
+		names := make([]string, 0, len(i.registry))
+		for name := range i.registry {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		i.println(strings.Join(names, " "))
+		return &p.Void, nil
+	}
+
+	arg := args.Lst[0]
+	name, isSym := p.AsSymbolName(arg)
+	if isSym {
+		var isBound bool
+		if arg, isBound = i.genv.vars[name]; !isBound {
+			return &p.Void, newError(errUnboundIdentifier, name)
+		}
+	}
+
+	if !isCallable(arg) {
+		return &p.Void, newError(errContractViolation, "help", "(or/c procedure? symbol?)", errString(arg))
+	}
+
+	i.println(i.help(arg))
+
+	return &p.Void, nil
+}
+
+// returns the documentation of the procedure or lambda
+func (i *Interpreter) help(proc p.Expression) string {
+	if pr, isProc := proc.(*p.Procedure); isProc {
+		if b, isBuiltin := i.registry[pr.Name]; isBuiltin {
+			return fmt.Sprintf("%s\n  arity: %s\n  contract: %s\n  %s", b.usage(), b.arity(), b.contract, b.doc)
+		}
+	}
+
+	info := i.bindingInfo(proc.String(0), proc)
+	res := info.Name
+	if info.Arity >= 0 {
+		res += "\n  arity: " + strconv.Itoa(info.Arity)
+	}
+	if len(info.Doc) != 0 {
+		res += "\n  " + info.Doc
+	}
+
+	return res
+}
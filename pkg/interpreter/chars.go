@@ -0,0 +1,153 @@
+package interpreter
+
+import (
+	"strconv"
+	"unicode"
+	"unicode/utf8"
+
+	p "github.com/dimbata23/golang-scheme-interpreter/pkg/parser"
+)
+
+/// ------------------------------------------------------------------------ ///
+/// ------------------------ Char procedure methods ------------------------ ///
+/// ------------------------------------------------------------------------ ///
+
+// (char-alphabetic? <char>)
+func procIsCharAlphabetic(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	return procCharTest(args, "char-alphabetic?", unicode.IsLetter)
+}
+
+// (char-numeric? <char>)
+func procIsCharNumeric(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	return procCharTest(args, "char-numeric?", unicode.IsDigit)
+}
+
+// (char-whitespace? <char>)
+func procIsCharWhitespace(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	return procCharTest(args, "char-whitespace?", unicode.IsSpace)
+}
+
+// (char-upper-case? <char>)
+func procIsCharUpperCase(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	return procCharTest(args, "char-upper-case?", unicode.IsUpper)
+}
+
+// (char-lower-case? <char>)
+func procIsCharLowerCase(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	return procCharTest(args, "char-lower-case?", unicode.IsLower)
+}
+
+// (char-upcase <char>)
+func procCharUpcase(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	return procCharConvert(args, "char-upcase", unicode.ToUpper)
+}
+
+// (char-downcase <char>)
+func procCharDowncase(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	return procCharConvert(args, "char-downcase", unicode.ToLower)
+}
+
+// (char? <expression>)
+func procIsChar(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen != 1 {
+		return &p.Void, newError(errArityMismatch, "char?", "1", strconv.Itoa(argsLen))
+	}
+
+	if _, isChar := args.Lst[0].(*p.Char); isChar {
+		return &p.True, nil
+	}
+
+	return &p.False, nil
+}
+
+// (char->integer <char>)
+// returns the unicode code point of the char
+func procCharToInteger(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	ch, err := toChar("char->integer", args)
+	if err != nil {
+		return &p.Void, err
+	}
+
+	return p.NewNumber(float64(ch.Val)), nil
+}
+
+// (integer->char <code point>)
+func procIntegerToChar(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen != 1 {
+		return &p.Void, newError(errArityMismatch, "integer->char", "1", strconv.Itoa(argsLen))
+	}
+
+	num, isNum := args.Lst[0].(*p.Number)
+	if !isNum || kindOf(num) != kindInteger || num.Val < 0 || num.Val > unicode.MaxRune || !utf8.ValidRune(rune(num.Val)) {
+		return &p.Void, newError(errContractViolation, "integer->char", "(and/c exact-integer? (or/c (integer-in 0 #xD7FF) (integer-in #xE000 #x10FFFF)))", errString(args.Lst[0]))
+	}
+
+	return &p.Char{Val: rune(num.Val)}, nil
+}
+
+// (char=? <chars...>)
+func procCharEqual(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen < 1 {
+		return &p.Void, newError(errArityMismatch, "char=?", "at least 1", strconv.Itoa(argsLen))
+	}
+
+	for _, arg := range args.Lst {
+		if _, isChar := arg.(*p.Char); !isChar {
+			return &p.Void, newError(errContractViolation, "char=?", "char?", errString(arg))
+		}
+	}
+
+	for i := 1; i < argsLen; i++ {
+		if args.Lst[i-1].(*p.Char).Val != args.Lst[i].(*p.Char).Val {
+			return &p.False, nil
+		}
+	}
+
+	return &p.True, nil
+}
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------------- Helper functions --------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// returns the only argument as a char or an error
+func toChar(procName string, args *p.ExprList) (ch *p.Char, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen != 1 {
+		return nil, newError(errArityMismatch, procName, "1", strconv.Itoa(argsLen))
+	}
+
+	ch, isChar := args.Lst[0].(*p.Char)
+	if !isChar {
+		return nil, newError(errContractViolation, procName, "char?", errString(args.Lst[0]))
+	}
+
+	return ch, nil
+}
+
+// tests whether the char given as the only argument satisfies the test
+func procCharTest(args *p.ExprList, procName string, test func(rune) bool) (ex p.Expression, err *p.Error) {
+	ch, err := toChar(procName, args)
+	if err != nil {
+		return &p.Void, err
+	}
+
+	if test(ch.Val) {
+		return &p.True, nil
+	}
+
+	return &p.False, nil
+}
+
+// applies the conversion to the char given as the only argument
+func procCharConvert(args *p.ExprList, procName string, convert func(rune) rune) (ex p.Expression, err *p.Error) {
+	ch, err := toChar(procName, args)
+	if err != nil {
+		return &p.Void, err
+	}
+
+	return &p.Char{Val: convert(ch.Val)}, nil
+}
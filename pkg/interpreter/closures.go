@@ -0,0 +1,53 @@
+package interpreter
+
+import (
+	"strconv"
+
+	"github.com/dimbata23/golang-scheme-interpreter/pkg/analyzer"
+	p "github.com/dimbata23/golang-scheme-interpreter/pkg/parser"
+)
+
+/// ------------------------------------------------------------------------ ///
+/// ---------------------- Closure procedure methods ----------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// (procedure-free-variables <procedure>)
+// returns the variables the body of the lambda refers to which it doesn't
+// bind, globals included, builtins have none
+func procProcedureFreeVariables(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen != 1 {
+		return &p.Void, newError(errArityMismatch, "procedure-free-variables", "1", strconv.Itoa(argsLen))
+	}
+
+	if !isCallable(args.Lst[0]) {
+		return &p.Void, newError(errContractViolation, "procedure-free-variables", "procedure?", errString(args.Lst[0]))
+	}
+
+	lambda, isLambda := args.Lst[0].(*p.Lambda)
+	if !isLambda {
+		return &p.NullSym, nil
+	}
+
+	names := freeVariables(lambda)
+	items := make([]p.Expression, len(names))
+	for i, name := range names {
+		items[i] = p.NewSymbol(name)
+	}
+
+	return p.List(items...), nil
+}
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------------- Helper functions --------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// returns the free variables of the lambda, analyzing its body
+// the first time they are needed
+func freeVariables(lambda *p.Lambda) []string {
+	if lambda.FreeVars == nil {
+		lambda.FreeVars = append([]string{}, analyzer.FreeVariables(lambda.Params, lambda.Body.Lst)...)
+	}
+
+	return lambda.FreeVars
+}
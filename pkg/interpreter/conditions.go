@@ -0,0 +1,154 @@
+package interpreter
+
+import (
+	"strconv"
+	"strings"
+
+	p "github.com/dimbata23/golang-scheme-interpreter/pkg/parser"
+)
+
+// name of the global variable bound to the most recent error
+const lastErrorVar = "*last-error*"
+
+// scheme condition object wrapping an error which occured during evaluation
+type errorObject struct {
+	err *p.Error
+}
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------- Public functions and methods --------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// returns the most recent error reported by the interpreter, nil if none
+// its stack lists the procedures being applied when it occured
+func (i *Interpreter) LastError() *p.Error {
+	if obj, isErr := i.genv.vars[lastErrorVar].(*errorObject); isErr {
+		return obj.err
+	}
+
+	return nil
+}
+
+/// ------------------------------------------------------------------------ ///
+/// ---------------------- Condition procedure methods --------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// (error <message> [irritants...])
+// the message of the error is the message displayed and the irritants
+// written after it, e.g. (error "bad value:" "x" 1) gives bad value: "x" 1
+func procError(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	if len(args.Lst) == 0 {
+		return &p.Void, newError(errArityMismatch, "error", "at least 1", "0")
+	}
+
+	msg, isStr := p.AsString(args.Lst[0])
+	if name, isSym := p.AsSymbolName(args.Lst[0]); isSym {
+		msg = name
+	} else if !isStr {
+		return &p.Void, newError(errContractViolation, "error", "(or/c string? symbol?)", errString(args.Lst[0]))
+	}
+
+	var sb strings.Builder
+	sb.WriteString(msg)
+	for _, irritant := range args.Lst[1:] {
+		sb.WriteString(" ")
+		sb.WriteString(p.WriteString(irritant))
+	}
+
+	return &p.Void, &p.Error{Val: sb.String()}
+}
+
+// (error-object? <expression>)
+func procIsErrorObject(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen != 1 {
+		return &p.Void, newError(errArityMismatch, "error-object?", "1", strconv.Itoa(argsLen))
+	}
+
+	if _, isErr := args.Lst[0].(*errorObject); isErr {
+		return &p.True, nil
+	}
+
+	return &p.False, nil
+}
+
+// (error-object-message <error object>)
+func procErrorObjectMessage(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	obj, err := toErrorObject("error-object-message", args)
+	if err != nil {
+		return &p.Void, err
+	}
+
+	return p.NewString(obj.err.Val), nil
+}
+
+// (error-object-stack <error object>)
+// returns the names of the procedures being applied, innermost first
+func procErrorObjectStack(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	obj, err := toErrorObject("error-object-stack", args)
+	if err != nil {
+		return &p.Void, err
+	}
+
+	frames := make([]p.Expression, len(obj.err.Stack))
+	for j, frame := range obj.err.Stack {
+		frames[j] = p.NewString(frame)
+	}
+
+	return p.List(frames...), nil
+}
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------------- Helper functions --------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// binds the error to *last-error* in the global environment
+func (i *Interpreter) setLastError(err *p.Error) {
+	i.genv.vars[lastErrorVar] = &errorObject{err: err}
+}
+
+// returns the only argument as an error object or an error
+func toErrorObject(procName string, args *p.ExprList) (obj *errorObject, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen != 1 {
+		return nil, newError(errArityMismatch, procName, "1", strconv.Itoa(argsLen))
+	}
+
+	obj, isErr := args.Lst[0].(*errorObject)
+	if !isErr {
+		return nil, newError(errContractViolation, procName, "error-object?", errString(args.Lst[0]))
+	}
+
+	return obj, nil
+}
+
+// returns how the procedure or lambda is shown in a stack of an error
+func frameName(pr p.Expression) string {
+	lambda, isLambda := pr.(*p.Lambda)
+	if !isLambda {
+		return pr.String(0)
+	}
+
+	res := lambda.String(0)
+	if lambda.Pos.Line != 0 && lambda.File != "" {
+		res += " at " + lambda.File + ":" + lambda.Pos.String()
+	} else if lambda.Pos.Line != 0 {
+		res += " at " + lambda.Pos.String()
+	}
+
+	return res
+}
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------------- String() methods --------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// printed as `#<error-object:<first line of the message>>`
+func (obj *errorObject) String(_ int) string {
+	msg := obj.err.Val
+	if end := strings.IndexByte(msg, '\n'); end != -1 {
+		msg = msg[:end]
+	}
+
+	return "#<error-object:" + msg + ">"
+}
@@ -0,0 +1,20 @@
+package interpreter
+
+import "testing"
+
+func TestError(t *testing.T) {
+	checkEvals(t, []evalTest{
+		{`(error "boom")`, "boom"},
+		{"(error-object? *last-error*)", "#t"},
+		{"(error-object-message *last-error*)", `"boom"`},
+		{`(error "bad value:" "x" 1 '(a b))`, `bad value: "x" 1 (a b)`},
+		{"(error-object-message *last-error*)", `"bad value: \"x\" 1 (a b)"`},
+		{"(error 'f)", "f"},
+		{`(define (check x) (if (< x 0) (error "negative:" x) x))`, "#<void>"},
+		{"(check 1)", "1"},
+		{"(check -1)", "negative: -1"},
+		{"(car (error-object-stack *last-error*))", `"#<procedure:error>"`},
+		{"(error 1)", "error: contract violation"},
+		{"(error)", "error: arity mismatch;"},
+	})
+}
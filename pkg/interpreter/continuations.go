@@ -0,0 +1,81 @@
+package interpreter
+
+import (
+	"strconv"
+
+	p "github.com/dimbata23/golang-scheme-interpreter/pkg/parser"
+)
+
+// escape continuation captured by call/cc, it can be applied only until
+// the call/cc which captured it returns and makes it return the value
+type continuation struct {
+	active bool // whether the call/cc which captured it hasn't returned yet
+}
+
+// application of a continuation, the evaluation is unwound to the call/cc
+// which captured it by propagating the error like any other error
+type escape struct {
+	err *p.Error      // the error being propagated
+	k   *continuation // the continuation being applied
+	val p.Expression  // the value the call/cc returns
+}
+
+/// ------------------------------------------------------------------------ ///
+/// -------------------- Continuation procedure methods -------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// (call/cc <procedure>)
+// calls the procedure with the continuation of the call/cc, applying the
+// continuation to a value returns the value from the call/cc, e.g. for an
+// early exit from a loop, continuations can't be reentered once it returns
+func (i *Interpreter) procCallCC(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen != 1 {
+		return &p.Void, newError(errArityMismatch, "call/cc", "1", strconv.Itoa(argsLen))
+	}
+
+	if !isCallable(args.Lst[0]) {
+		return &p.Void, newError(errContractViolation, "call/cc", "procedure?", errString(args.Lst[0]))
+	}
+
+	k := &continuation{active: true}
+	defer func() { k.active = false }()
+
+	proc := &p.Procedure{Name: "continuation", Fn: func(args *p.ExprList) (p.Expression, *p.Error) {
+		return i.applyContinuation(k, args)
+	}}
+
+	ex, err = i.genv.apply(args.Lst[0], &p.ExprList{Lst: []interface{ p.Expression }{proc}})
+	if err != nil && i.escaping != nil && i.escaping.err == err && i.escaping.k == k {
+		ex, i.escaping = i.escaping.val, nil
+		return ex, nil
+	}
+
+	return ex, err
+}
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------------- Helper functions --------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// starts unwinding the evaluation to the call/cc which captured
+// the continuation, returning the error to propagate
+func (i *Interpreter) applyContinuation(k *continuation, args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen > 1 {
+		return &p.Void, newError(errArityMismatch, "continuation", "0 or 1", strconv.Itoa(argsLen))
+	}
+
+	if !k.active {
+		return &p.Void, &p.Error{Val: "continuation application: attempt to jump into an escape continuation which is no longer active"}
+	}
+
+	var val p.Expression = &p.Void
+	if argsLen == 1 {
+		val = args.Lst[0]
+	}
+
+	err = &p.Error{Val: "continuation application: the continuation escaped past its call/cc"}
+	i.escaping = &escape{err: err, k: k, val: val}
+	return &p.Void, err
+}
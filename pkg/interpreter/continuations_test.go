@@ -0,0 +1,20 @@
+package interpreter
+
+import "testing"
+
+// continuations escape from the procedures the builtins apply
+func TestCallCCEscapes(t *testing.T) {
+	checkEvals(t, []evalTest{
+		{"(call/cc (lambda (k) (map (lambda (x) (if (< x 0) (k x) (* x 2))) '(1 -2 3))))", "-2"},
+		{"(call/cc (lambda (k) (map (lambda (x) (if (< x 0) (k x) (* x 2))) '(1 2 3))))", "(2 4 6)"},
+		{"(define seen '())", "#<void>"},
+		{"(call/cc (lambda (k) (for-each (lambda (x) (if (= x 3) (k 'found) (set! seen (cons x seen)))) '(1 2 3 4))))", "found"},
+		{"seen", "(2 1)"},
+		{"(call/cc (lambda (k) (sort '(3 1 2) (lambda (a b) (k 'escaped)))))", "escaped"},
+		{"(call/cc (lambda (k) (apply (lambda args (k (length args))) '(1 2 3))))", "3"},
+		{"(+ 1 (call/cc (lambda (k) (+ 10 (k 1)))))", "2"},
+		{"(define saved #f)", "#<void>"},
+		{"(call/cc (lambda (k) (set! saved k) 1))", "1"},
+		{"(saved 2)", "continuation application: attempt to jump into an escape continuation which is no longer active"},
+	})
+}
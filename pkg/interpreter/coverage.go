@@ -0,0 +1,156 @@
+package interpreter
+
+import (
+	"sort"
+
+	"github.com/dimbata23/golang-scheme-interpreter/pkg/lexer"
+	p "github.com/dimbata23/golang-scheme-interpreter/pkg/parser"
+)
+
+/// ------------------------------------------------------------------------ ///
+/// -------------------------- Public definitions -------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// a top-level form or a branch of an if or a cond whose
+// executions are recorded by the coverage
+type CoveragePoint struct {
+	Where  Location // where the form or the branch starts
+	Branch int      // 0 for a top-level form, 1 and 2 for the branches of an if, the number of the clause of a cond
+	Hits   int      // number of times it was executed
+}
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------------- Inner structure ---------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// the points of the source read while the coverage is recorded
+type coverage struct {
+	points []*CoveragePoint               // in the order they were read
+	byKey  map[coverageKey]*CoveragePoint // the points by their forms
+}
+
+// a point is a top-level form or a branch of the if or cond form
+type coverageKey struct {
+	form   *p.ExprList
+	branch int
+}
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------- Public functions and methods --------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// turns the recording of the coverage on or off, turning it on forgets the
+// points recorded so far, only the source read while it is on is covered
+func (i *Interpreter) SetCoverage(enabled bool) {
+	i.coverage = nil
+	if enabled {
+		i.coverage = &coverage{byKey: map[coverageKey]*CoveragePoint{}}
+	}
+}
+
+// returns the points of the covered source sorted by where they are,
+// the ones which weren't executed have no hits
+func (i *Interpreter) Coverage() []CoveragePoint {
+	if i.coverage == nil {
+		return nil
+	}
+
+	res := make([]CoveragePoint, len(i.coverage.points))
+	for j, point := range i.coverage.points {
+		res[j] = *point
+	}
+
+	sort.SliceStable(res, func(l, r int) bool {
+		lhs, rhs := res[l].Where, res[r].Where
+		if lhs.File != rhs.File {
+			return lhs.File < rhs.File
+		}
+
+		return lhs.Pos.Offset < rhs.Pos.Offset
+	})
+
+	return res
+}
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------------- Helper functions --------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// records the execution of a top-level form, the first time the form
+// is executed its branches are added as points without hits
+func (i *Interpreter) coverForm(expr p.Expression) {
+	form, isLst := expr.(*p.ExprList)
+	if i.coverage == nil || !isLst {
+		return
+	}
+
+	key := coverageKey{form: form}
+	if _, isKnown := i.coverage.byKey[key]; !isKnown {
+		i.coverage.add(key, Location{File: i.source, Pos: form.Pos})
+		i.coverage.addBranches(form, i.source)
+	}
+
+	i.coverage.byKey[key].Hits++
+}
+
+// records the execution of the branch of the if or cond form,
+// branches which weren't read while the coverage was on aren't covered
+func (i *Interpreter) coverBranch(form *p.ExprList, branch int) {
+	if i.coverage == nil {
+		return
+	}
+
+	if point, isKnown := i.coverage.byKey[coverageKey{form: form, branch: branch}]; isKnown {
+		point.Hits++
+	}
+}
+
+// adds a point without hits
+func (cov *coverage) add(key coverageKey, where Location) {
+	point := &CoveragePoint{Where: where, Branch: key.branch}
+	cov.points = append(cov.points, point)
+	cov.byKey[key] = point
+}
+
+// adds the branches of the if and cond forms in the code of the file,
+// quoted data has no branches
+func (cov *coverage) addBranches(expr p.Expression, file string) {
+	form, isLst := expr.(*p.ExprList)
+	if !isLst || form.Qlevel != 0 || len(form.Lst) == 0 {
+		return
+	}
+
+	head, _ := form.Lst[0].(*p.Variable)
+	if head != nil && head.Val == "quote" {
+		return
+	}
+
+	if head != nil && (head.Val == "if" || head.Val == "cond") {
+		// the branches of an if follow its condition, a cond has clauses
+		first := 2
+		if head.Val == "cond" {
+			first = 1
+		}
+
+		for j := first; j < len(form.Lst); j++ {
+			key := coverageKey{form: form, branch: j - first + 1}
+			if _, isKnown := cov.byKey[key]; !isKnown {
+				cov.add(key, Location{File: file, Pos: posOf(form.Lst[j], form)})
+			}
+		}
+	}
+
+	for _, item := range form.Lst {
+		cov.addBranches(item, file)
+	}
+}
+
+// returns where the expression starts, where the form it is in starts
+// if it isn't a list
+func posOf(expr p.Expression, form *p.ExprList) lexer.Position {
+	if lst, isLst := expr.(*p.ExprList); isLst {
+		return lst.Pos
+	}
+
+	return form.Pos
+}
@@ -0,0 +1,204 @@
+package interpreter
+
+import (
+	"strconv"
+
+	p "github.com/dimbata23/golang-scheme-interpreter/pkg/parser"
+)
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------- Environment procedure methods -------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// (make-environment [parent environment])
+// the new environment inherits the global definitions by default, the
+// definitions and the set! of the inherited bindings evaluated in it
+// bind the identifiers in it, leaving the bindings of the parent as they are
+func (i *Interpreter) procMakeEnvironment(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen > 1 {
+		return &p.Void, newError(errArityMismatch, "make-environment", "0 or 1", strconv.Itoa(argsLen))
+	}
+
+	parent := &i.genv
+	if argsLen == 1 {
+		parent, err = toEnvironment("make-environment", args.Lst[0])
+		if err != nil {
+			return &p.Void, err
+		}
+	}
+
+	i.stats.environments++
+
+	return &environment{parent: parent, vars: make(map[string]p.Expression), interp: i, isolated: true}, nil
+}
+
+// (interaction-environment)
+func (i *Interpreter) procInteractionEnvironment(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen != 0 {
+		return &p.Void, newError(errArityMismatch, "interaction-environment", "0", strconv.Itoa(argsLen))
+	}
+
+	return &i.genv, nil
+}
+
+// (eval <expression> [environment])
+func (i *Interpreter) procEval(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen < 1 || argsLen > 2 {
+		return &p.Void, newError(errArityMismatch, "eval", "1 or 2", strconv.Itoa(argsLen))
+	}
+
+	env := &i.genv
+	if argsLen == 2 {
+		env, err = toEnvironment("eval", args.Lst[1])
+		if err != nil {
+			return &p.Void, err
+		}
+	}
+
+	return env.eval(p.Unquote(args.Lst[0]))
+}
+
+// (eval-string <string> [environment])
+// evaluates the expressions in the string returning the result of the last
+// one, stops at the first error
+func (i *Interpreter) procEvalString(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen < 1 || argsLen > 2 {
+		return &p.Void, newError(errArityMismatch, "eval-string", "1 or 2", strconv.Itoa(argsLen))
+	}
+
+	src, isStr := p.AsString(args.Lst[0])
+	if !isStr {
+		return &p.Void, newError(errContractViolation, "eval-string", "string?", errString(args.Lst[0]))
+	}
+
+	env := &i.genv
+	if argsLen == 2 {
+		env, err = toEnvironment("eval-string", args.Lst[1])
+		if err != nil {
+			return &p.Void, err
+		}
+	}
+
+	return env.evalSource(src)
+}
+
+// (load-string <string>)
+// interprets the string like load interprets a file
+func (i *Interpreter) procLoadString(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen != 1 {
+		return &p.Void, newError(errArityMismatch, "load-string", "1", strconv.Itoa(argsLen))
+	}
+
+	src, isStr := p.AsString(args.Lst[0])
+	if !isStr {
+		return &p.Void, newError(errContractViolation, "load-string", "string?", errString(args.Lst[0]))
+	}
+
+	return &p.Void, i.genv.load(i.newParser(src))
+}
+
+// (environment? <expression>)
+func procIsEnvironment(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen != 1 {
+		return &p.Void, newError(errArityMismatch, "environment?", "1", strconv.Itoa(argsLen))
+	}
+
+	if _, isEnv := args.Lst[0].(*environment); isEnv {
+		return &p.True, nil
+	}
+
+	return &p.False, nil
+}
+
+// (environment-define! <environment> <symbol> <value>)
+func procEnvironmentDefine(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen != 3 {
+		return &p.Void, newError(errArityMismatch, "environment-define!", "3", strconv.Itoa(argsLen))
+	}
+
+	env, err := toEnvironment("environment-define!", args.Lst[0])
+	if err != nil {
+		return &p.Void, err
+	}
+
+	name, isSym := p.AsSymbolName(args.Lst[1])
+	if !isSym {
+		return &p.Void, newError(errContractViolation, "environment-define!", "symbol?", errString(args.Lst[1]))
+	}
+
+	env.vars[name] = args.Lst[2]
+
+	return &p.Void, nil
+}
+
+// (environment-ref <environment> <symbol>)
+func procEnvironmentRef(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen != 2 {
+		return &p.Void, newError(errArityMismatch, "environment-ref", "2", strconv.Itoa(argsLen))
+	}
+
+	env, err := toEnvironment("environment-ref", args.Lst[0])
+	if err != nil {
+		return &p.Void, err
+	}
+
+	name, isSym := p.AsSymbolName(args.Lst[1])
+	if !isSym {
+		return &p.Void, newError(errContractViolation, "environment-ref", "symbol?", errString(args.Lst[1]))
+	}
+
+	return env.find(name)
+}
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------------- Helper functions --------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// evaluates the scheme source in the environment returning the result
+// of the last expression, stops at the first error
+func (env *environment) evalSource(src string) (ex p.Expression, err *p.Error) {
+	par := env.interp.newParser(src)
+	ex = &p.Void
+	for {
+		expr, err := par.Next()
+		if expr == nil {
+			return ex, nil // parser has finished
+		}
+
+		if err != nil {
+			return &p.Void, err
+		}
+
+		env.interp.coverForm(expr)
+		ex, err = env.eval(expr)
+		if err != nil {
+			return &p.Void, err
+		}
+	}
+}
+
+// returns the given expression as an environment or an error
+func toEnvironment(procName string, arg p.Expression) (env *environment, err *p.Error) {
+	env, isEnv := arg.(*environment)
+	if !isEnv {
+		return nil, newError(errContractViolation, procName, "environment?", errString(arg))
+	}
+
+	return env, nil
+}
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------------- String() methods --------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+func (env *environment) String(_ int) string {
+	return "#<environment>"
+}
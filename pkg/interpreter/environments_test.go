@@ -0,0 +1,28 @@
+package interpreter
+
+import "testing"
+
+// the bindings a made environment inherits are shadowed by set! in it,
+// the bindings of its parent stay as they are
+func TestMakeEnvironmentIsolated(t *testing.T) {
+	checkEvals(t, []evalTest{
+		{"(define x 1)", "#<void>"},
+		{"(define env (make-environment))", "#<void>"},
+		{"(eval '(set! x 99) env)", "#<void>"},
+		{"x", "1"},
+		{"(eval 'x env)", "99"},
+		{"(eval '(define y 2) env)", "#<void>"},
+		{"y", "y: unbound identifier"},
+		{"(define child (make-environment env))", "#<void>"},
+		{"(eval '(set! x 5) child)", "#<void>"},
+		{"(list x (eval 'x env) (eval 'x child))", "(1 99 5)"},
+		{"(eval '(let ((z 1)) (set! z 2) z) env)", "2"},
+		{"(eval '((lambda (v) (set! x v) x) 7) env)", "7"},
+		{"(eval 'x env)", "7"},
+		{"x", "1"},
+		{"(define (bump!) (set! x (+ x 1)))", "#<void>"},
+		{"(eval '(bump!) env)", "#<void>"},
+		{"x", "2"},
+		{"(eval '(set! unbound 1) env)", "set!: assignment disallowed;"},
+	})
+}
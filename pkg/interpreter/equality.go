@@ -0,0 +1,91 @@
+package interpreter
+
+import (
+	"math"
+	"strconv"
+
+	p "github.com/dimbata23/golang-scheme-interpreter/pkg/parser"
+)
+
+/// ------------------------------------------------------------------------ ///
+/// ---------------------- Equality procedure methods ---------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// (eq? <expression> <expression>)
+// tests whether the two are the same object, symbols, booleans, characters
+// and small integers are the same whenever they have the same value
+func procIsEq(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	return procEqualityTest(args, "eq?", isEq)
+}
+
+// (eqv? <expression> <expression>)
+// like eq? but numbers are the same whenever they have the same value
+func procIsEqv(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	return procEqualityTest(args, "eqv?", isEqv)
+}
+
+// (equal? <expression> <expression>)
+// tests whether the two have the same structure, e.g. pairs with equal
+// cars and cdrs or strings with the same characters
+func procIsEqual(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	return procEqualityTest(args, "equal?", p.Equal)
+}
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------------- Helper functions --------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// returns whether the two arguments pass the test as a boolean
+func procEqualityTest(args *p.ExprList, procName string, test func(lhs p.Expression, rhs p.Expression) bool) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen != 2 {
+		return &p.Void, newError(errArityMismatch, procName, "2", strconv.Itoa(argsLen))
+	}
+
+	if test(args.Lst[0], args.Lst[1]) {
+		return &p.True, nil
+	}
+
+	return &p.False, nil
+}
+
+// tests whether the two expressions are the same object
+// the values which aren't allocated by scheme are compared by their value
+func isEq(lhs p.Expression, rhs p.Expression) bool {
+	if l, isNum := lhs.(*p.Number); isNum {
+		r, isNum := rhs.(*p.Number)
+		return l == r || isNum && isFixnum(l) && p.Equal(l, r)
+	}
+
+	return isEqv(lhs, rhs)
+}
+
+// tests whether the two expressions are the same object or
+// numbers, characters, booleans or symbols with the same value
+func isEqv(lhs p.Expression, rhs p.Expression) bool {
+	switch l := lhs.(type) {
+	case *p.Number:
+		r, isNum := rhs.(*p.Number)
+		return isNum && p.Equal(l, r)
+
+	case *p.Char:
+		r, isChar := rhs.(*p.Char)
+		return isChar && l.Val == r.Val
+
+	case *p.Boolean:
+		r, isBool := rhs.(*p.Boolean)
+		return isBool && l.Val == r.Val
+
+	case *p.Symbol:
+		r, isSym := rhs.(*p.Symbol)
+		return isSym && p.Equal(l, r)
+	}
+
+	return lhs == rhs
+}
+
+// tests whether the number is an integer small enough to be an immediate
+// value, such numbers are the same object whenever they are equal
+func isFixnum(num *p.Number) bool {
+	return !num.Inexact && num.Exact == nil && num.Ratio == nil && math.Abs(num.Val) < 1<<30
+}
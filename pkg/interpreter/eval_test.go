@@ -0,0 +1,153 @@
+package interpreter
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	p "github.com/dimbata23/golang-scheme-interpreter/pkg/parser"
+)
+
+// an expression and what it evaluates to as write prints it,
+// or the first line of the message of its error
+type evalTest struct {
+	src  string
+	want string
+}
+
+// evaluates the expressions of the tests in order in one interpreter,
+// reporting every one which doesn't evaluate to what it should
+func checkEvals(t *testing.T, tests []evalTest) {
+	t.Helper()
+
+	i := NewInterpreter()
+	defer i.Close()
+
+	for _, test := range tests {
+		results := i.Eval(test.src)
+		if len(results) != 1 {
+			t.Errorf("%s: got %d results, want 1", test.src, len(results))
+			continue
+		}
+
+		var got string
+		if err := results[0].Err; err != nil {
+			got = strings.SplitN(err.Val, "\n", 2)[0]
+		} else {
+			got = p.WriteString(results[0].Value)
+		}
+
+		if got != test.want {
+			t.Errorf("%s = %s, want %s", test.src, got, test.want)
+		}
+	}
+}
+
+// every kind of value evaluates to itself, e.g. when a value is spliced
+// into code given to eval, only identifiers, lists of code and the (exit)
+// command are evaluated otherwise
+func TestEvalSelfEvaluating(t *testing.T) {
+	i := NewInterpreter()
+	defer i.Close()
+
+	recordType := &p.RecordType{Name: "point", Fields: []string{"x", "y"}}
+	tests := []struct {
+		name string
+		expr p.Expression
+	}{
+		{"exact integer", p.NewNumber(42)},
+		{"inexact real", p.NewReal(1.5)},
+		{"symbol", p.NewSymbol("sym")},
+		{"null", &p.NullSym},
+		{"boolean", &p.True},
+		{"string", p.NewString("str")},
+		{"char", p.NewChar('a')},
+		{"keyword", i.keywords.Keyword("key")},
+		{"string builder", &p.StringBuilder{}},
+		{"input port", p.NewStringInputPort("")},
+		{"output port", p.NewOutputPort("discard", io.Discard)},
+		{"eof object", &p.EOFObject},
+		{"void", &p.Void},
+		{"hash table", p.NewHashTable()},
+		{"vector", p.NewVector([]p.Expression{p.NewNumber(1)})},
+		{"record type", recordType},
+		{"record", &p.Record{Type: recordType, Vals: []p.Expression{p.NewNumber(1), p.NewNumber(2)}}},
+		{"promise", &p.Promise{Val: p.NewNumber(1), Forced: true}},
+		{"multiple values", &p.MultipleValues{Vals: []p.Expression{p.NewNumber(1), p.NewNumber(2)}}},
+		{"pair", p.Cons(p.NewNumber(1), p.NewNumber(2))},
+		{"procedure", &p.Procedure{Name: "proc", Fn: procAdd}},
+		{"lambda", &p.Lambda{Name: "lam", Params: &p.ExprList{}, Body: &p.ExprList{}}},
+		{"environment", &i.genv},
+		{"generator", &generator{}},
+		{"condition", &errorObject{err: &p.Error{Val: "failed"}}},
+		{"macro", &macro{name: "mac"}},
+		{"continuation mark set", &markSet{}},
+	}
+
+	for _, test := range tests {
+		res, err := i.genv.eval(test.expr)
+		if err != nil {
+			t.Errorf("%s: evaluating %s failed: %s", test.name, errString(test.expr), err.String())
+			continue
+		}
+
+		if res != test.expr {
+			t.Errorf("%s: %s evaluates to %s, want itself", test.name, errString(test.expr), errString(res))
+		}
+	}
+}
+
+// the expressions which aren't values evaluate to what they stand for
+func TestEvalCode(t *testing.T) {
+	i := NewInterpreter()
+	defer i.Close()
+	i.Define("x", p.NewNumber(5))
+
+	tests := []struct {
+		name string
+		expr p.Expression
+		want p.Expression
+	}{
+		{"identifier", &p.Variable{Val: "x"}, p.NewNumber(5)},
+		{"application", &p.ExprList{Lst: []interface{ p.Expression }{&p.Variable{Val: "+"}, &p.Variable{Val: "x"}, p.NewNumber(1)}}, p.NewNumber(6)},
+		{"quoted list", &p.ExprList{Lst: []interface{ p.Expression }{p.NewNumber(1), &p.NullSym}, Qlevel: 1}, p.List(p.NewNumber(1))},
+	}
+
+	for _, test := range tests {
+		res, err := i.genv.eval(test.expr)
+		if err != nil {
+			t.Errorf("%s: evaluating %s failed: %s", test.name, p.CodeString(test.expr), err.String())
+			continue
+		}
+
+		if !p.Equal(res, test.want) {
+			t.Errorf("%s: %s evaluates to %s, want %s", test.name, p.CodeString(test.expr), errString(res), errString(test.want))
+		}
+	}
+}
+
+// outside of strict mode a lambda may be made with a parameter which isn't
+// an identifier, applying it is a bad syntax printing nothing
+func TestNonIdentifierParams(t *testing.T) {
+	i := NewInterpreter()
+	defer i.Close()
+
+	var out strings.Builder
+	i.SetOutputPort(i.NewPortFromWriter(&out))
+
+	for _, src := range []string{"((lambda (1) 1) 2)", "((lambda (a . 1) a) 2)"} {
+		res := i.Eval(src)
+		if len(res) != 1 || res[0].Err == nil {
+			t.Errorf("%s didn't fail", src)
+			continue
+		}
+
+		if msg := res[0].Err.Val; !strings.HasPrefix(msg, "lambda: bad syntax") {
+			t.Errorf("%s failed with %q, want a bad syntax", src, msg)
+		}
+	}
+
+	if out.Len() != 0 {
+		t.Errorf("applying the lambdas printed %q", out.String())
+	}
+}
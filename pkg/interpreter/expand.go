@@ -0,0 +1,213 @@
+package interpreter
+
+import (
+	"github.com/dimbata23/golang-scheme-interpreter/pkg/lexer"
+	p "github.com/dimbata23/golang-scheme-interpreter/pkg/parser"
+)
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------- Public functions and methods --------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// rewrites the given expression into the core forms without evaluating it
+// the uses of the macros defined in the interpreter with define-macro and
+// define-syntax are expanded until none are left, then
+// (define (<name> [params...]) <body...>) becomes a define of a lambda and
+// cond becomes nested ifs, quoted data and macro definitions are left as they are
+func (i *Interpreter) Expand(expr p.Expression) (ex p.Expression, err *p.Error) {
+	return i.genv.expand(expr)
+}
+
+// expands the expressions of the given string, read the way Eval reads
+// them, returns the expansion of each expression in the order of the input
+func (i *Interpreter) ExpandString(input string) []Result {
+	par := i.newParser(input)
+	results := []Result{}
+
+	for {
+		expr, err := par.Next()
+		if expr == nil {
+			return results
+		}
+
+		if err == nil {
+			expr, err = i.genv.expand(expr)
+		}
+
+		results = append(results, makeResult(par, expr, err))
+	}
+}
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------------- Helper functions --------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// expands the given expression and all of its subexpressions
+func (env *environment) expand(expr p.Expression) (ex p.Expression, err *p.Error) {
+	for {
+		lst, isLst := expr.(*p.ExprList)
+		if !isLst || lst.Qlevel > 0 || len(lst.Lst) == 0 {
+			return expr, nil
+		}
+
+		// special forms come before macros like in eval
+		if head, isVar := lst.Lst[0].(*p.Variable); isVar && env.interp.forms[head.Val] != nil {
+			break
+		}
+
+		m, isMacro := env.macroOf(lst)
+		if !isMacro {
+			break
+		}
+
+		if expr, err = env.expandMacro(m, lst); err != nil {
+			return &p.Void, err
+		}
+	}
+
+	lst := expr.(*p.ExprList)
+	if head, isVar := lst.Lst[0].(*p.Variable); isVar {
+		switch head.Val {
+		case "quote", "quasiquote", "define-macro", "define-syntax":
+			return lst, nil
+		case "define":
+			return env.expandDefine(lst)
+		case "cond":
+			return env.expandCond(lst)
+		}
+	}
+
+	res, err := env.expandAll(lst, lst.Lst)
+	if err != nil {
+		return &p.Void, err
+	}
+
+	res.Tail = lst.Tail
+	return res, nil
+}
+
+// returns a copy of the list with its items replaced by their expansions
+func (env *environment) expandAll(lst *p.ExprList, items []interface{ p.Expression }) (ex *p.ExprList, err *p.Error) {
+	res := &p.ExprList{Lst: make([]interface{ p.Expression }, len(items)), Pos: lst.Pos}
+	for i, item := range items {
+		res.Lst[i], err = env.expand(item)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
+// (define (<name> [params...]) <body...>) => (define <name> (lambda ([params...]) <body...>))
+func (env *environment) expandDefine(lst *p.ExprList) (ex p.Expression, err *p.Error) {
+	if len(lst.Lst) < 3 {
+		return env.expandAll(lst, lst.Lst)
+	}
+
+	sign, isLst := lst.Lst[1].(*p.ExprList)
+	if !isLst || sign.Qlevel > 0 || len(sign.Lst) == 0 {
+		return env.expandAll(lst, lst.Lst)
+	}
+
+	body, err := env.expandAll(lst, lst.Lst[2:])
+	if err != nil {
+		return &p.Void, err
+	}
+
+	params := &p.ExprList{Lst: sign.Lst[1:], Pos: sign.Pos, Tail: sign.Tail}
+	lambda := append([]interface{ p.Expression }{&p.Variable{Val: "lambda"}, params}, body.Lst...)
+
+	def := []interface{ p.Expression }{lst.Lst[0], sign.Lst[0], &p.ExprList{Lst: lambda, Pos: lst.Pos}}
+	return &p.ExprList{Lst: def, Pos: lst.Pos}, nil
+}
+
+// (cond (<test> <body...>) ... [(else <body...>)]) => (if <test> <body> (if ...))
+// a clause with several body expressions becomes a begin of them,
+// a (<test>) clause becomes (or <test> ...) and a (<test> => <receiver>) clause
+// passes the value of the test, the receiver and the rest as thunks to a lambda
+// so the bindings of its parameters can't capture their identifiers
+func (env *environment) expandCond(lst *p.ExprList) (ex p.Expression, err *p.Error) {
+	var res p.Expression
+	for i := len(lst.Lst) - 1; i > 0; i-- {
+		clause, isPair := asClause(lst.Lst[i])
+		if !isPair || clause.Qlevel > 0 {
+			return &p.Void, newError(errBadSyntax, "cond", "pair? as a test clause", errString(lst.Lst[i]))
+		}
+
+		clauseLst, err := env.expandAll(clause, clause.Lst)
+		if err != nil {
+			return &p.Void, err
+		}
+
+		test := clauseLst.Lst[0]
+		if len(clauseLst.Lst) == 1 {
+			res = condTestOnly(test, res, clause.Pos)
+			continue
+		}
+
+		if arrow, isVar := clauseLst.Lst[1].(*p.Variable); isVar && arrow.Val == "=>" {
+			if len(clauseLst.Lst) != 3 {
+				return &p.Void, newError(errBadSyntax, "cond", "(<test> => <receiver>) as a clause", errString(clause))
+			}
+
+			res = condArrow(test, clauseLst.Lst[2], res, clause.Pos)
+			continue
+		}
+
+		body := clauseLst.Lst[1]
+		if len(clauseLst.Lst) > 2 {
+			begin := append([]interface{ p.Expression }{&p.Variable{Val: "begin"}}, clauseLst.Lst[1:]...)
+			body = &p.ExprList{Lst: begin, Pos: clause.Pos}
+		}
+
+		if v, isVar := test.(*p.Variable); isVar && v.Val == "else" {
+			res = body
+			continue
+		}
+
+		ifLst := []interface{ p.Expression }{&p.Variable{Val: "if"}, test, body}
+		if res != nil {
+			ifLst = append(ifLst, res)
+		}
+		res = &p.ExprList{Lst: ifLst, Pos: clause.Pos}
+	}
+
+	if res == nil {
+		return &p.ExprList{Lst: lst.Lst[:1], Pos: lst.Pos}, nil
+	}
+
+	return res, nil
+}
+
+// (<test>) => (or <test> <rest>)
+func condTestOnly(test p.Expression, rest p.Expression, pos lexer.Position) p.Expression {
+	if rest == nil {
+		rest = voidCode(pos)
+	}
+
+	return &p.ExprList{Lst: []interface{ p.Expression }{&p.Variable{Val: "or"}, test, rest}, Pos: pos}
+}
+
+// (<test> => <receiver>) =>
+// ((lambda (v r k) (if v ((r) v) (k))) <test> (lambda () <receiver>) (lambda () <rest>))
+func condArrow(test p.Expression, receiver p.Expression, rest p.Expression, pos lexer.Position) p.Expression {
+	if rest == nil {
+		rest = voidCode(pos)
+	}
+
+	code := func(items ...interface{ p.Expression }) *p.ExprList {
+		return &p.ExprList{Lst: items, Pos: pos}
+	}
+
+	lambda := &p.Variable{Val: "lambda"}
+	val, rec, next := &p.Variable{Val: "v"}, &p.Variable{Val: "r"}, &p.Variable{Val: "k"}
+	body := code(&p.Variable{Val: "if"}, val, code(code(rec), val), code(next))
+
+	return code(code(lambda, code(val, rec, next), body), test, code(lambda, code(), receiver), code(lambda, code(), rest))
+}
+
+// returns (if #f #f), the value of a cond none of whose clauses is true
+func voidCode(pos lexer.Position) p.Expression {
+	return &p.ExprList{Lst: []interface{ p.Expression }{&p.Variable{Val: "if"}, &p.False, &p.False}, Pos: pos}
+}
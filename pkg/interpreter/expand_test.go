@@ -0,0 +1,65 @@
+package interpreter
+
+import (
+	"testing"
+
+	p "github.com/dimbata23/golang-scheme-interpreter/pkg/parser"
+)
+
+func TestExpandString(t *testing.T) {
+	i := NewInterpreter()
+	defer i.Close()
+
+	i.SetReaderHandler("twice", func(datum p.Expression) (p.Expression, *p.Error) {
+		return p.List(datum, datum), nil
+	})
+	mustEval(t, i, "(define-syntax inc (syntax-rules () ((_ a) (+ a 1))))")
+
+	tests := []struct {
+		src  string
+		want string
+	}{
+		{"(inc 2)", "(+ 2 1)"},
+		{"(define (f x) (inc x))", "(define f (lambda (x) (+ x 1)))"},
+		{"(cond (a 1 2) (else 3))", "(if a (begin 1 2) 3)"},
+		{"(cond (a 1))", "(if a 1)"},
+		{"'(inc 2)", "'(inc 2)"},
+		{"#twice 1", "'(1 1)"},
+	}
+
+	for _, test := range tests {
+		res := i.ExpandString(test.src)
+		if len(res) != 1 || res[0].Err != nil {
+			t.Errorf("expanding %s failed: %v", test.src, res)
+			continue
+		}
+
+		if got := p.CodeString(res[0].Value); got != test.want {
+			t.Errorf("%s expands to %s, want %s", test.src, got, test.want)
+		}
+	}
+
+	// keywords are read as the keywords of the interpreter
+	if res := i.ExpandString("#:key"); len(res) != 1 || res[0].Value != mustEval(t, i, "#:key") {
+		t.Errorf("ExpandString reads #:key as another keyword than Eval")
+	}
+}
+
+// the definitions in the clauses of the expanded cond are made where
+// the cond is, not in a scope of their own
+func TestExpandedCondDefines(t *testing.T) {
+	i := NewInterpreter()
+	defer i.Close()
+
+	res := i.ExpandString("(cond (#t (define x 1) (define y 2)))")
+	if len(res) != 1 || res[0].Err != nil {
+		t.Fatalf("expanding the cond failed: %v", res)
+	}
+
+	if _, err := i.genv.eval(res[0].Value); err != nil {
+		t.Fatalf("evaluating %s failed: %s", p.CodeString(res[0].Value), err.Val)
+	}
+	if got := p.WriteString(mustEval(t, i, "(+ x y)")); got != "3" {
+		t.Errorf("(+ x y) = %s after the cond, want 3", got)
+	}
+}
@@ -0,0 +1,148 @@
+package interpreter
+
+import (
+	"runtime"
+	"strconv"
+
+	p "github.com/dimbata23/golang-scheme-interpreter/pkg/parser"
+)
+
+func init() {
+	registerSpecialForm("cond-expand", (*environment).evalCondExpand)
+}
+
+// features every interpreter has
+var defaultFeatures = []string{
+	"r7rs",
+	"golang-scheme-interpreter",
+	"full-unicode",
+	runtime.GOOS,
+	runtime.GOARCH,
+}
+
+/// ------------------------------------------------------------------------ ///
+/// ------------------------- Special form methods ------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// (cond-expand (<feature requirement> <body expressions...>) ... [(else <body expressions...>)])
+// a feature requirement is a feature identifier or one of
+// (and [requirements...]), (or [requirements...]), (not <requirement>)
+// and (library <name>)
+func (env *environment) evalCondExpand(lst *p.ExprList) (ex p.Expression, err *p.Error) {
+	for _, ex := range lst.Lst[1:] {
+		clause, isLst := ex.(*p.ExprList)
+		if !isLst || clause.Qlevel > 0 || len(clause.Lst) == 0 {
+			return &p.Void, newError(errBadSyntax, "cond-expand", "(<feature requirement> <body expressions...>) as a clause", p.CodeString(ex))
+		}
+
+		isMet := false
+		if req, isVar := clause.Lst[0].(*p.Variable); isVar && req.Val == "else" {
+			isMet = true
+		} else {
+			isMet, err = env.interp.hasFeatures(clause.Lst[0])
+			if err != nil {
+				return &p.Void, err
+			}
+		}
+
+		if isMet {
+			var res p.Expression = &p.Void
+			for _, expr := range clause.Lst[1:] {
+				res, err = env.eval(expr)
+				if err != nil {
+					return &p.Void, err
+				}
+			}
+			return res, nil
+		}
+	}
+
+	return &p.Void, nil
+}
+
+/// ------------------------------------------------------------------------ ///
+/// ------------------------ Feature procedure methods --------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// (features)
+func (i *Interpreter) procFeatures(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen != 0 {
+		return &p.Void, newError(errArityMismatch, "features", "0", strconv.Itoa(argsLen))
+	}
+
+	features := i.features()
+	res := make([]p.Expression, len(features))
+	for j, feature := range features {
+		res[j] = p.NewSymbol(feature)
+	}
+
+	return p.List(res...), nil
+}
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------------- Helper functions --------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// returns the identifiers of the features of the interpreter
+// including the enabled extensions
+func (i *Interpreter) features() []string {
+	res := append([]string{}, defaultFeatures...)
+	if i.strict {
+		res = append(res, "strict")
+	}
+
+	return res
+}
+
+// tests whether the interpreter meets the feature requirement
+func (i *Interpreter) hasFeatures(req p.Expression) (isMet bool, err *p.Error) {
+	switch rq := req.(type) {
+	case *p.Variable:
+		return containsString(i.features(), rq.Val), nil
+
+	case *p.ExprList:
+		if rq.Qlevel > 0 || len(rq.Lst) == 0 {
+			break
+		}
+
+		head, isVar := rq.Lst[0].(*p.Variable)
+		if !isVar {
+			break
+		}
+
+		reqs := rq.Lst[1:]
+		switch head.Val {
+		case "and", "or":
+			isOr := head.Val == "or"
+			for _, sub := range reqs {
+				isMet, err := i.hasFeatures(sub)
+				if err != nil {
+					return false, err
+				}
+
+				if isMet == isOr {
+					return isOr, nil
+				}
+			}
+			return !isOr, nil
+
+		case "not":
+			if len(reqs) != 1 {
+				return false, newError(errBadSyntax, "cond-expand", "(not <requirement>)", p.CodeString(req))
+			}
+
+			isMet, err := i.hasFeatures(reqs[0])
+			return !isMet && err == nil, err
+
+		case "library":
+			if len(reqs) != 1 {
+				return false, newError(errBadSyntax, "cond-expand", "(library <name>)", p.CodeString(req))
+			}
+
+			return false, nil // there are no libraries to import
+		}
+	}
+
+	return false, newError(errBadSyntax, "cond-expand", "a feature requirement", p.CodeString(req))
+}
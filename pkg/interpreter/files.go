@@ -0,0 +1,206 @@
+package interpreter
+
+import (
+	"errors"
+	"os"
+	"strconv"
+
+	p "github.com/dimbata23/golang-scheme-interpreter/pkg/parser"
+)
+
+// flags opening a file for output by what is done if it exists,
+// the mode is given by the #:exists option
+var existsModes = map[string]int{
+	"error":    os.O_EXCL,
+	"replace":  os.O_TRUNC,
+	"truncate": os.O_TRUNC,
+	"append":   os.O_APPEND,
+}
+
+/// ------------------------------------------------------------------------ ///
+/// ------------------------ File procedure methods ------------------------ ///
+/// ------------------------------------------------------------------------ ///
+
+// (open-input-file <path>)
+// relative paths are resolved against the current directory
+func (i *Interpreter) procOpenInputFile(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	path, err := toPath("open-input-file", args)
+	if err != nil {
+		return &p.Void, err
+	}
+
+	return i.openInputFile("open-input-file", path)
+}
+
+// (open-output-file <path> [#:exists <mode>])
+// the mode says what is done if the file exists, one of 'error,
+// the default, 'replace, 'truncate and 'append
+func (i *Interpreter) procOpenOutputFile(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	positional, opts, err := splitOptions("open-output-file", args.Lst, "exists")
+	if err != nil {
+		return &p.Void, err
+	}
+
+	path, err := toPath("open-output-file", &p.ExprList{Lst: positional})
+	if err != nil {
+		return &p.Void, err
+	}
+
+	flag, err := toExistsFlag("open-output-file", opts)
+	if err != nil {
+		return &p.Void, err
+	}
+
+	return i.openOutputFile("open-output-file", path, flag)
+}
+
+// (with-input-from-file <path> <thunk>)
+// calls the thunk with a port reading the file as the current input port,
+// the port is closed when the thunk returns
+func (i *Interpreter) procWithInputFromFile(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen != 2 {
+		return &p.Void, newError(errArityMismatch, "with-input-from-file", "2", strconv.Itoa(argsLen))
+	}
+
+	path, err := toPath("with-input-from-file", &p.ExprList{Lst: args.Lst[:1]})
+	if err != nil {
+		return &p.Void, err
+	}
+
+	if !isCallable(args.Lst[1]) {
+		return &p.Void, newError(errContractViolation, "with-input-from-file", "procedure?", errString(args.Lst[1]))
+	}
+
+	port, err := i.openInputFile("with-input-from-file", path)
+	if err != nil {
+		return &p.Void, err
+	}
+
+	prev := i.inputPort
+	i.inputPort = port
+	ex, err = i.genv.apply(args.Lst[1], &p.ExprList{})
+	i.inputPort = prev
+
+	if closeErr := i.closePort("with-input-from-file", port); err == nil {
+		err = closeErr
+	}
+
+	return ex, err
+}
+
+// (with-output-to-file <path> <thunk> [#:exists <mode>])
+// calls the thunk with a port writing to the file as the current output
+// port, the port is closed when the thunk returns, the mode is the one
+// of open-output-file
+func (i *Interpreter) procWithOutputToFile(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	positional, opts, err := splitOptions("with-output-to-file", args.Lst, "exists")
+	if err != nil {
+		return &p.Void, err
+	}
+
+	argsLen := len(positional)
+	if argsLen != 2 {
+		return &p.Void, newError(errArityMismatch, "with-output-to-file", "2", strconv.Itoa(argsLen))
+	}
+
+	path, err := toPath("with-output-to-file", &p.ExprList{Lst: positional[:1]})
+	if err != nil {
+		return &p.Void, err
+	}
+
+	if !isCallable(positional[1]) {
+		return &p.Void, newError(errContractViolation, "with-output-to-file", "procedure?", errString(positional[1]))
+	}
+
+	flag, err := toExistsFlag("with-output-to-file", opts)
+	if err != nil {
+		return &p.Void, err
+	}
+
+	port, err := i.openOutputFile("with-output-to-file", path, flag)
+	if err != nil {
+		return &p.Void, err
+	}
+
+	prev := i.outputPort
+	i.outputPort = port
+	ex, err = i.genv.apply(positional[1], &p.ExprList{})
+	i.outputPort = prev
+
+	if closeErr := i.closePort("with-output-to-file", port); err == nil {
+		err = closeErr
+	}
+
+	return ex, err
+}
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------------- Helper functions --------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// opens the file as an input port, which is closed with the interpreter
+// unless it is closed before
+func (i *Interpreter) openInputFile(procName string, path string) (port *p.Port, err *p.Error) {
+	file, ioerr := os.Open(i.resolvePath(path))
+	if ioerr != nil {
+		return nil, fileError(procName, "cannot open input file", path, ioerr)
+	}
+
+	port = p.NewClosingInputPort(path, file)
+	i.trackPort(port)
+	return port, nil
+}
+
+// opens the file as an output port, creating it if it doesn't exist,
+// which is closed with the interpreter unless it is closed before
+func (i *Interpreter) openOutputFile(procName string, path string, flag int) (port *p.Port, err *p.Error) {
+	file, ioerr := os.OpenFile(i.resolvePath(path), os.O_WRONLY|os.O_CREATE|flag, 0666)
+	if ioerr != nil {
+		return nil, fileError(procName, "cannot open output file", path, ioerr)
+	}
+
+	port = p.NewClosingOutputPort(path, file)
+	i.trackPort(port)
+	return port, nil
+}
+
+// remembers the port to close it when the interpreter is closed
+func (i *Interpreter) trackPort(port *p.Port) {
+	if i.openPorts == nil {
+		i.openPorts = map[*p.Port]bool{}
+	}
+
+	i.openPorts[port] = true
+}
+
+// closes the port, which no longer needs closing with the interpreter
+func (i *Interpreter) closePort(procName string, port *p.Port) *p.Error {
+	delete(i.openPorts, port)
+	if ioerr := port.Close(); ioerr != nil {
+		return &p.Error{Val: procName + ": " + ioerr.Error()}
+	}
+
+	return nil
+}
+
+// returns the flag of the #:exists option, 'error if it isn't given
+func toExistsFlag(procName string, opts options) (flag int, err *p.Error) {
+	mode := opts.get("exists", p.NewSymbol("error"))
+	name, isSym := p.AsSymbolName(mode)
+	if flag, isMode := existsModes[name]; isSym && isMode {
+		return flag, nil
+	}
+
+	return 0, newError(errContractViolation, procName, "(or/c 'error 'replace 'truncate 'append)", errString(mode))
+}
+
+// returns the error of opening the file
+func fileError(procName string, msg string, path string, ioerr error) *p.Error {
+	var pathErr *os.PathError
+	if errors.As(ioerr, &pathErr) {
+		ioerr = pathErr.Err
+	}
+
+	return &p.Error{Val: procName + ": " + msg + "\n  path: " + path + "\n  system error: " + ioerr.Error()}
+}
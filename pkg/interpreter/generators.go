@@ -0,0 +1,252 @@
+package interpreter
+
+import (
+	"runtime"
+	"strconv"
+	"sync"
+
+	p "github.com/dimbata23/golang-scheme-interpreter/pkg/parser"
+)
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------------- Inner structure ---------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// scheme generator, a procedure running as a coroutine over a goroutine
+// the goroutine only refers to the coroutine, so once the generator is
+// garbage collected its coroutine is stopped, if it is waiting in a yield,
+// the next time the interpreter makes or runs a generator
+type generator struct {
+	co *coroutine
+}
+
+// the state of a generator shared with the goroutine running it
+type coroutine struct {
+	proc    p.Expression       // the procedure producing the values
+	resume  chan struct{}      // continues the procedure after a yield
+	results chan generatorStep // values yielded by the procedure
+	started bool               // whether the procedure has been started
+	done    bool               // whether the procedure has returned, its value is produced last
+	marks   *markFrame         // continuation marks of the procedure when it yielded
+	stopped bool               // whether the procedure has to return from the yield it waits in
+}
+
+// the coroutines of the collected generators, stopped by the interpreter
+// on its own goroutine as the finalizers run on another one
+type abandonedCoroutines struct {
+	mu  sync.Mutex
+	cos []*coroutine
+}
+
+// a single step of a generator
+type generatorStep struct {
+	val  p.Expression // the yielded value
+	err  *p.Error     // an error that stopped the procedure
+	done bool         // whether the procedure has returned
+}
+
+/// ------------------------------------------------------------------------ ///
+/// ---------------------- Generator procedure methods --------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// (make-generator <procedure>)
+// the procedure takes no arguments and produces values with (yield <value>)
+// followed by the value it returns unless it is void
+func (i *Interpreter) procMakeGenerator(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen != 1 {
+		return &p.Void, newError(errArityMismatch, "make-generator", "1", strconv.Itoa(argsLen))
+	}
+
+	if !isCallable(args.Lst[0]) {
+		return &p.Void, newError(errContractViolation, "make-generator", "procedure?", errString(args.Lst[0]))
+	}
+
+	i.stopAbandoned()
+
+	gen := &generator{co: &coroutine{
+		proc:    args.Lst[0],
+		resume:  make(chan struct{}),
+		results: make(chan generatorStep),
+	}}
+	runtime.SetFinalizer(gen, func(gen *generator) { i.abandoned.add(gen.co) })
+
+	return gen, nil
+}
+
+// (generator-next <generator>)
+// returns the next yielded value, then the value the procedure returns
+// unless it is void, and the eof object once the generator is done
+func (i *Interpreter) procGeneratorNext(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen != 1 {
+		return &p.Void, newError(errArityMismatch, "generator-next", "1", strconv.Itoa(argsLen))
+	}
+
+	gen, isGen := args.Lst[0].(*generator)
+	if !isGen {
+		return &p.Void, newError(errContractViolation, "generator-next", "generator?", errString(args.Lst[0]))
+	}
+
+	co := gen.co
+	if co.done {
+		return &p.EOFObject, nil
+	}
+
+	for _, running := range i.generators {
+		if running == co {
+			return &p.Void, newError(errContractViolation, "generator-next", "a generator that isn't running", errString(gen))
+		}
+	}
+
+	i.stopAbandoned()
+
+	// the generator keeps its own continuation marks between the steps
+	marks := i.marks
+	i.generators = append(i.generators, co)
+	if co.started {
+		i.marks = co.marks
+		co.resume <- struct{}{}
+	} else {
+		co.started = true
+		go i.runGenerator(co)
+	}
+
+	step := <-co.results
+	i.generators = i.generators[:len(i.generators)-1]
+	co.marks, i.marks = i.marks, marks
+
+	if step.done {
+		co.done = true
+		delete(i.suspended, co)
+		if step.err != nil {
+			return &p.Void, step.err
+		}
+
+		// the value the procedure returns is the last one
+		if step.val == nil || step.val == &p.Void {
+			return &p.EOFObject, nil
+		}
+
+		return step.val, nil
+	}
+
+	if i.suspended == nil {
+		i.suspended = map[*coroutine]bool{}
+	}
+	i.suspended[co] = true
+
+	return step.val, nil
+}
+
+// (yield <value>)
+// can only be called while a generator is producing its next value
+func (i *Interpreter) procYield(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen != 1 {
+		return &p.Void, newError(errArityMismatch, "yield", "1", strconv.Itoa(argsLen))
+	}
+
+	genLen := len(i.generators)
+	if genLen == 0 {
+		return &p.Void, &p.Error{Val: "yield: must be called in the context of a generator"}
+	}
+
+	co := i.generators[genLen-1]
+	co.results <- generatorStep{val: args.Lst[0]}
+	<-co.resume
+
+	if co.stopped {
+		return &p.Void, &p.Error{Val: "yield: the generator was stopped"}
+	}
+
+	return &p.Void, nil
+}
+
+// (generator? <expression>)
+func procIsGenerator(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen != 1 {
+		return &p.Void, newError(errArityMismatch, "generator?", "1", strconv.Itoa(argsLen))
+	}
+
+	if _, isGen := args.Lst[0].(*generator); isGen {
+		return &p.True, nil
+	}
+
+	return &p.False, nil
+}
+
+// (generator-done? <generator>)
+func procIsGeneratorDone(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen != 1 {
+		return &p.Void, newError(errArityMismatch, "generator-done?", "1", strconv.Itoa(argsLen))
+	}
+
+	gen, isGen := args.Lst[0].(*generator)
+	if !isGen {
+		return &p.Void, newError(errContractViolation, "generator-done?", "generator?", errString(args.Lst[0]))
+	}
+
+	if gen.co.done {
+		return &p.True, nil
+	}
+
+	return &p.False, nil
+}
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------------- Helper functions --------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// runs the procedure of the generator reporting when it returns
+func (i *Interpreter) runGenerator(co *coroutine) {
+	val, err := i.genv.apply(co.proc, &p.ExprList{})
+	co.results <- generatorStep{val: val, err: err, done: true}
+}
+
+// stops the coroutines of the collected generators waiting in a yield
+func (i *Interpreter) stopAbandoned() {
+	for _, co := range i.abandoned.take() {
+		if i.suspended[co] {
+			delete(i.suspended, co)
+			co.stop()
+		}
+	}
+}
+
+// makes the procedure of the suspended generator return from its
+// yield with an error and waits until it has returned
+func (co *coroutine) stop() {
+	co.stopped = true
+	co.resume <- struct{}{}
+	<-co.results
+	co.done = true
+}
+
+// adds the coroutine of a collected generator
+func (ac *abandonedCoroutines) add(co *coroutine) {
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+
+	ac.cos = append(ac.cos, co)
+}
+
+// returns the coroutines added so far and forgets them
+func (ac *abandonedCoroutines) take() []*coroutine {
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+
+	cos := ac.cos
+	ac.cos = nil
+	return cos
+}
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------------- String() methods --------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+func (gen *generator) String(_ int) string {
+	return "#<generator>"
+}
@@ -0,0 +1,60 @@
+package interpreter
+
+import (
+	"runtime"
+	"testing"
+	"time"
+
+	p "github.com/dimbata23/golang-scheme-interpreter/pkg/parser"
+)
+
+func TestGenerators(t *testing.T) {
+	checkEvals(t, []evalTest{
+		{"(define g (make-generator (lambda () (yield 1) 42)))", "#<void>"},
+		{"(generator-next g)", "1"},
+		{"(generator-done? g)", "#f"},
+		{"(generator-next g)", "42"},
+		{"(generator-done? g)", "#t"},
+		{"(generator-next g)", "#<eof>"},
+		{"(define h (make-generator (lambda () (yield 'a) (set! g 0))))", "#<void>"},
+		{"(list (generator-next h) (generator-next h) (generator-next h))", "(a #<eof> #<eof>)"},
+		{"(define e (make-generator (lambda () (yield 1) (car 1))))", "#<void>"},
+		{"(generator-next e)", "1"},
+		{"(generator-next e)", "car: contract violation"},
+		{"(generator-next e)", "#<eof>"},
+		{"(yield 1)", "yield: must be called in the context of a generator"},
+	})
+}
+
+// the goroutines of the generators waiting in a yield are stopped once the
+// generators are collected, and the rest once the interpreter is closed
+func TestGeneratorGoroutines(t *testing.T) {
+	i := NewInterpreter()
+	before := runtime.NumGoroutine()
+
+	mustEval(t, i, "(define kept (make-generator (lambda () (yield 1) (yield 2))))")
+	mustEval(t, i, "(generator-next kept)")
+	for n := 0; n < 10; n++ {
+		mustEval(t, i, "(generator-next (make-generator (lambda () (yield 1) (yield 2))))")
+	}
+
+	// the collected generators are stopped when the next one is made
+	deadline := time.Now().Add(5 * time.Second)
+	for runtime.NumGoroutine() > before+1 && time.Now().Before(deadline) {
+		runtime.GC()
+		time.Sleep(10 * time.Millisecond)
+		mustEval(t, i, "(make-generator (lambda () 1))")
+	}
+
+	if got := runtime.NumGoroutine(); got != before+1 {
+		t.Errorf("%d goroutines are left running after the generators were collected, want 1", got-before)
+	}
+	if got := p.WriteString(mustEval(t, i, "(generator-next kept)")); got != "2" {
+		t.Errorf("the generator kept gives %s, want 2", got)
+	}
+
+	i.Close()
+	if got := runtime.NumGoroutine(); got != before {
+		t.Errorf("%d goroutines are left running after the interpreter was closed", got-before)
+	}
+}
@@ -0,0 +1,156 @@
+package interpreter
+
+import (
+	"strconv"
+
+	p "github.com/dimbata23/golang-scheme-interpreter/pkg/parser"
+)
+
+/// ------------------------------------------------------------------------ ///
+/// ------------------------ Hash procedure methods ------------------------ ///
+/// ------------------------------------------------------------------------ ///
+
+// (make-hash [association list])
+func procMakeHash(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen > 1 {
+		return &p.Void, newError(errArityMismatch, "make-hash", "0 or 1", strconv.Itoa(argsLen))
+	}
+
+	res := p.NewHashTable()
+	if argsLen == 0 {
+		return res, nil
+	}
+
+	items, isList := p.AsList(args.Lst[0])
+	if !isList {
+		return &p.Void, newError(errContractViolation, "make-hash", "(listof pair?)", errString(args.Lst[0]))
+	}
+
+	for _, item := range items {
+		pair, isPair := item.(*p.Pair)
+		if !isPair {
+			return &p.Void, newError(errContractViolation, "make-hash", "(listof pair?)", errString(args.Lst[0]))
+		}
+
+		res.Set(pair.Car, pair.Cdr)
+	}
+
+	return res, nil
+}
+
+// (hash? <expression>)
+func procIsHash(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen != 1 {
+		return &p.Void, newError(errArityMismatch, "hash?", "1", strconv.Itoa(argsLen))
+	}
+
+	if _, isHash := args.Lst[0].(*p.HashTable); isHash {
+		return &p.True, nil
+	}
+
+	return &p.False, nil
+}
+
+// (hash-set! <hash> <key> <value>)
+func procHashSet(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen != 3 {
+		return &p.Void, newError(errArityMismatch, "hash-set!", "3", strconv.Itoa(argsLen))
+	}
+
+	hash, err := toHash("hash-set!", args.Lst[0])
+	if err != nil {
+		return &p.Void, err
+	}
+
+	hash.Set(args.Lst[1], args.Lst[2])
+
+	return &p.Void, nil
+}
+
+// (hash-ref <hash> <key> [failure value])
+func procHashRef(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen < 2 || argsLen > 3 {
+		return &p.Void, newError(errArityMismatch, "hash-ref", "2 or 3", strconv.Itoa(argsLen))
+	}
+
+	hash, err := toHash("hash-ref", args.Lst[0])
+	if err != nil {
+		return &p.Void, err
+	}
+
+	if val, isFound := hash.Get(args.Lst[1]); isFound {
+		return val, nil
+	}
+
+	if argsLen == 3 {
+		return args.Lst[2], nil
+	}
+
+	return &p.Void, &p.Error{Val: "hash-ref: no value found for key\n  key: " + errString(args.Lst[1])}
+}
+
+// (hash-remove! <hash> <key>)
+func procHashRemove(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen != 2 {
+		return &p.Void, newError(errArityMismatch, "hash-remove!", "2", strconv.Itoa(argsLen))
+	}
+
+	hash, err := toHash("hash-remove!", args.Lst[0])
+	if err != nil {
+		return &p.Void, err
+	}
+
+	hash.Delete(args.Lst[1])
+
+	return &p.Void, nil
+}
+
+// (hash-count <hash>)
+func procHashCount(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen != 1 {
+		return &p.Void, newError(errArityMismatch, "hash-count", "1", strconv.Itoa(argsLen))
+	}
+
+	hash, err := toHash("hash-count", args.Lst[0])
+	if err != nil {
+		return &p.Void, err
+	}
+
+	return p.NewNumber(float64(hash.Len())), nil
+}
+
+// (hash->list <hash>)
+// the pairs are sorted by their keys like when the hash is printed
+func procHashToList(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen != 1 {
+		return &p.Void, newError(errArityMismatch, "hash->list", "1", strconv.Itoa(argsLen))
+	}
+
+	hash, err := toHash("hash->list", args.Lst[0])
+	if err != nil {
+		return &p.Void, err
+	}
+
+	return p.List(hash.Pairs()...), nil
+}
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------------- Helper functions --------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// returns the given expression as a hash table or an error
+func toHash(procName string, arg p.Expression) (hash *p.HashTable, err *p.Error) {
+	hash, isHash := arg.(*p.HashTable)
+	if !isHash {
+		return nil, newError(errContractViolation, procName, "hash?", errString(arg))
+	}
+
+	return hash, nil
+}
@@ -0,0 +1,143 @@
+package interpreter
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+
+	p "github.com/dimbata23/golang-scheme-interpreter/pkg/parser"
+)
+
+// extension of the files of the libraries
+const libraryExtension = ".scm"
+
+// first parts of the names of the standard libraries, which are built in
+var builtinLibraries = map[string]bool{
+	"scheme": true,
+	"srfi":   true,
+}
+
+func init() {
+	registerSpecialForm("import", (*environment).evalImport)
+}
+
+/// ------------------------------------------------------------------------ ///
+/// ------------------------- Special form methods ------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// (import <library> ...)
+// loads the file of every library into the global environment the first
+// time it is imported, `utils` names the file utils.scm and (lib utils)
+// the file lib/utils.scm, both relative to the directory of the program
+// being run, the standard libraries such as (scheme base) are built in
+func (env *environment) evalImport(lst *p.ExprList) (ex p.Expression, err *p.Error) {
+	lstLen := len(lst.Lst)
+	if lstLen < 2 {
+		return &p.Void, newError(errBadSyntax, "import", "at least 1 library", strconv.Itoa(lstLen-1))
+	}
+
+	for _, lib := range lst.Lst[1:] {
+		parts, err := libraryName(lib)
+		if err != nil {
+			return &p.Void, err
+		}
+
+		if builtinLibraries[parts[0]] {
+			continue
+		}
+
+		if err := env.interp.importLibrary(filepath.Join(parts...) + libraryExtension); err != nil {
+			return &p.Void, err
+		}
+	}
+
+	return &p.Void, nil
+}
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------------- Helper functions --------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// evaluates the file of the library in the global environment unless it
+// has been imported already, the first error stops it, its definitions
+// stay, the results of the expressions aren't printed
+func (i *Interpreter) importLibrary(path string) *p.Error {
+	full, absErr := filepath.Abs(i.resolvePath(path))
+	if absErr != nil {
+		return newError(errCouldntLoadFile, absErr.Error())
+	}
+
+	if isDone, isKnown := i.imported[full]; isKnown {
+		if !isDone {
+			return &p.Error{Val: "import: the library imports itself\n  library: " + path}
+		}
+		return nil
+	}
+
+	file, ioerr := os.Open(full)
+	if ioerr != nil {
+		return fileError("import", "cannot find the library", path, ioerr)
+	}
+	defer file.Close()
+
+	if i.imported == nil {
+		i.imported = map[string]bool{}
+	}
+	i.imported[full] = false
+
+	prev := i.source
+	i.source = path
+	defer func() { i.source = prev }()
+
+	par := i.newParserFromReader(file)
+	for {
+		expr, err := par.Next()
+		if expr == nil {
+			break // parser has finished
+		}
+
+		if err == nil {
+			i.coverForm(expr)
+			_, err = i.genv.eval(expr)
+		}
+
+		if err != nil {
+			delete(i.imported, full) // it can be imported again once it is fixed
+			if err.File == "" {
+				err.Pos, err.File = par.Pos(), path
+			}
+			return err
+		}
+	}
+
+	i.imported[full] = true
+	return nil
+}
+
+// returns the parts of the name of a library, `utils` or (lib utils),
+// the parts of the names of the standard libraries can be numbers
+func libraryName(expr p.Expression) (parts []string, err *p.Error) {
+	if v, isVar := expr.(*p.Variable); isVar {
+		return []string{v.Val}, nil
+	}
+
+	lst, isLst := expr.(*p.ExprList)
+	if !isLst || lst.Qlevel > 0 || lst.Tail != nil || len(lst.Lst) == 0 {
+		return nil, newError(errBadSyntax, "import", "a library name", p.CodeString(expr))
+	}
+
+	for _, item := range lst.Lst {
+		switch part := item.(type) {
+		case *p.Variable:
+			parts = append(parts, part.Val)
+
+		case *p.Number:
+			parts = append(parts, p.CodeString(part))
+
+		default:
+			return nil, newError(errBadSyntax, "import", "identifiers in a library name", p.CodeString(expr))
+		}
+	}
+
+	return parts, nil
+}
@@ -1,11 +1,17 @@
 package interpreter
 
 import (
+	"context"
 	"fmt"
-	"io/ioutil"
 	"math"
+	"math/big"
+	"os"
 	"strconv"
+	"strings"
+	"unicode/utf8"
 
+	"github.com/dimbata23/golang-scheme-interpreter/pkg/analyzer"
+	"github.com/dimbata23/golang-scheme-interpreter/pkg/lexer"
 	p "github.com/dimbata23/golang-scheme-interpreter/pkg/parser"
 )
 
@@ -14,16 +20,52 @@ import (
 /// ------------------------------------------------------------------------ ///
 
 // the interpreter struct
+// copies of an interpreter share the same state
 type Interpreter struct {
-	genv environment
+	*interpreterState
 }
 
-type Status int // status of the interpreter after interpreting
+// what happened while interpreting an input, for front-ends to act on
+// without reading the printed output
+type Status struct {
+	Code     StatusCode   // how interpreting finished
+	Value    p.Expression // value of the last expression which succeeded, nil if none did
+	Errors   []*p.Error   // errors of the expressions in the order they occured
+	ExitCode int          // code an (exit [code]) command asked to exit with
+}
+
+type StatusCode int // how interpreting an input finished
+
+// how a definition replacing a builtin or a special form is treated
+type RedefinitionMode int
 
 const (
-	StatusOk      Status = iota // interpreting finished successfully
-	StatusExitted               // interpreter was given an exit command
-	StatusError                 // an error occured while interpreting
+	RedefinitionAllow RedefinitionMode = iota // allowed silently
+	RedefinitionWarn                          // allowed printing a warning
+	RedefinitionError                         // disallowed with an error
+)
+
+// handler of a special form installed with DefineSpecialForm, given the
+// whole unevaluated form and a function evaluating expressions in the
+// environment the form is used in
+type SpecialForm func(form *p.ExprList, eval func(expr p.Expression) (p.Expression, *p.Error)) (ex p.Expression, err *p.Error)
+
+// handler resolving the value of an identifier which isn't bound
+// returns false if it doesn't know the identifier either
+type UnboundHandler func(name string) (ex p.Expression, isFound bool)
+
+// how errors of the interpreted expressions are treated
+type ErrorMode int
+
+const (
+	ErrorContinue ErrorMode = iota // printed, interpreting continues with the next expression
+	ErrorStop                      // returned, interpreting stops at the first error
+)
+
+const (
+	StatusOk      StatusCode = iota // interpreting finished successfully
+	StatusExitted                   // interpreter was given an exit command
+	StatusError                     // an error occured while interpreting
 )
 
 /// ------------------------------------------------------------------------ ///
@@ -32,20 +74,105 @@ const (
 
 // creates a new interpreter
 func NewInterpreter() *Interpreter {
-	res := Interpreter{}
+	res := &Interpreter{&interpreterState{ctx: context.Background(), integerBitLimit: defaultIntegerBitLimit, keywords: p.NewKeywordTable(), symbols: p.NewSymbolTable(), parserLimits: p.DefaultLimits}}
 	return res.addDefaultDefs()
 }
 
 // makes a new interpreter
 func MakeInterpreter() Interpreter {
-	res := Interpreter{}
-	return *res.addDefaultDefs()
+	return *NewInterpreter()
+}
+
+// turns strict mode on or off, in strict mode syntax accepted leniently
+// by default but not allowed by R7RS is reported as an error, e.g. lambda
+// parameters that aren't distinct identifiers, `else` clauses in `cond`
+// followed by more clauses or conditions of `if` and `cond` which are
+// unbound identifiers, even if the handler set with OnUnbound knows them
+func (i *Interpreter) SetStrictMode(strict bool) {
+	i.strict = strict
+}
+
+// sets the limits on the nesting depth and the length of the lists
+// of the input the interpreter reads, the parser's defaults unless set
+func (i *Interpreter) SetParserLimits(limits p.Limits) {
+	i.parserLimits = limits
+}
+
+// sets how definitions replacing a builtin or shadowing
+// a special form are treated, they are allowed by default
+func (i *Interpreter) SetRedefinitionMode(mode RedefinitionMode) {
+	i.redefinitions = mode
+}
+
+// installs a special form of the interpreter under the given name,
+// replacing the special form with that name if there is one, other
+// interpreters aren't affected
+func (i *Interpreter) DefineSpecialForm(name string, form SpecialForm) {
+	i.forms[name] = func(env *environment, lst *p.ExprList) (ex p.Expression, err *p.Error) {
+		return form(lst, env.eval)
+	}
+}
+
+// sets a handler consulted before an identifier is reported as unbound
+// a value it resolves is defined in the global environment so the handler
+// is asked about every name at most once, nil removes the handler
+func (i *Interpreter) OnUnbound(handler UnboundHandler) {
+	i.onUnbound = handler
+}
+
+// sets the context bounding the evaluation, once it is done the evaluation
+// stops with an error, by default the evaluation is never cancelled
+func (i *Interpreter) SetContext(ctx context.Context) {
+	i.ctx = ctx
+}
+
+// turns printing the warnings of the analyzer about every interpreted
+// expression on or off, they are printed before evaluating the expression
+func (i *Interpreter) SetWarnings(warnings bool) {
+	i.warnings = warnings
+}
+
+// sets the maximum number of bytes printed for the result or the error
+// of an evaluation, longer output is cut and ends with a truncation marker,
+// a limit of 0 (the default) means no limit
+func (i *Interpreter) SetOutputLimit(bytes int) {
+	i.outputLimit = bytes
+}
+
+// sets how numbers are printed in the results of evaluations and by
+// number->string, e.g. the digits reals are rounded to, by default they
+// are printed the way they are read
+func (i *Interpreter) SetNumberFormat(format p.NumberFormat) {
+	i.numberFormat = format
+}
+
+// sets the maximum size in bits of the exact integers computed by `expt`,
+// computations which would exceed it are reported as errors instead of
+// exhausting the memory, a limit of 0 means no limit
+func (i *Interpreter) SetIntegerBitLimit(bits int) {
+	i.integerBitLimit = bits
+}
+
+// binds the name to the value in the global environment
+// e.g. to give scheme code access to ports made from Go
+func (i *Interpreter) Define(name string, val p.Expression) {
+	i.symbols.Intern(name)
+	i.genv.vars[name] = val
 }
 
 // interprets the given string printing any results to the console
-// returns the status of the interpreter afterwards
+// returns what happened while interpreting it
 func (i *Interpreter) Interpret(input string) Status {
-	par := p.NewParser(input)
+	status, _ := i.InterpretWith(input, ErrorContinue)
+	return status
+}
+
+// interprets the given string printing any results to the console
+// treating errors according to the given mode, with ErrorStop the first
+// error is returned along with StatusError and includes where it occured
+// returns what happened while interpreting it
+func (i *Interpreter) InterpretWith(input string, mode ErrorMode) (status Status, err *p.Error) {
+	par := i.newParser(input)
 
 	for {
 		expr, err := par.Next()
@@ -53,35 +180,127 @@ func (i *Interpreter) Interpret(input string) Status {
 			break // parser has finished
 		}
 
-		if p.IsSpecialExit(expr) {
-			fmt.Println("Got (exit), bye!")
-			return StatusExitted
+		form := expr
+		if err == nil && i.warnings {
+			for _, diag := range analyzer.Analyze(expr) {
+				i.println(diag.String())
+			}
 		}
 
 		if err == nil {
+			i.coverForm(expr)
 			expr, err = i.genv.eval(expr)
+		} else {
+			form = nil
+		}
+
+		if code, isExit := i.exitCode(err); isExit {
+			i.println("Got (exit), bye!")
+			status.Code, status.ExitCode = StatusExitted, code
+			return status, nil
+		}
+
+		i.reportResult(par, expr, err)
+
+		if err != nil && mode == ErrorStop {
+			i.setLastError(err)
+			err = failedAt(err, form, par.Pos())
+			status.Code, status.Errors = StatusError, append(status.Errors, err)
+			return status, err
 		}
 
 		if err != nil {
-			fmt.Println(err.String())
+			i.setLastError(err)
+			i.printError(err)
+			status.Errors = append(status.Errors, err)
 		} else {
-			fmt.Println(expr.String(0))
+			i.printResult(expr)
+			status.Value = expr
 		}
 	}
 
-	return StatusOk
+	status.Code = StatusOk
+	return status, nil
 }
 
 /// ------------------------------------------------------------------------ ///
 /// --------------------------- Inner structure ---------------------------- ///
 /// ------------------------------------------------------------------------ ///
 
+// the state of an interpreter
+type interpreterState struct {
+	genv            environment                // the global environment
+	builtins        map[string]bool            // names of the default definitions
+	registry        map[string]*builtin        // declarations of the builtin procedures by their names
+	forms           map[string]specialForm     // special forms by the name they are invoked with
+	redefinitions   RedefinitionMode           // how redefining builtins is treated
+	strict          bool                       // whether lenient syntax is reported as errors
+	generators      []*coroutine               // generators producing a value, innermost last
+	callHooks       []callHook                 // hooks notified of every application
+	stats           runtimeStats               // counters reported by runtime-statistics
+	onUnbound       UnboundHandler             // resolves identifiers which aren't bound
+	ctx             context.Context            // evaluation stops when the context is done
+	outputLimit     int                        // maximum number of bytes printed per result, 0 for no limit
+	warnings        bool                       // whether the analyzer warnings are printed before evaluating
+	integerBitLimit int                        // maximum size in bits of exact integer results, 0 for no limit
+	inputPort       *p.Port                    // the current input port, nil for the standard input
+	outputPort      *p.Port                    // the current output port, nil for the standard output
+	stdin           *p.Port                    // the port of the interpreter reading the standard input, nil until used
+	stdout          *p.Port                    // the port of the interpreter writing to the standard output, nil until used
+	source          string                     // name of the file being loaded, empty for interpreted input
+	definitions     map[string]Location        // where the global bindings were defined
+	macroRenames    int                        // number of identifiers renamed by syntax-rules expansions so far
+	gensyms         int                        // number of symbols created by gensym so far
+	keywords        *p.KeywordTable            // interns the keywords the interpreter reads and creates
+	symbols         *p.SymbolTable             // names of the symbols the interpreter reads, gensym doesn't return them
+	parserLimits    p.Limits                   // limits on the input the interpreter reads
+	escaping        *escape                    // the continuation being applied while the evaluation unwinds to its call/cc
+	exiting         *exitRequest               // the exit being made while the evaluation unwinds to the top level
+	cleanups        []func() *p.Error          // called when the interpreter is closed, the latest last
+	suspended       map[*coroutine]bool        // generators waiting in a yield, stopped when the interpreter is closed
+	abandoned       abandonedCoroutines        // the generators collected while waiting in a yield, stopped by the interpreter
+	closed          bool                       // whether the interpreter has been closed
+	marks           *markFrame                 // marks of the enclosing with-continuation-mark forms, innermost first
+	directory       string                     // directory relative paths are resolved against, empty for the working directory
+	stepper         *Stepper                   // the stepper running the evaluation, if any
+	onResult        ResultHook                 // called with the result of every interpreted expression
+	readers         map[string]p.ReaderHandler // handlers of the custom reader syntax by their names
+	numberFormat    p.NumberFormat             // how numbers are printed in results and by number->string
+	coverage        *coverage                  // the executed forms and branches, nil unless the coverage is recorded
+	running         bool                       // whether a program is run, the files it loads don't print their results
+	imported        map[string]bool            // absolute paths of the imported libraries, false while one is being imported
+	openPorts       map[*p.Port]bool           // file ports not closed yet, closed with the interpreter
+}
+
+// hook called before a procedure or lambda is applied to its arguments
+// returning a function which is called after the application has finished
+type callHook func(proc p.Expression, args *p.ExprList) (done func())
+
 // environment containing definitions of expressions
 type environment struct {
-	vars   map[string]p.Expression // expression definitions
-	parent *environment            // a parent environment, if any
+	vars     map[string]p.Expression // expression definitions
+	parent   *environment            // a parent environment, if any
+	interp   *Interpreter            // the interpreter the environment belongs to
+	isolated bool                    // whether set! of the bindings it inherits binds them in it instead
 }
 
+// printed after output cut because of the output limit
+const truncationMarker = " ...[truncated]"
+
+// number of evaluation steps between checks whether the context is done
+const cancelCheckSteps = 256
+
+// default maximum size in bits of exact integer results
+const defaultIntegerBitLimit = 1 << 20
+
+// handler of a special form, given the whole unevaluated form
+type specialForm func(env *environment, lst *p.ExprList) (ex p.Expression, err *p.Error)
+
+// the builtin special forms by the name they are invoked with, every
+// interpreter starts with a copy of them, filled in init() since the
+// handlers themselves use the table through eval
+var specialForms = map[string]specialForm{}
+
 type errorType int
 
 const (
@@ -94,78 +313,137 @@ const (
 	errNotAProc
 	errArityMismatch
 	errContractViolation
+	errIndexOutOfRange
+	errRedefinition
+	errCancelled
 )
 
 /// ------------------------------------------------------------------------ ///
 /// -------------------- Private functions and methods --------------------- ///
 /// ------------------------------------------------------------------------ ///
 
-// creates an environment
-func makeEnvironment(parent *environment, params *p.ExprList, args *p.ExprList) environment {
+func init() {
+	registerSpecialForm("define", (*environment).evalDefine)
+	registerSpecialForm("set!", (*environment).evalSet)
+	registerSpecialForm("quote", (*environment).evalQuote)
+	registerSpecialForm("if", (*environment).evalIf)
+	registerSpecialForm("begin", (*environment).evalBegin)
+	registerSpecialForm("and", (*environment).evalAnd)
+	registerSpecialForm("or", (*environment).evalOr)
+	registerSpecialForm("load", (*environment).evalLoad)
+	registerSpecialForm("cond", (*environment).evalCond)
+	registerSpecialForm("lambda", (*environment).evalLambda)
+}
+
+// installs a builtin special form under the given name
+// replacing any special form previously registered with that name
+func registerSpecialForm(name string, form specialForm) {
+	specialForms[name] = form
+}
+
+// creates an environment binding the parameters to the arguments
+// a parameter that isn't an identifier is a bad syntax of the form
+func makeEnvironment(procName string, parent *environment, params *p.ExprList, args *p.ExprList) (env environment, err *p.Error) {
 	resEnv := environment{
 		parent: parent,
 		vars:   make(map[string]p.Expression, len(params.Lst)),
+		interp: parent.interp,
 	}
+	resEnv.interp.stats.environments++
 
 	for i, param := range params.Lst {
 		if vp, isVar := param.(*p.Variable); isVar {
 			resEnv.vars[vp.Val] = args.Lst[i]
 		} else {
-			fmt.Printf("DEBUG: non-variable param given %q\n", param.String(0))
+			return resEnv, newError(errBadSyntax, procName, "an identifier as a parameter", errString(param))
 		}
 	}
 
-	return resEnv
+	// the rest parameter is bound to the list of the remaining arguments
+	if rest, isVar := params.Tail.(*p.Variable); isVar {
+		resEnv.vars[rest.Val] = p.List(toExprs(args.Lst[len(params.Lst):])...)
+	} else if params.Tail != nil {
+		return resEnv, newError(errBadSyntax, procName, "an identifier as a parameter", errString(params.Tail))
+	}
+
+	return resEnv, nil
 }
 
 // evaluates the given expression
 // can return an error
+// the tail calls the special forms and the applications return are
+// evaluated in a loop, so they don't nest
 func (env *environment) eval(expr p.Expression) (ex p.Expression, err *p.Error) {
+	source := env.interp.source
+	for {
+		if tc, isTail := expr.(*tailCall); isTail {
+			env, expr = tc.env, tc.expr
+			env.interp.source = tc.file
+		}
+
+		ex, err = env.evalStep(expr)
+		if _, isTail := ex.(*tailCall); !isTail || err != nil {
+			break
+		}
+		expr = ex
+	}
+	env.interp.source = source
+
+	return ex, err
+}
+
+// evaluates the expression up to its tail call, if it has one
+func (env *environment) evalStep(expr p.Expression) (ex p.Expression, err *p.Error) {
+	env.interp.stats.steps++
+	if env.interp.stats.steps%cancelCheckSteps == 0 {
+		if ctxErr := env.interp.ctx.Err(); ctxErr != nil {
+			return &p.Void, newError(errCancelled, ctxErr.Error())
+		}
+	}
+
+	if env.interp.stepper != nil {
+		if err := env.interp.stepper.step(expr); err != nil {
+			return &p.Void, err
+		}
+	}
+
 	switch ex := expr.(type) {
 
 	case *p.Variable:
-		return env.find(ex.Val)
+		res, err := env.find(ex.Val)
+		env.locate(err, ex.Pos)
+		return res, err
+
+	case *p.Symbol, *p.Number, *p.Boolean:
+		return p.Datum(ex), nil
+
+	case *p.String, *p.Char, *p.Keyword, *p.StringBuilder, *p.Port, *p.EOFExpr, *p.VoidExpr:
+		return ex, nil
 
-	case *p.Symbol:
+	case *p.HashTable, *p.Record, *p.RecordType, *p.Vector, *p.Promise, *p.MultipleValues:
 		return ex, nil
 
-	case *p.Number:
+	// already evaluated values, e.g. spliced into code given to eval
+	case *p.Pair, *p.Procedure, *p.Lambda, *environment, *generator, *errorObject, *macro, *markSet:
 		return ex, nil
 
 	case *p.ExprList:
 		if ex.Qlevel > 0 {
-			return ex, nil
+			return p.Datum(ex), nil
 		}
 
-		if len(ex.Lst) == 0 {
-			return &p.Void, newError(errMissingProc)
-		}
+		res, err := env.evalForm(ex)
+		env.locate(err, ex.Pos)
+		return res, err
 
-		if len(ex.Lst) == 1 && p.IsNullSym(ex.Lst[0]) {
-			panic("shouldn't happen")
+	case *p.SpecialExpr:
+		if !p.IsSpecialExit(ex) {
+			return &p.Void, newError(errUnknown)
 		}
 
-		// special forms
-		if v, isVar := ex.Lst[0].(*p.Variable); isVar {
-			switch v.Val {
-			case "define":
-				return env.evalDefine(ex)
-			case "if":
-				return env.evalIf(ex)
-			case "load":
-				return env.evalLoad(ex)
-			case "cond":
-				return env.evalCond(ex)
-			case "lambda":
-				return env.evalLambda(ex)
-			}
-		}
-
-		// lambda/procedure
-		return env.evalProcLambda(ex)
-
-	case *p.Procedure:
-		panic("unreachable")
+		// the parser reads (exit [code]) as a command, it is a call of exit
+		code := p.NewNumber(float64(p.ExitCode(ex)))
+		return env.evalForm(&p.ExprList{Lst: []interface{ p.Expression }{&p.Variable{Val: "exit"}, code}})
 
 	default:
 		return &p.Void, newError(errUnknown)
@@ -173,50 +451,155 @@ func (env *environment) eval(expr p.Expression) (ex p.Expression, err *p.Error)
 	}
 }
 
+// evaluates the special form, the macro use or the application
+func (env *environment) evalForm(ex *p.ExprList) (res p.Expression, err *p.Error) {
+	if len(ex.Lst) == 0 {
+		return &p.Void, newError(errMissingProc)
+	}
+
+	if len(ex.Lst) == 1 && p.IsNullSym(ex.Lst[0]) {
+		panic("shouldn't happen")
+	}
+
+	if ex.Tail != nil {
+		return &p.Void, newError(errBadSyntax, "#%app", "a proper list of expressions", p.CodeString(ex))
+	}
+
+	// special forms
+	if v, isVar := ex.Lst[0].(*p.Variable); isVar {
+		if form, isForm := env.interp.forms[v.Val]; isForm {
+			return form(env, ex)
+		}
+	}
+
+	// macro uses
+	if m, isMacro := env.macroOf(ex); isMacro {
+		code, err := env.expandMacro(m, ex)
+		if err != nil {
+			return &p.Void, err
+		}
+		return env.tail(code), nil
+	}
+
+	// lambda/procedure
+	return env.evalProcLambda(ex)
+}
+
+// records where the error occured in the source unless it is already known,
+// the innermost expression with a position is where the error is reported
+func (env *environment) locate(err *p.Error, pos lexer.Position) {
+	if err != nil && err.Pos.Line == 0 && pos.Line != 0 {
+		err.Pos, err.File = pos, env.interp.source
+	}
+}
+
 // returns an expression defined by the given string
 // or an error if no such definition is found
 func (env *environment) find(val string) (ex p.Expression, err *p.Error) {
-	if val, ok := env.vars[val]; ok {
-		return val, nil
+	if ex, ok := env.vars[val]; ok {
+		if ex == uninitialized {
+			return &p.Void, &p.Error{Val: val + ": undefined;\n cannot use before initialization"}
+		}
+		return ex, nil
 	}
 
 	if env.parent != nil {
 		return env.parent.find(val)
 	}
 
+	if handler := env.interp.onUnbound; handler != nil {
+		if ex, isFound := handler(val); isFound {
+			env.vars[val] = ex
+			return ex, nil
+		}
+	}
+
 	return &p.Void, newError(errUnboundIdentifier, val)
 }
 
+// checks whether binding the identifier in the environment is allowed by the
+// redefinition mode of the interpreter, warnings are printed and not returned
+func (env *environment) checkRedefinition(procName string, ident string) (err *p.Error) {
+	mode := env.interp.redefinitions
+	if mode == RedefinitionAllow {
+		return nil
+	}
+
+	what, action := "", ""
+	if _, isForm := env.interp.forms[ident]; isForm {
+		what, action = "special form", "shadowing"
+	} else if env.parent == nil && env.interp.builtins[ident] {
+		what, action = "builtin", "redefining"
+	} else {
+		return nil
+	}
+
+	if mode == RedefinitionWarn {
+		env.interp.println(fmt.Sprintf("warning: %s: %s the %s `%s`", procName, action, what, ident))
+		return nil
+	}
+
+	return newError(errRedefinition, procName, "an identifier that isn't a "+what, ident)
+}
+
+// checks every parameter name of a lambda with checkRedefinition
+// in strict mode the parameters also have to be distinct identifiers
+func (env *environment) checkParams(procName string, params *p.ExprList) (err *p.Error) {
+	seen := make(map[string]bool, len(params.Lst)+1)
+
+	all := params.Lst
+	if params.Tail != nil {
+		all = append(all[:len(all):len(all)], params.Tail)
+	}
+
+	for _, param := range all {
+		v, isVar := param.(*p.Variable)
+		if !isVar {
+			if env.interp.strict {
+				return newError(errBadSyntax, procName, "an identifier as a parameter", errString(param))
+			}
+			continue
+		}
+
+		if seen[v.Val] && env.interp.strict {
+			return newError(errBadSyntax, procName, "distinct parameter names", v.Val)
+		}
+		seen[v.Val] = true
+
+		if _, isForm := env.interp.forms[v.Val]; isForm {
+			if err := env.checkRedefinition(procName, v.Val); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
 // add the default scheme definitions
 func (i *Interpreter) addDefaultDefs() *Interpreter {
 	i.genv.parent = nil
+	i.genv.interp = i
 	i.genv.vars = map[string]p.Expression{
-		"#f":        &p.FalseSym,
-		"#t":        &p.TrueSym,
-		"+":         &p.Procedure{Fn: procAdd},
-		"*":         &p.Procedure{Fn: procMultiply},
-		"-":         &p.Procedure{Fn: procSubtract},
-		"/":         &p.Procedure{Fn: procDivide},
-		"=":         &p.Procedure{Fn: procEquals},
-		"<":         &p.Procedure{Fn: procLess},
-		"<=":        &p.Procedure{Fn: procLessEq},
-		">":         &p.Procedure{Fn: procGreater},
-		">=":        &p.Procedure{Fn: procGreaterEq},
-		"number?":   &p.Procedure{Fn: procIsNumber},
-		"null?":     &p.Procedure{Fn: procIsNull},
-		"and":       &p.Procedure{Fn: procAnd},
-		"or":        &p.Procedure{Fn: procOr},
-		"remainder": &p.Procedure{Fn: procRemainder},
-		"quotient":  &p.Procedure{Fn: procQuotient},
-		"expt":      &p.Procedure{Fn: procExpt},
-		"list":      &p.Procedure{Fn: procList},
-		"cons":      &p.Procedure{Fn: procCons},
-		"car":       &p.Procedure{Fn: procCar},
-		"cdr":       &p.Procedure{Fn: procCdr},
-		"pair?":     &p.Procedure{Fn: procIsPair},
-		"list?":     &p.Procedure{Fn: procIsList},
-		"max":       &p.Procedure{Fn: procMax},
-		"min":       &p.Procedure{Fn: procMin},
+		lastErrorVar: &p.False,
+	}
+
+	registry := i.builtinRegistry()
+	i.registry = make(map[string]*builtin, len(registry))
+	for j := range registry {
+		b := &registry[j]
+		i.registry[b.name] = b
+		i.genv.vars[b.name] = &p.Procedure{Fn: b.fn, Name: b.name}
+	}
+
+	i.forms = make(map[string]specialForm, len(specialForms))
+	for name, form := range specialForms {
+		i.forms[name] = form
+	}
+
+	i.builtins = make(map[string]bool, len(i.genv.vars))
+	for name := range i.genv.vars {
+		i.builtins[name] = true
 	}
 
 	return i
@@ -277,6 +660,24 @@ func newError(typ errorType, args ...string) (err *p.Error) {
 			err.Val = fmt.Sprintf("%s: %s", args[0], err.Val)
 		}
 
+	case errIndexOutOfRange:
+		err.Val = "index is out of range"
+		if len >= 1 {
+			err.Val = fmt.Sprintf("%s: %s", args[0], err.Val)
+		}
+
+	case errRedefinition:
+		err.Val = "redefinition disallowed"
+		if len >= 1 {
+			err.Val = fmt.Sprintf("%s: %s", args[0], err.Val)
+		}
+
+	case errCancelled:
+		err.Val = "evaluation cancelled"
+		if len >= 1 {
+			err.Val = fmt.Sprintf("%s;\n %s", err.Val, args[0])
+		}
+
 	default:
 		err.Val = "wrong error type"
 	}
@@ -292,6 +693,13 @@ func newError(typ errorType, args ...string) (err *p.Error) {
 	return err
 }
 
+// returns the printed form of a value given in an error message, values
+// deeper or longer than the error print options are shortened with `...`
+func errString(expr p.Expression) string {
+	str, _ := p.StringWith(expr, p.ErrorPrintOptions)
+	return str
+}
+
 /// ------------------------------------------------------------------------ ///
 /// ------------------------- Special form methods ------------------------- ///
 /// ------------------------------------------------------------------------ ///
@@ -303,59 +711,124 @@ func (env *environment) evalIf(lst *p.ExprList) (ex p.Expression, err *p.Error)
 		return &p.Void, newError(errBadSyntax, "if", "2 or 3 arguments", strconv.Itoa(len-1))
 	}
 
+	if err := env.checkCondition("if", lst.Lst[1]); err != nil {
+		return &p.Void, err
+	}
+
 	cond, condErr := env.eval(lst.Lst[1])
 	if condErr != nil {
 		return &p.Void, condErr
 	}
 
-	if p.IsFalseSym(cond) {
+	if p.IsFalse(cond) {
 		// false case
 		if len == 4 {
-			return env.eval(lst.Lst[3])
+			env.interp.coverBranch(lst, 2)
+			return env.tail(lst.Lst[3]), nil
 		}
 
 		return &p.Void, nil
 	}
 
 	// true case
-	return env.eval(lst.Lst[2])
+	env.interp.coverBranch(lst, 1)
+	return env.tail(lst.Lst[2]), nil
+}
+
+// (and [expressions...])
+// stops evaluating at the first false expression
+func (env *environment) evalAnd(lst *p.ExprList) (ex p.Expression, err *p.Error) {
+	ex = &p.True
+
+	for _, expr := range lst.Lst[1:] {
+		ex, err = env.eval(expr)
+		if err != nil {
+			return &p.Void, err
+		}
+
+		if p.IsFalse(ex) {
+			return &p.False, nil
+		}
+	}
+
+	return ex, nil
+}
+
+// (or [expressions...])
+// stops evaluating at the first true expression
+func (env *environment) evalOr(lst *p.ExprList) (ex p.Expression, err *p.Error) {
+	for _, expr := range lst.Lst[1:] {
+		ex, err = env.eval(expr)
+		if err != nil {
+			return &p.Void, err
+		}
+
+		if !p.IsFalse(ex) {
+			return ex, nil
+		}
+	}
+
+	return &p.False, nil
+}
+
+// (begin [expressions...])
+// evaluates the expressions in order in the current environment, so the
+// definitions among them are made in it, the last one is in tail position
+func (env *environment) evalBegin(lst *p.ExprList) (ex p.Expression, err *p.Error) {
+	exprs := lst.Lst[1:]
+	if len(exprs) == 0 {
+		return &p.Void, nil
+	}
+
+	for _, expr := range exprs[:len(exprs)-1] {
+		if _, err = env.eval(expr); err != nil {
+			return &p.Void, err
+		}
+	}
+
+	return env.tail(exprs[len(exprs)-1]), nil
 }
 
 // (load <filename>)
-// interprets a scheme file
+// or
+// (load <string expression>)
+// interprets a scheme file, relative paths are resolved against the current directory
 func (env *environment) evalLoad(lst *p.ExprList) (ex p.Expression, err *p.Error) {
 	len := len(lst.Lst)
 	if len != 2 {
 		return &p.Void, newError(errBadSyntax, "load", "1 argument", strconv.Itoa(len-1))
 	}
 
-	arg := lst.Lst[1]
-	if fileName, isVar := arg.(*p.Variable); isVar {
-		input, ioerr := ioutil.ReadFile(fileName.Val)
-		if ioerr != nil {
-			return &p.Void, newError(errCouldntLoadFile, ioerr.Error())
+	// the file name is written as it is or given by an expression
+	var fileName string
+	if name, isVar := lst.Lst[1].(*p.Variable); isVar {
+		fileName = name.Val
+	} else {
+		arg, err := env.eval(lst.Lst[1])
+		if err != nil {
+			return &p.Void, err
 		}
 
-		par := p.NewParser(string(input))
-		for {
-			ex, err := par.Next()
-			if ex == nil {
-				break // parser has finished
-			}
-
-			if err == nil {
-				ex, err = env.eval(ex)
-			}
-
-			if err != nil {
-				fmt.Println(err.String())
-			} else {
-				fmt.Println(ex.String(0))
-			}
+		name, isStr := p.AsString(arg)
+		if !isStr {
+			return &p.Void, newError(errContractViolation, "load", "string?", errString(arg))
 		}
+		fileName = name
 	}
 
-	return &p.Void, nil
+	// the file is read as it is parsed, one expression at a time
+	file, ioerr := os.Open(env.interp.resolvePath(fileName))
+	if ioerr != nil {
+		return &p.Void, newError(errCouldntLoadFile, ioerr.Error())
+	}
+	defer file.Close()
+
+	prev := env.interp.source
+	env.interp.source = fileName
+	err = env.load(env.interp.newParserFromReader(file))
+	env.interp.source = prev
+
+	return &p.Void, err
 }
 
 // (<proc/lambda> [args...])
@@ -365,11 +838,8 @@ func (env *environment) evalProcLambda(lst *p.ExprList) (ex p.Expression, err *p
 		return &p.Void, prErr
 	}
 
-	proc, isProc := pr.(*p.Procedure)
-	lambda, isLambda := pr.(*p.Lambda)
-
-	if !isProc && !isLambda {
-		return &p.Void, newError(errNotAProc, pr.String(0))
+	if !isCallable(pr) {
+		return &p.Void, newError(errNotAProc, errString(pr))
 	}
 
 	argsLen := len(lst.Lst[1:])
@@ -382,22 +852,34 @@ func (env *environment) evalProcLambda(lst *p.ExprList) (ex p.Expression, err *p
 		}
 	}
 
+	return env.applyTail(pr, &args)
+}
+
+// applies the given procedure or lambda to the already evaluated arguments
+func (env *environment) apply(pr p.Expression, args *p.ExprList) (ex p.Expression, err *p.Error) {
+	for _, hook := range env.interp.callHooks {
+		defer hook(pr, args)()
+	}
+
+	proc, isProc := pr.(*p.Procedure)
+	lambda, isLambda := pr.(*p.Lambda)
+
 	if isProc {
-		ex, err = proc.Fn(&args)
+		ex, err = proc.Fn(args)
 	} else if isLambda {
 		paramLen := len(lambda.Params.Lst)
 		argsLen := len(args.Lst)
-		if paramLen != argsLen {
-			return &p.Void, newError(errArityMismatch, lambda.Name, strconv.Itoa(paramLen), strconv.Itoa(argsLen))
+		if paramLen != argsLen && (lambda.Params.Tail == nil || argsLen < paramLen) {
+			return &p.Void, lambdaArityError(lambda, args)
 		}
 
-		lambdaEnv := makeEnvironment(env, lambda.Params, &args)
-		for _, expr := range lambda.Body.Lst {
-			ex, err = lambdaEnv.eval(expr)
-			if err != nil {
-				break
-			}
-		}
+		ex, err = resolveTail(env.callLambda(lambda, args))
+	} else {
+		return &p.Void, newError(errNotAProc, errString(pr))
+	}
+
+	if err != nil {
+		err.Stack = append(err.Stack, frameName(pr))
 	}
 
 	return ex, err
@@ -424,11 +906,14 @@ func (env *environment) evalDefine(lst *p.ExprList) (ex p.Expression, err *p.Err
 	case *p.ExprList: // Lambda definition
 		if lambdaName, isVar := firstArg.Lst[0].(*p.Variable); isVar {
 			ident = lambdaName.Val
-			params := p.ExprList{Lst: firstArg.Lst[1:]}
+			params := p.ExprList{Lst: firstArg.Lst[1:], Tail: firstArg.Tail}
 			body := p.ExprList{Lst: lst.Lst[2:]}
-			ex = &p.Lambda{Name: ident, Params: &params, Body: &body}
+			if err := env.checkParams("define", &params); err != nil {
+				return &p.Void, err
+			}
+			ex = &p.Lambda{Name: ident, Params: &params, Body: &body, Pos: lst.Pos, File: env.interp.source, Env: env}
 		} else {
-			return &p.Void, newError(errBadSyntax, "define", "identifier", firstArg.Lst[0].String(0))
+			return &p.Void, newError(errBadSyntax, "define", "identifier", errString(firstArg.Lst[0]))
 		}
 
 	case *p.Variable: // Variable definition
@@ -439,59 +924,207 @@ func (env *environment) evalDefine(lst *p.ExprList) (ex p.Expression, err *p.Err
 		}
 
 	default:
-		return &p.Void, newError(errBadSyntax, "define", "identifier or list", lst.Lst[1].String(0))
+		return &p.Void, newError(errBadSyntax, "define", "identifier or list", errString(lst.Lst[1]))
+	}
+
+	if err := env.checkRedefinition("define", ident); err != nil {
+		return &p.Void, err
 	}
 
 	env.vars[ident] = ex
+	if env.parent == nil && lst.Pos.Line != 0 {
+		env.interp.defined(ident, lst.Pos)
+	}
 
-	return ex, nil
+	return &p.Void, nil
+}
+
+// (quote <datum>)
+func (env *environment) evalQuote(lst *p.ExprList) (ex p.Expression, err *p.Error) {
+	len := len(lst.Lst)
+	if len != 2 {
+		return &p.Void, newError(errBadSyntax, "quote", "exactly 1 argument", strconv.Itoa(len-1))
+	}
+
+	return p.Quote(lst.Lst[1]), nil
+}
+
+// (set! <identifier> <expression>)
+// changes the nearest binding of the identifier
+func (env *environment) evalSet(lst *p.ExprList) (ex p.Expression, err *p.Error) {
+	len := len(lst.Lst)
+	if len != 3 {
+		return &p.Void, newError(errBadSyntax, "set!", "exactly 2 arguments", strconv.Itoa(len-1))
+	}
+
+	ident, isVar := lst.Lst[1].(*p.Variable)
+	if !isVar {
+		return &p.Void, newError(errBadSyntax, "set!", "identifier", p.CodeString(lst.Lst[1]))
+	}
+
+	owner := env.owner(ident.Val)
+	if owner == nil {
+		return &p.Void, &p.Error{Val: "set!: assignment disallowed;\n cannot set variable before its definition\n  variable: " + ident.Val}
+	}
+	owner = env.assignee(owner)
+
+	if err := owner.checkRedefinition("set!", ident.Val); err != nil {
+		return &p.Void, err
+	}
+
+	ex, err = env.eval(lst.Lst[2])
+	if err != nil {
+		return &p.Void, err
+	}
+
+	owner.vars[ident.Val] = ex
+
+	return &p.Void, nil
+}
+
+// returns the nearest environment binding the identifier, nil if it's unbound
+func (env *environment) owner(ident string) *environment {
+	for curr := env; curr != nil; curr = curr.parent {
+		if _, isBound := curr.vars[ident]; isBound {
+			return curr
+		}
+	}
+
+	return nil
+}
+
+// returns the environment set! binds an identifier of the owner in,
+// the nearest isolated environment between this one and the owner if any,
+// so the bindings outside of an isolated environment are shadowed in it
+func (env *environment) assignee(owner *environment) *environment {
+	for curr := env; curr != owner; curr = curr.parent {
+		if curr.isolated {
+			return curr
+		}
+	}
+
+	return owner
+}
+
+// in strict mode reports a condition of the form which is an unbound
+// identifier, without asking the handler of unbound identifiers for a value
+// the condition would be coerced to
+func (env *environment) checkCondition(procName string, test p.Expression) (err *p.Error) {
+	v, isVar := test.(*p.Variable)
+	if !env.interp.strict || !isVar || env.owner(v.Val) != nil {
+		return nil
+	}
+
+	return newError(errBadSyntax, procName, "a bound identifier as the condition", v.Val)
+}
+
+// interprets the scheme source read by the parser in the environment
+// printing the result or the error of every expression, an exit stops
+// it and its error is returned to be propagated
+func (env *environment) load(par *p.Parser) *p.Error {
+	for {
+		ex, err := par.Next()
+		if ex == nil {
+			return nil // parser has finished
+		}
+
+		if err == nil {
+			env.interp.coverForm(ex)
+			ex, err = env.eval(ex)
+		}
+
+		if env.interp.exiting != nil && env.interp.exiting.err == err {
+			return err // the program exits
+		}
+
+		if err != nil {
+			env.interp.setLastError(err)
+			env.interp.printError(err)
+		} else if !env.interp.running {
+			env.interp.printResult(ex)
+		}
+	}
 }
 
 // (cond (<clause condition> <clause result>) ... [(else <clause result>)])
+// a clause can also be (<clause condition>), returning the value of the
+// condition, or (<clause condition> => <receiver>), calling the receiver
+// with the value of the condition
 func (env *environment) evalCond(lst *p.ExprList) (ex p.Expression, err *p.Error) {
-	for _, ex := range lst.Lst[1:len(lst.Lst)] {
-		clause, isPair := isPair(ex)
+	if env.interp.strict {
+		if len(lst.Lst) < 2 {
+			return &p.Void, newError(errBadSyntax, "cond", "at least 1 clause", "0")
+		}
+
+		for _, ex := range lst.Lst[1 : len(lst.Lst)-1] {
+			if clause, isPair := asClause(ex); isPair {
+				if varTest, isVar := clause.Lst[0].(*p.Variable); isVar && varTest.Val == "else" {
+					return &p.Void, newError(errBadSyntax, "cond", "`else` as the last clause", errString(ex))
+				}
+			}
+		}
+	}
+
+	for j, ex := range lst.Lst[1:len(lst.Lst)] {
+		clause, isPair := asClause(ex)
 		if !isPair {
-			return &p.Void, newError(errBadSyntax, "cond", "pair? as a test clause", ex.String(0))
+			return &p.Void, newError(errBadSyntax, "cond", "pair? as a test clause", errString(ex))
 		}
 
 		testClause := clause.Lst[0]
 		resClauses := clause.Lst[1:len(clause.Lst)]
-		isClauseTrue := false
 
-		if varTest, isVar := testClause.(*p.Variable); isVar {
-			if varTest.Val == "else" {
-				isClauseTrue = true
+		var testRes p.Expression = &p.Void
+		if varTest, isVar := testClause.(*p.Variable); !isVar || varTest.Val != "else" {
+			if err := env.checkCondition("cond", testClause); err != nil {
+				return &p.Void, err
 			}
-		}
 
-		if !isClauseTrue {
-			clRes, err := env.eval(testClause)
+			testRes, err = env.eval(testClause)
 			if err != nil {
 				return &p.Void, err
 			}
 
-			if !p.IsFalseSym(clRes) {
-				isClauseTrue = true
+			if p.IsFalse(testRes) {
+				continue
 			}
 		}
 
-		if isClauseTrue {
-			var res p.Expression = &p.Void
-			for _, ex := range resClauses {
-				res, err = env.eval(ex)
-				if err != nil {
-					return &p.Void, err
-				}
+		env.interp.coverBranch(lst, j+1)
+		if len(resClauses) == 0 {
+			return testRes, nil
+		}
+
+		if arrow, isVar := resClauses[0].(*p.Variable); isVar && arrow.Val == "=>" {
+			if len(resClauses) != 2 {
+				return &p.Void, newError(errBadSyntax, "cond", "(<test> => <receiver>) as a clause", errString(ex))
+			}
+
+			receiver, err := env.eval(resClauses[1])
+			if err != nil {
+				return &p.Void, err
 			}
-			return res, nil
+
+			return env.applyTail(receiver, &p.ExprList{Lst: []interface{ p.Expression }{testRes}})
 		}
+
+		for _, ex := range resClauses[:len(resClauses)-1] {
+			if _, err = env.eval(ex); err != nil {
+				return &p.Void, err
+			}
+		}
+		return env.tail(resClauses[len(resClauses)-1]), nil
 	}
 
 	return &p.Void, nil
 }
 
 // (lambda (<parameters...>) <body expressions>)
+// or
+// (lambda (<parameters...> . <rest parameter>) <body expressions>)
+// or
+// (lambda <rest parameter> <body expressions>)
+// the rest parameter is bound to the list of the arguments after the others
 func (env *environment) evalLambda(lst *p.ExprList) (ex p.Expression, err *p.Error) {
 	lstLen := len(lst.Lst)
 	if lstLen < 3 {
@@ -499,11 +1132,19 @@ func (env *environment) evalLambda(lst *p.ExprList) (ex p.Expression, err *p.Err
 	}
 
 	params, isLst := lst.Lst[1].(*p.ExprList)
+	if rest, isVar := lst.Lst[1].(*p.Variable); isVar {
+		params, isLst = &p.ExprList{Lst: []interface{ p.Expression }{}, Tail: rest}, true
+	}
+
 	if !isLst {
-		return &p.Void, newError(errBadSyntax, "lambda", "a list of parameters", lst.Lst[0].String(0))
+		return &p.Void, newError(errBadSyntax, "lambda", "a list of parameters", errString(lst.Lst[0]))
+	}
+
+	if err := env.checkParams("lambda", params); err != nil {
+		return &p.Void, err
 	}
 
-	res := &p.Lambda{Params: params, Body: &p.ExprList{}}
+	res := &p.Lambda{Params: params, Body: &p.ExprList{}, Pos: lst.Pos, File: env.interp.source, Env: env}
 	res.Body.Lst = lst.Lst[2:lstLen]
 
 	return res, nil
@@ -566,10 +1207,10 @@ func procIsNumber(args *p.ExprList) (ex p.Expression, err *p.Error) {
 	}
 
 	if _, isNum := args.Lst[0].(*p.Number); isNum {
-		return &p.TrueSym, nil
+		return &p.True, nil
 	}
 
-	return &p.FalseSym, nil
+	return &p.False, nil
 }
 
 // (null? <expression>)
@@ -580,156 +1221,153 @@ func procIsNull(args *p.ExprList) (ex p.Expression, err *p.Error) {
 	}
 
 	if p.IsNullSym(args.Lst[0]) {
-		return &p.TrueSym, nil
+		return &p.True, nil
 	}
 
-	return &p.FalseSym, nil
+	return &p.False, nil
 }
 
-// (and [args...])
-func procAnd(args *p.ExprList) (ex p.Expression, err *p.Error) {
-	res := p.Expression(&p.TrueSym)
-
-	for _, ex := range args.Lst {
-		if p.IsFalseSym(ex) {
-			return &p.FalseSym, nil
-		}
-
-		res = ex
+// (boolean? <expression>)
+func procIsBoolean(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	len := len(args.Lst)
+	if len != 1 {
+		return &p.Void, newError(errArityMismatch, "boolean?", "1", strconv.Itoa(len))
 	}
 
-	return res, nil
-}
-
-// (or [args...])
-func procOr(args *p.ExprList) (ex p.Expression, err *p.Error) {
-	for _, ex := range args.Lst {
-		if !p.IsFalseSym(ex) {
-			return ex, nil
-		}
+	if _, isBool := p.AsBoolean(args.Lst[0]); isBool {
+		return &p.True, nil
 	}
 
-	return &p.FalseSym, nil
+	return &p.False, nil
 }
 
 // (remainder <dividend> <divisor>)
+// the remainder has the sign of the dividend
 func procRemainder(args *p.ExprList) (ex p.Expression, err *p.Error) {
-	len := len(args.Lst)
-	if len != 2 {
-		return &p.Void, newError(errArityMismatch, "remainder", "2", strconv.Itoa(len))
-	}
-
-	num, isNum := args.Lst[0].(*p.Number)
-	if !isNum {
-		return &p.Void, newError(errContractViolation, "remainder", "number?", args.Lst[0].String(0))
-	}
-
-	div, isNumDiv := args.Lst[1].(*p.Number)
-	if !isNumDiv {
-		return &p.Void, newError(errContractViolation, "remainder", "number?", args.Lst[1].String(0))
-	}
-
-	return &p.Number{Val: float64(int64(num.Val) % int64(div.Val))}, nil
+	return procIntegerDivision(args, "remainder", true)
 }
 
 // (quotient <dividend> <divisor>)
+// the quotient is truncated towards zero
 func procQuotient(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	return procIntegerDivision(args, "quotient", false)
+}
+
+// (expt <base> <exponent>)
+// integer powers of exact numbers are computed exactly
+func (i *Interpreter) procExpt(args *p.ExprList) (ex p.Expression, err *p.Error) {
 	len := len(args.Lst)
 	if len != 2 {
-		return &p.Void, newError(errArityMismatch, "quotient", "2", strconv.Itoa(len))
+		return &p.Void, newError(errArityMismatch, "expt", "2", strconv.Itoa(len))
 	}
 
 	num, isNum := args.Lst[0].(*p.Number)
 	if !isNum {
-		return &p.Void, newError(errContractViolation, "quotient", "number?", args.Lst[0].String(0))
+		return &p.Void, newError(errContractViolation, "expt", "number?", errString(args.Lst[0]))
 	}
 
-	div, isNumDiv := args.Lst[1].(*p.Number)
-	if !isNumDiv {
-		return &p.Void, newError(errContractViolation, "quotient", "number?", args.Lst[1].String(0))
+	exp, isExpDiv := args.Lst[1].(*p.Number)
+	if !isExpDiv {
+		return &p.Void, newError(errContractViolation, "expt", "number?", errString(args.Lst[1]))
 	}
 
-	return &p.Number{Val: float64(int64(num.Val) / int64(div.Val))}, nil
-}
+	power, isPowerInt := p.AsInteger(exp)
+	if kindOf(num) == kindReal || !isPowerInt {
+		return p.NewReal(math.Pow(num.Val, exp.Val)), nil
+	}
 
-// (expt <base> <exponent>)
-func procExpt(args *p.ExprList) (ex p.Expression, err *p.Error) {
-	len := len(args.Lst)
-	if len != 2 {
-		return &p.Void, newError(errArityMismatch, "expt", "2", strconv.Itoa(len))
+	base := toBigRat(num)
+	if power.Sign() < 0 {
+		if base.Sign() == 0 {
+			return &p.Void, &p.Error{Val: "expt: division by zero"}
+		}
+		base.Inv(base)
+		power.Neg(power)
 	}
 
-	num, isNum := args.Lst[0].(*p.Number)
-	if !isNum {
-		return &p.Void, newError(errContractViolation, "expt", "number?", args.Lst[0].String(0))
+	numer, denom := new(big.Int).Set(base.Num()), new(big.Int).Set(base.Denom())
+	larger := numer
+	if denom.CmpAbs(numer) > 0 {
+		larger = denom
 	}
 
-	exp, isExpDiv := args.Lst[1].(*p.Number)
-	if !isExpDiv {
-		return &p.Void, newError(errContractViolation, "expt", "number?", args.Lst[1].String(0))
+	if larger.CmpAbs(big.NewInt(1)) > 0 && i.integerBitLimit > 0 {
+		bits := new(big.Int).Mul(big.NewInt(int64(larger.BitLen()-1)), power)
+		if bits.Cmp(big.NewInt(int64(i.integerBitLimit))) > 0 {
+			return &p.Void, &p.Error{Val: "expt: result exceeds the exact integer size limit\n  limit: " + strconv.Itoa(i.integerBitLimit) + " bits"}
+		}
 	}
 
-	return &p.Number{Val: math.Pow(num.Val, exp.Val)}, nil
+	numer.Exp(numer, power, nil)
+	denom.Exp(denom, power, nil)
+	return p.NewRational(new(big.Rat).SetFrac(numer, denom)), nil
 }
 
 // (list [args...])
-func procList(args *p.ExprList) (ex p.Expression, err *p.Error) {
+func (i *Interpreter) procList(args *p.ExprList) (ex p.Expression, err *p.Error) {
 	if len(args.Lst) == 0 {
 		return &p.NullSym, nil
 	}
 
-	args.Lst = append(args.Lst, &p.NullSym)
+	i.stats.conses += len(args.Lst)
 
-	return args, nil
+	return p.List(toExprs(args.Lst)...), nil
 }
 
 // (cons <first> <second>)
-func procCons(args *p.ExprList) (ex p.Expression, err *p.Error) {
+func (i *Interpreter) procCons(args *p.ExprList) (ex p.Expression, err *p.Error) {
 	len := len(args.Lst)
 	if len != 2 {
 		return &p.Void, newError(errArityMismatch, "cons", "2", strconv.Itoa(len))
 	}
 
-	resLst := args.Lst[0:2]
-	if secArg, isLst := args.Lst[1].(*p.ExprList); isLst && secArg.Qlevel <= 1 {
-		resLst = append(args.Lst[0:1], secArg.Lst...)
-	}
+	i.stats.conses++
 
-	return &p.ExprList{Lst: resLst, Qlevel: 1}, nil
+	return p.Cons(args.Lst[0], args.Lst[1]), nil
 }
 
 // (car <pair>)
 func procCar(args *p.ExprList) (ex p.Expression, err *p.Error) {
-	len := len(args.Lst)
-	if len != 1 {
-		return &p.Void, newError(errArityMismatch, "car", "1", strconv.Itoa(len))
-	}
-
-	arg := args.Lst[0]
-	if lstArg, isLst := arg.(*p.ExprList); isLst {
-		return lstArg.Lst[0], nil
+	pair, err := toPair("car", args, 1)
+	if err != nil {
+		return &p.Void, err
 	}
 
-	return &p.Void, newError(errContractViolation, "car", "pair?", arg.String(0))
+	return pair.Car, nil
 }
 
 // (cdr <pair>)
 func procCdr(args *p.ExprList) (ex p.Expression, err *p.Error) {
-	argsLen := len(args.Lst)
-	if argsLen != 1 {
-		return &p.Void, newError(errArityMismatch, "cdr", "1", strconv.Itoa(argsLen))
+	pair, err := toPair("cdr", args, 1)
+	if err != nil {
+		return &p.Void, err
 	}
 
-	arg := args.Lst[0]
-	if pairArg, isPair := isPair(arg); isPair {
-		if len(pairArg.Lst) == 2 {
-			return pairArg.Lst[1], nil
-		}
+	return pair.Cdr, nil
+}
+
+// (set-car! <pair> <value>)
+func procSetCar(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	pair, err := toPair("set-car!", args, 2)
+	if err != nil {
+		return &p.Void, err
+	}
+
+	pair.Car = args.Lst[1]
+
+	return &p.Void, nil
+}
 
-		return &p.ExprList{Lst: pairArg.Lst[1:], Qlevel: pairArg.Qlevel}, nil
+// (set-cdr! <pair> <value>)
+func procSetCdr(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	pair, err := toPair("set-cdr!", args, 2)
+	if err != nil {
+		return &p.Void, err
 	}
 
-	return &p.Void, newError(errContractViolation, "cdr", "pair?", arg.String(0))
+	pair.Cdr = args.Lst[1]
+
+	return &p.Void, nil
 }
 
 // (list? <expression>)
@@ -739,19 +1377,11 @@ func procIsList(args *p.ExprList) (ex p.Expression, err *p.Error) {
 		return &p.Void, newError(errArityMismatch, "list?", "1", strconv.Itoa(argsLen))
 	}
 
-	arg := args.Lst[0]
-	if p.IsNullSym(arg) {
-		return &p.TrueSym, nil
+	if isProperList(args.Lst[0]) {
+		return &p.True, nil
 	}
 
-	if lst, isList := arg.(*p.ExprList); isList {
-		len := len(lst.Lst)
-		if len == 0 || p.IsNullSym(lst.Lst[len-1]) {
-			return &p.TrueSym, nil
-		}
-	}
-
-	return &p.FalseSym, nil
+	return &p.False, nil
 }
 
 // (pair? <expression>)
@@ -761,11 +1391,38 @@ func procIsPair(args *p.ExprList) (ex p.Expression, err *p.Error) {
 		return &p.Void, newError(errArityMismatch, "pair?", "1", strconv.Itoa(argsLen))
 	}
 
-	if _, isPair := isPair(args.Lst[0]); isPair {
-		return &p.TrueSym, nil
+	if _, isPair := args.Lst[0].(*p.Pair); isPair {
+		return &p.True, nil
+	}
+
+	return &p.False, nil
+}
+
+// (apply <procedure> [args...] <list>)
+// calls the procedure with the arguments followed by the items of the list
+func (i *Interpreter) procApply(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen < 2 {
+		return &p.Void, newError(errArityMismatch, "apply", "at least 2", strconv.Itoa(argsLen))
+	}
+
+	pr := args.Lst[0]
+	if !isCallable(pr) {
+		return &p.Void, newError(errContractViolation, "apply", "procedure?", errString(pr))
+	}
+
+	rest, isList := p.AsList(args.Lst[argsLen-1])
+	if !isList {
+		return &p.Void, newError(errContractViolation, "apply", "list?", errString(args.Lst[argsLen-1]))
 	}
 
-	return &p.FalseSym, nil
+	callArgs := p.ExprList{Lst: make([]interface{ p.Expression }, 0, argsLen-2+len(rest))}
+	callArgs.Lst = append(callArgs.Lst, args.Lst[1:argsLen-1]...)
+	for _, arg := range rest {
+		callArgs.Lst = append(callArgs.Lst, arg)
+	}
+
+	return i.genv.apply(pr, &callArgs)
 }
 
 // (max <numbers...>)
@@ -794,139 +1451,280 @@ func procMin(args *p.ExprList) (ex p.Expression, err *p.Error) {
 
 // (- [numbers...]) or (/ [numbers...])
 func procSubDiv(args *p.ExprList, isSub bool) (ex p.Expression, err *p.Error) {
-	res := 0.0
-	if !isSub {
-		res = 1
+	procName, op, res := "/", opDiv, p.NewNumber(1)
+	if isSub {
+		procName, op, res = "-", opSub, p.NewNumber(0)
 	}
 
 	if len(args.Lst) == 0 {
-		return &p.Number{Val: res}, nil
+		return res, nil
 	}
 
-	procName := "/"
-	if isSub {
-		procName = "-"
+	nums, err := toNumbers(procName, args.Lst)
+	if err != nil {
+		return &p.Void, err
 	}
 
-	fnum, isNum := args.Lst[0].(*p.Number)
-	if !isNum {
-		return &p.Void, newError(errContractViolation, procName, "number?", args.Lst[0].String(0))
+	if len(nums) == 1 {
+		return arith(procName, op, res, nums[0])
 	}
-	res = fnum.Val
 
-	if len(args.Lst) == 1 {
-		if isSub {
-			return &p.Number{Val: -res}, nil
-		} else {
-			return &p.Number{Val: 1 / res}, nil
+	res = nums[0]
+	for _, num := range nums[1:] {
+		res, err = arith(procName, op, res, num)
+		if err != nil {
+			return &p.Void, err
 		}
 	}
 
-	for _, ex := range args.Lst[1:] {
-		num, isNum := ex.(*p.Number)
-		if !isNum {
-			return &p.Void, newError(errContractViolation, procName, "number?", args.Lst[0].String(0))
-		}
-		if isSub {
-			res -= num.Val
-		} else {
-			res /= num.Val
+	return res, nil
+}
+
+// (+ [numbers...]) or (* [numbers...])
+func procAddMult(args *p.ExprList, isAdd bool) (ex p.Expression, err *p.Error) {
+	procName, op, res := "*", opMul, p.NewNumber(1)
+	if isAdd {
+		procName, op, res = "+", opAdd, p.NewNumber(0)
+	}
+
+	nums, err := toNumbers(procName, args.Lst)
+	if err != nil {
+		return &p.Void, err
+	}
+
+	for _, num := range nums {
+		res, err = arith(procName, op, res, num)
+		if err != nil {
+			return &p.Void, err
 		}
 	}
 
-	return &p.Number{Val: res}, nil
+	return res, nil
 }
 
-// (+ [numbers...]) or (* [numbers...])
-func procAddMult(args *p.ExprList, isAdd bool) (ex p.Expression, err *p.Error) {
-	res := 0.0
-	if !isAdd {
-		res = 1
+// (remainder <dividend> <divisor>) or (quotient <dividend> <divisor>)
+// the result is inexact if one of the integers is inexact
+func procIntegerDivision(args *p.ExprList, procName string, isRem bool) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen != 2 {
+		return &p.Void, newError(errArityMismatch, procName, "2", strconv.Itoa(argsLen))
 	}
 
-	procName := "*"
-	if isAdd {
-		procName = "+"
+	for _, arg := range args.Lst {
+		if num, isNum := arg.(*p.Number); !isNum || !isInteger(num) {
+			return &p.Void, newError(errContractViolation, procName, "integer?", errString(arg))
+		}
+	}
+
+	num, div := args.Lst[0].(*p.Number), args.Lst[1].(*p.Number)
+	if div.Exact == nil && div.Val == 0 {
+		return &p.Void, &p.Error{Val: procName + ": undefined for 0"}
 	}
 
-	for _, ex := range args.Lst {
-		num, isNum := ex.(*p.Number)
-		if !isNum {
-			return &p.Void, newError(errContractViolation, procName, "number?", ex.String(0))
+	if kindOf(num) == kindReal || kindOf(div) == kindReal {
+		if isRem {
+			return p.NewReal(math.Mod(num.Val, div.Val)), nil
 		}
+		return p.NewReal(math.Trunc(num.Val / div.Val)), nil
+	}
 
-		if isAdd {
-			res += num.Val
-		} else {
-			res *= num.Val
+	if num.Exact == nil && div.Exact == nil {
+		if isRem {
+			return newInteger64(int64(num.Val) % int64(div.Val)), nil
 		}
+		return newInteger64(int64(num.Val) / int64(div.Val)), nil
 	}
 
-	return &p.Number{Val: res}, nil
+	quo, rem := new(big.Int).QuoRem(toBigInt(num), toBigInt(div), new(big.Int))
+	if isRem {
+		return p.NewInteger(rem), nil
+	}
+
+	return p.NewInteger(quo), nil
 }
 
 // (<comparison character> [args...])
 func procComp(args *p.ExprList, comp func(*p.Number, *p.Number) bool) (ex p.Expression, err *p.Error) {
 	if len(args.Lst) == 0 {
-		return &p.TrueSym, nil
+		return &p.True, nil
 	}
 
-	lastNum, isNum := args.Lst[0].(*p.Number)
-	if !isNum {
-		return &p.Void, newError(errContractViolation, "<comparison>", "number?", args.Lst[0].String(0))
+	nums, err := toNumbers("<comparison>", args.Lst)
+	if err != nil {
+		return &p.Void, err
 	}
 
-	for _, ex := range args.Lst[1:] {
-		num, isNum := ex.(*p.Number)
-		if !isNum {
-			return &p.Void, newError(errContractViolation, "<comparison>", "number?", ex.String(0))
+	for i := 1; i < len(nums); i++ {
+		if !comp(nums[i-1], nums[i]) {
+			return &p.False, nil
 		}
-
-		if !comp(lastNum, num) {
-			return &p.FalseSym, nil
-		}
-
-		lastNum = num
 	}
 
-	return &p.TrueSym, nil
+	return &p.True, nil
 }
 
 // returns true if lhs < rhs
 func less(lhs *p.Number, rhs *p.Number) bool {
-	return lhs.Val < rhs.Val
+	cmp, ok := compareNumbers(lhs, rhs)
+	return ok && cmp < 0
 }
 
 // returns true if lhs <= rhs
 func lessEq(lhs *p.Number, rhs *p.Number) bool {
-	return lhs.Val <= rhs.Val
+	cmp, ok := compareNumbers(lhs, rhs)
+	return ok && cmp <= 0
 }
 
 // returns true if lhs > rhs
 func greater(lhs *p.Number, rhs *p.Number) bool {
-	return lhs.Val > rhs.Val
+	cmp, ok := compareNumbers(lhs, rhs)
+	return ok && cmp > 0
 }
 
 // returns true if lhs >= rhs
 func greaterEq(lhs *p.Number, rhs *p.Number) bool {
-	return lhs.Val >= rhs.Val
+	cmp, ok := compareNumbers(lhs, rhs)
+	return ok && cmp >= 0
 }
 
 // returns true if lhs == rhs
 func equal(lhs *p.Number, rhs *p.Number) bool {
-	return lhs.Val == rhs.Val
+	cmp, ok := compareNumbers(lhs, rhs)
+	return ok && cmp == 0
+}
+
+// creates an arity mismatch error for the lambda called with the given
+// arguments, listing the names of the parameters and the given values
+func lambdaArityError(lambda *p.Lambda, args *p.ExprList) (err *p.Error) {
+	name := lambdaName(lambda)
+
+	params := make([]string, len(lambda.Params.Lst))
+	for i, param := range lambda.Params.Lst {
+		params[i] = param.String(0)
+	}
+
+	expected := strconv.Itoa(len(params))
+	if rest := lambda.Params.Tail; rest != nil {
+		expected = "at least " + expected
+		params = append(params, ".", rest.String(0))
+	}
+
+	err = newError(errArityMismatch, name, expected, strconv.Itoa(len(args.Lst)))
+	err.Val = fmt.Sprintf("%s\n  parameters: (%s)", err.Val, strings.Join(params, " "))
+
+	if len(args.Lst) > 0 {
+		err.Val += "\n  arguments...:"
+		for _, arg := range args.Lst {
+			err.Val += "\n   " + errString(arg)
+		}
+	}
+
+	return err
+}
+
+// returns the name of the lambda or, for anonymous
+// lambdas, where they were defined in the input
+func lambdaName(lambda *p.Lambda) string {
+	if len(lambda.Name) != 0 {
+		return lambda.Name
+	}
+
+	if lambda.Pos.Line > 0 {
+		return "lambda at " + lambda.Pos.String()
+	}
+
+	return "lambda"
+}
+
+// test whether the given expression is a procedure or a lambda
+func isCallable(arg p.Expression) bool {
+	switch arg.(type) {
+	case *p.Procedure, *p.Lambda:
+		return true
+	}
+
+	return false
 }
 
-// test whether the given expression is a scheme pair
-func isPair(arg p.Expression) (pair *p.ExprList, isPair bool) {
-	if pair, isPair := arg.(*p.ExprList); isPair && len(pair.Lst) >= 2 {
-		return pair, isPair
+// returns the first of the given number of arguments as a pair or an error
+func toPair(procName string, args *p.ExprList, argsCount int) (pair *p.Pair, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen != argsCount {
+		return nil, newError(errArityMismatch, procName, strconv.Itoa(argsCount), strconv.Itoa(argsLen))
+	}
+
+	pair, isPair := args.Lst[0].(*p.Pair)
+	if !isPair {
+		return nil, newError(errContractViolation, procName, "pair?", errString(args.Lst[0]))
+	}
+
+	return pair, nil
+}
+
+// returns the given code as a nonempty list of expressions,
+// e.g. a clause of cond, and whether it is one
+func asClause(arg p.Expression) (clause *p.ExprList, isClause bool) {
+	if clause, isLst := arg.(*p.ExprList); isLst && len(clause.Lst) >= 1 {
+		return clause, true
 	}
 
 	return nil, false
 }
 
+// prints the result of an evaluation respecting the output limit,
+// multiple values are printed one per line, void results aren't printed
+func (i *Interpreter) printResult(expr p.Expression) {
+	if _, isVoid := expr.(*p.VoidExpr); isVoid {
+		return
+	}
+
+	if mv, isValues := expr.(*p.MultipleValues); isValues {
+		for _, val := range mv.Vals {
+			i.printResult(val)
+		}
+		return
+	}
+
+	limit := i.outputLimit
+	if limit < 0 {
+		limit = 0 // no limit
+	}
+
+	str, truncated := p.StringWith(expr, p.PrintOptions{Limit: limit, Numbers: i.numberFormat})
+	if truncated {
+		str += truncationMarker
+	}
+
+	i.println(str)
+}
+
+// prints the error of an evaluation respecting the output limit
+func (i *Interpreter) printError(err *p.Error) {
+	str := err.String()
+	if i.outputLimit > 0 && len(str) > i.outputLimit {
+		end := i.outputLimit
+		for end > 0 && !utf8.RuneStart(str[end]) {
+			end-- // don't split a character
+		}
+		str = str[:end] + truncationMarker
+	}
+
+	i.println(str)
+}
+
+// returns the error extended with the expression it occured in and
+// the position of the expression, the expression is nil for parse errors
+func failedAt(err *p.Error, expr p.Expression, pos lexer.Position) *p.Error {
+	res := err.Val + "\n  at: " + pos.String()
+	if expr != nil {
+		res += "\n  in: " + p.CodeString(expr)
+	}
+
+	return &p.Error{Val: res, Stack: err.Stack, Pos: err.Pos, File: err.File}
+}
+
 // returns the min and max number from the given list or error
+// both are inexact if one of the numbers is inexact
 func minMax(args *p.ExprList) (min *p.Number, max *p.Number, err *p.Error) {
 	argsLen := len(args.Lst)
 	if argsLen == 0 {
@@ -936,21 +1734,27 @@ func minMax(args *p.ExprList) (min *p.Number, max *p.Number, err *p.Error) {
 	max, isNum := args.Lst[0].(*p.Number)
 	min, _ = args.Lst[0].(*p.Number)
 	if !isNum {
-		return nil, nil, newError(errContractViolation, "min/max", "number?", args.Lst[0].String(0))
+		return nil, nil, newError(errContractViolation, "min/max", "number?", errString(args.Lst[0]))
 	}
 
+	isInexact := max.Inexact
 	for _, expr := range args.Lst[1:] {
 		if curr, isNum := expr.(*p.Number); isNum {
-			if curr.Val > max.Val {
+			if greater(curr, max) {
 				max = curr
 			}
-			if curr.Val < min.Val {
+			if less(curr, min) {
 				min = curr
 			}
+			isInexact = isInexact || curr.Inexact
 		} else {
-			return nil, nil, newError(errContractViolation, "min/max", "number?", expr.String(0))
+			return nil, nil, newError(errContractViolation, "min/max", "number?", errString(expr))
 		}
 	}
 
+	if isInexact {
+		return p.ToInexact(min), p.ToInexact(max), nil
+	}
+
 	return min, max, nil
 }
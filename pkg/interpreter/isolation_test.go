@@ -0,0 +1,76 @@
+package interpreter
+
+import (
+	"testing"
+
+	p "github.com/dimbata23/golang-scheme-interpreter/pkg/parser"
+)
+
+// evaluates the expression in the interpreter, failing the test on errors
+func mustEval(t *testing.T, i *Interpreter, src string) p.Expression {
+	t.Helper()
+
+	res := i.Eval(src)
+	if len(res) != 1 || res[0].Err != nil {
+		t.Fatalf("%s failed: %v", src, res)
+	}
+
+	return res[0].Value
+}
+
+// two interpreters in one process share none of their symbols,
+// definitions and ports
+func TestInterpretersIsolated(t *testing.T) {
+	first, second := NewInterpreter(), NewInterpreter()
+	defer first.Close()
+	defer second.Close()
+
+	// keywords are interned per interpreter
+	key := mustEval(t, first, "#:key")
+	if again := mustEval(t, first, "#:key"); again != key {
+		t.Errorf("reading #:key twice gives two keywords")
+	}
+	if other := mustEval(t, second, "#:key"); other == key {
+		t.Errorf("both interpreters read #:key as the same keyword")
+	}
+
+	// the gensym counters are separate
+	mustEval(t, first, "(gensym)")
+	mustEval(t, first, "(gensym)")
+	if got := p.WriteString(mustEval(t, second, "(gensym)")); got != "g1" {
+		t.Errorf("the first gensym of the second interpreter is %s, want g1", got)
+	}
+
+	// so are the names the gensyms skip
+	mustEval(t, first, "'g3")
+	if got := p.WriteString(mustEval(t, first, "(gensym)")); got != "g4" {
+		t.Errorf("gensym after reading g3 gives %s, want g4", got)
+	}
+	if got := p.WriteString(mustEval(t, second, "(gensym)")); got != "g2" {
+		t.Errorf("gensym of the second interpreter gives %s, want g2", got)
+	}
+
+	// definitions don't cross
+	mustEval(t, first, "(define x 1)")
+	mustEval(t, second, "(define x 2)")
+	if got := p.WriteString(mustEval(t, first, "x")); got != "1" {
+		t.Errorf("x of the first interpreter is %s, want 1", got)
+	}
+	if got := first.Eval("(define y 3)"); got[0].Err != nil || second.Eval("y")[0].Err == nil {
+		t.Errorf("y defined by the first interpreter is bound in the second")
+	}
+
+	// each has its own standard ports
+	if first.StdinPort() == second.StdinPort() {
+		t.Errorf("the interpreters share the standard input port")
+	}
+	if first.currentOutputPort() == second.currentOutputPort() {
+		t.Errorf("the interpreters share the standard output port")
+	}
+
+	mustEval(t, first, "(set-port-read-timeout! (current-input-port) 1)")
+	mustEval(t, first, "(close-port (current-input-port))")
+	if port := second.StdinPort(); port.IsClosed() || port.Timeout != 0 {
+		t.Errorf("closing the standard input of the first interpreter closes the second's")
+	}
+}
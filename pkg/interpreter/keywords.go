@@ -0,0 +1,121 @@
+package interpreter
+
+import (
+	"strconv"
+
+	p "github.com/dimbata23/golang-scheme-interpreter/pkg/parser"
+)
+
+/// ------------------------------------------------------------------------ ///
+/// ----------------------- Keyword procedure methods ---------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// (keyword? <expression>)
+func procIsKeyword(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen != 1 {
+		return &p.Void, newError(errArityMismatch, "keyword?", "1", strconv.Itoa(argsLen))
+	}
+
+	if _, isKw := args.Lst[0].(*p.Keyword); isKw {
+		return &p.True, nil
+	}
+
+	return &p.False, nil
+}
+
+// (keyword->string <keyword>)
+func procKeywordToString(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen != 1 {
+		return &p.Void, newError(errArityMismatch, "keyword->string", "1", strconv.Itoa(argsLen))
+	}
+
+	name, isKw := p.AsKeywordName(args.Lst[0])
+	if !isKw {
+		return &p.Void, newError(errContractViolation, "keyword->string", "keyword?", errString(args.Lst[0]))
+	}
+
+	return p.NewString(name), nil
+}
+
+// (string->keyword <string>)
+func (i *Interpreter) procStringToKeyword(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen != 1 {
+		return &p.Void, newError(errArityMismatch, "string->keyword", "1", strconv.Itoa(argsLen))
+	}
+
+	str, isStr := args.Lst[0].(*p.String)
+	if !isStr {
+		return &p.Void, newError(errContractViolation, "string->keyword", "string?", errString(args.Lst[0]))
+	}
+
+	return i.keywords.Keyword(string(str.Val)), nil
+}
+
+// (plist-get <plist> <key> [default])
+// a plist is a list of alternating keys and values like (#:a 1 #:b 2),
+// returns the value after the first equal key or the default, #f if not given
+func procPlistGet(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen < 2 || argsLen > 3 {
+		return &p.Void, newError(errArityMismatch, "plist-get", "2 or 3", strconv.Itoa(argsLen))
+	}
+
+	items, err := toPlist("plist-get", args.Lst[0])
+	if err != nil {
+		return &p.Void, err
+	}
+
+	for i := 0; i < len(items); i += 2 {
+		if p.Equal(items[i], args.Lst[1]) {
+			return items[i+1], nil
+		}
+	}
+
+	if argsLen == 3 {
+		return args.Lst[2], nil
+	}
+
+	return &p.False, nil
+}
+
+// (plist-put <plist> <key> <value>)
+// returns a new plist with the value of the first equal key replaced,
+// or with the key and the value added at the end if there is no such key
+func procPlistPut(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen != 3 {
+		return &p.Void, newError(errArityMismatch, "plist-put", "3", strconv.Itoa(argsLen))
+	}
+
+	items, err := toPlist("plist-put", args.Lst[0])
+	if err != nil {
+		return &p.Void, err
+	}
+
+	for i := 0; i < len(items); i += 2 {
+		if p.Equal(items[i], args.Lst[1]) {
+			items[i+1] = args.Lst[2]
+			return p.List(items...), nil
+		}
+	}
+
+	return p.List(append(items, args.Lst[1], args.Lst[2])...), nil
+}
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------------- Helper functions --------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// returns the items of the plist or an error if it isn't
+// a proper list with an even number of items
+func toPlist(procName string, arg p.Expression) (items []p.Expression, err *p.Error) {
+	items, isList := p.AsList(arg)
+	if !isList || len(items)%2 != 0 {
+		return nil, newError(errContractViolation, procName, "a list of alternating keys and values", errString(arg))
+	}
+
+	return items, nil
+}
@@ -0,0 +1,252 @@
+package interpreter
+
+import (
+	"strconv"
+
+	p "github.com/dimbata23/golang-scheme-interpreter/pkg/parser"
+)
+
+func init() {
+	registerSpecialForm("let", (*environment).evalLet)
+	registerSpecialForm("let*", (*environment).evalLetStar)
+	registerSpecialForm("letrec", (*environment).evalLetrec)
+	registerSpecialForm("letrec*", (*environment).evalLetrec)
+}
+
+// value of a variable bound by letrec before its initialization
+type uninitializedExpr struct{}
+
+var uninitialized = &uninitializedExpr{}
+
+/// ------------------------------------------------------------------------ ///
+/// ------------------------- Special form methods ------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// (let ((<identifier> <expression>) ...) <body expressions...>)
+// or
+// (let <name> ((<identifier> <expression>) ...) <body expressions...>)
+// the expressions are evaluated in the enclosing environment
+func (env *environment) evalLet(lst *p.ExprList) (ex p.Expression, err *p.Error) {
+	if len(lst.Lst) > 1 {
+		if _, isVar := lst.Lst[1].(*p.Variable); isVar {
+			return env.evalNamedLet(lst)
+		}
+	}
+
+	params, exprs, err := env.letBindings(lst)
+	if err != nil {
+		return &p.Void, err
+	}
+
+	args := p.ExprList{Lst: make([]interface{ p.Expression }, len(exprs))}
+	for i, expr := range exprs {
+		args.Lst[i], err = env.eval(expr)
+		if err != nil {
+			return &p.Void, err
+		}
+	}
+
+	letEnv, err := makeEnvironment("let", env, params, &args)
+	if err != nil {
+		return &p.Void, err
+	}
+
+	return letEnv.evalBody(lst.Lst[2:])
+}
+
+// (let <name> ((<identifier> <expression>) ...) <body expressions...>)
+// binds the name in the body to a procedure of the identifiers with the body
+// and calls it with the values of the expressions, e.g. for a loop
+func (env *environment) evalNamedLet(lst *p.ExprList) (ex p.Expression, err *p.Error) {
+	lstLen := len(lst.Lst)
+	if lstLen < 4 {
+		return &p.Void, newError(errBadSyntax, "let", "at least 3 arguments", strconv.Itoa(lstLen-1))
+	}
+
+	name := lst.Lst[1].(*p.Variable)
+	if err := env.checkParams("let", &p.ExprList{Lst: lst.Lst[1:2]}); err != nil {
+		return &p.Void, err
+	}
+
+	// the bindings and the body follow the name
+	unnamed := &p.ExprList{Lst: append([]interface{ p.Expression }{lst.Lst[0]}, lst.Lst[2:]...), Pos: lst.Pos}
+	params, exprs, err := env.letBindings(unnamed)
+	if err != nil {
+		return &p.Void, err
+	}
+
+	args := p.ExprList{Lst: make([]interface{ p.Expression }, len(exprs))}
+	for i, expr := range exprs {
+		args.Lst[i], err = env.eval(expr)
+		if err != nil {
+			return &p.Void, err
+		}
+	}
+
+	loopEnv, _ := makeEnvironment("let", env, &p.ExprList{}, &p.ExprList{})
+	loop := &p.Lambda{Name: name.Val, Params: params, Body: &p.ExprList{Lst: unnamed.Lst[2:]}, Pos: lst.Pos, File: env.interp.source, Env: &loopEnv}
+	loopEnv.vars[name.Val] = loop
+
+	return loopEnv.applyTail(loop, &args)
+}
+
+// (let* ((<identifier> <expression>) ...) <body expressions...>)
+// every expression is evaluated in an environment with the bindings before it
+func (env *environment) evalLetStar(lst *p.ExprList) (ex p.Expression, err *p.Error) {
+	params, exprs, err := env.letBindings(lst)
+	if err != nil {
+		return &p.Void, err
+	}
+
+	letEnv := env
+	for i, expr := range exprs {
+		val, err := letEnv.eval(expr)
+		if err != nil {
+			return &p.Void, err
+		}
+
+		bindEnv, err := makeEnvironment("let*", letEnv, &p.ExprList{Lst: params.Lst[i : i+1]}, &p.ExprList{Lst: []interface{ p.Expression }{val}})
+		if err != nil {
+			return &p.Void, err
+		}
+		letEnv = &bindEnv
+	}
+
+	if len(exprs) == 0 {
+		bindEnv, _ := makeEnvironment("let*", env, params, &p.ExprList{})
+		letEnv = &bindEnv
+	}
+
+	return letEnv.evalBody(lst.Lst[2:])
+}
+
+// (letrec ((<identifier> <expression>) ...) <body expressions...>)
+// the expressions are evaluated in order in the environment of the bindings
+// so they can refer to each other, using a variable before its expression
+// has been evaluated is an error
+func (env *environment) evalLetrec(lst *p.ExprList) (ex p.Expression, err *p.Error) {
+	params, exprs, err := env.letBindings(lst)
+	if err != nil {
+		return &p.Void, err
+	}
+
+	args := p.ExprList{Lst: make([]interface{ p.Expression }, len(exprs))}
+	for i := range args.Lst {
+		args.Lst[i] = uninitialized
+	}
+
+	letEnv, err := makeEnvironment("letrec", env, params, &args)
+	if err != nil {
+		return &p.Void, err
+	}
+
+	for i, expr := range exprs {
+		val, err := letEnv.eval(expr)
+		if err != nil {
+			return &p.Void, err
+		}
+
+		letEnv.vars[params.Lst[i].(*p.Variable).Val] = val
+	}
+
+	return letEnv.evalBody(lst.Lst[2:])
+}
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------------- Helper functions --------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// returns the identifiers and the expressions of the bindings of a let form
+func (env *environment) letBindings(lst *p.ExprList) (params *p.ExprList, exprs []p.Expression, err *p.Error) {
+	procName := lst.Lst[0].String(0)
+
+	lstLen := len(lst.Lst)
+	if lstLen < 3 {
+		return nil, nil, newError(errBadSyntax, procName, "at least 2 arguments", strconv.Itoa(lstLen-1))
+	}
+
+	bindings, isLst := lst.Lst[1].(*p.ExprList)
+	if !isLst || bindings.Qlevel > 0 {
+		return nil, nil, newError(errBadSyntax, procName, "a list of bindings", p.CodeString(lst.Lst[1]))
+	}
+
+	params = &p.ExprList{Lst: make([]interface{ p.Expression }, 0, len(bindings.Lst))}
+	for _, ex := range bindings.Lst {
+		binding, isLst := ex.(*p.ExprList)
+		if !isLst || binding.Qlevel > 0 || len(binding.Lst) != 2 {
+			return nil, nil, newError(errBadSyntax, procName, "(<identifier> <expression>) as a binding", p.CodeString(ex))
+		}
+
+		if _, isVar := binding.Lst[0].(*p.Variable); !isVar {
+			return nil, nil, newError(errBadSyntax, procName, "an identifier", p.CodeString(binding.Lst[0]))
+		}
+
+		params.Lst = append(params.Lst, binding.Lst[0])
+		exprs = append(exprs, binding.Lst[1])
+	}
+
+	if procName != "let*" {
+		if err := env.checkParams(procName, params); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return params, exprs, nil
+}
+
+// evaluates the body of a lambda or a let in order returning its last
+// expression as a tail call, the names its defines define are bound in the
+// environment of the body before it is evaluated, like by letrec*, so they
+// shadow outer bindings in the whole body and using one before its define
+// is evaluated is an error
+func (env *environment) evalBody(body []interface{ p.Expression }) (ex p.Expression, err *p.Error) {
+	for _, expr := range body {
+		if name, isDefine := internalDefine(expr); isDefine {
+			env.vars[name] = uninitialized
+		}
+	}
+
+	if len(body) == 0 {
+		return &p.Void, nil
+	}
+
+	for _, expr := range body[:len(body)-1] {
+		if _, err = env.eval(expr); err != nil {
+			return &p.Void, err
+		}
+	}
+
+	return env.tail(body[len(body)-1]), nil
+}
+
+// returns the name defined by the expression if it is a define
+// of a body, (define <name> ...) or (define (<name> ...) ...)
+func internalDefine(expr p.Expression) (name string, isDefine bool) {
+	lst, isLst := expr.(*p.ExprList)
+	if !isLst || lst.Qlevel > 0 || len(lst.Lst) < 3 {
+		return "", false
+	}
+
+	if head, isVar := lst.Lst[0].(*p.Variable); !isVar || head.Val != "define" {
+		return "", false
+	}
+
+	target := lst.Lst[1]
+	if sign, isLst := target.(*p.ExprList); isLst && len(sign.Lst) > 0 {
+		target = sign.Lst[0]
+	}
+
+	if v, isVar := target.(*p.Variable); isVar {
+		return v.Val, true
+	}
+
+	return "", false
+}
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------------- String() methods --------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+func (*uninitializedExpr) String(_ int) string {
+	return "#<undefined>"
+}
@@ -0,0 +1,425 @@
+package interpreter
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	p "github.com/dimbata23/golang-scheme-interpreter/pkg/parser"
+)
+
+/// ------------------------------------------------------------------------ ///
+/// ------------------------ List procedure methods ------------------------ ///
+/// ------------------------------------------------------------------------ ///
+
+// (length <list>)
+func procLength(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen != 1 {
+		return &p.Void, newError(errArityMismatch, "length", "1", strconv.Itoa(argsLen))
+	}
+
+	items, err := toListItems("length", args.Lst[0])
+	if err != nil {
+		return &p.Void, err
+	}
+
+	return p.NewNumber(float64(len(items))), nil
+}
+
+// (append [lists...] [tail])
+// returns a list of the items of the lists ending with the last argument,
+// which is shared and doesn't have to be a list
+func (i *Interpreter) procAppend(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen == 0 {
+		return &p.NullSym, nil
+	}
+
+	items := []p.Expression{}
+	for _, arg := range args.Lst[:argsLen-1] {
+		lstItems, err := toListItems("append", arg)
+		if err != nil {
+			return &p.Void, err
+		}
+		items = append(items, lstItems...)
+	}
+
+	i.stats.conses += len(items)
+
+	return p.ListWithTail(items, args.Lst[argsLen-1]), nil
+}
+
+// (reverse <list>)
+func (i *Interpreter) procReverse(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen != 1 {
+		return &p.Void, newError(errArityMismatch, "reverse", "1", strconv.Itoa(argsLen))
+	}
+
+	items, err := toListItems("reverse", args.Lst[0])
+	if err != nil {
+		return &p.Void, err
+	}
+
+	var res p.Expression = &p.NullSym
+	for _, item := range items {
+		res = p.Cons(item, res)
+	}
+
+	i.stats.conses += len(items)
+
+	return res, nil
+}
+
+// (list-ref <list> <index>)
+func procListRef(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen != 2 {
+		return &p.Void, newError(errArityMismatch, "list-ref", "2", strconv.Itoa(argsLen))
+	}
+
+	items, err := toListItems("list-ref", args.Lst[0])
+	if err != nil {
+		return &p.Void, err
+	}
+
+	idx, err := toIndex("list-ref", args.Lst[1])
+	if err != nil {
+		return &p.Void, err
+	}
+
+	if idx >= len(items) {
+		return &p.Void, newError(errIndexOutOfRange, "list-ref", indexRange(len(items)), strconv.Itoa(idx))
+	}
+
+	return items[idx], nil
+}
+
+// (list-tail <list> <count>)
+// returns the list without its first count pairs, the list can be improper
+func procListTail(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen != 2 {
+		return &p.Void, newError(errArityMismatch, "list-tail", "2", strconv.Itoa(argsLen))
+	}
+
+	count, err := toIndex("list-tail", args.Lst[1])
+	if err != nil {
+		return &p.Void, err
+	}
+
+	res := args.Lst[0]
+	for skipped := 0; skipped < count; skipped++ {
+		pair, isPair := res.(*p.Pair)
+		if !isPair {
+			return &p.Void, newError(errIndexOutOfRange, "list-tail", fmt.Sprintf("[0, %d]", skipped), strconv.Itoa(count))
+		}
+		res = pair.Cdr
+	}
+
+	return res, nil
+}
+
+// (memq <expression> <list>)
+func procMemq(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	return procMember(args, "memq", isEq)
+}
+
+// (memv <expression> <list>)
+func procMemv(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	return procMember(args, "memv", isEqv)
+}
+
+// (member <expression> <list> [equality procedure])
+// the items are compared with equal? unless a procedure is given
+func (i *Interpreter) procMember(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	var testErr *p.Error
+	test, err := i.equalityArg("member", args, &testErr)
+	if err != nil {
+		return &p.Void, err
+	}
+
+	ex, err = procMember(&p.ExprList{Lst: args.Lst[:2]}, "member", test)
+	if testErr != nil {
+		return &p.Void, testErr
+	}
+
+	return ex, err
+}
+
+// (assq <key> <association list>)
+func procAssq(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	return procAssoc(args, "assq", isEq)
+}
+
+// (assv <key> <association list>)
+func procAssv(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	return procAssoc(args, "assv", isEqv)
+}
+
+// (assoc <key> <association list> [equality procedure])
+// the keys are compared with equal? unless a procedure is given
+func (i *Interpreter) procAssoc(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	var testErr *p.Error
+	test, err := i.equalityArg("assoc", args, &testErr)
+	if err != nil {
+		return &p.Void, err
+	}
+
+	ex, err = procAssoc(&p.ExprList{Lst: args.Lst[:2]}, "assoc", test)
+	if testErr != nil {
+		return &p.Void, testErr
+	}
+
+	return ex, err
+}
+
+// (sort <list> <less-than procedure> [#:key <extract-key procedure>])
+// the sort is stable, the items are compared by the keys extracted from
+// them if the key procedure is given, which is called once per item
+func (i *Interpreter) procSort(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	positional, opts, err := splitOptions("sort", args.Lst, "key")
+	if err != nil {
+		return &p.Void, err
+	}
+
+	argsLen := len(positional)
+	if argsLen != 2 {
+		return &p.Void, newError(errArityMismatch, "sort", "2", strconv.Itoa(argsLen))
+	}
+
+	items, err := toListItems("sort", positional[0])
+	if err != nil {
+		return &p.Void, err
+	}
+
+	less, extract := positional[1], opts.get("key", nil)
+	for _, proc := range []p.Expression{less, extract} {
+		if proc != nil && !isCallable(proc) {
+			return &p.Void, newError(errContractViolation, "sort", "procedure?", errString(proc))
+		}
+	}
+
+	keys := items
+	if extract != nil {
+		keys = make([]p.Expression, len(items))
+		for j, item := range items {
+			keys[j], err = i.genv.apply(extract, &p.ExprList{Lst: []interface{ p.Expression }{item}})
+			if err != nil {
+				return &p.Void, err
+			}
+		}
+	}
+
+	order := make([]int, len(items))
+	for j := range order {
+		order[j] = j
+	}
+
+	var lessErr *p.Error
+	sort.SliceStable(order, func(l, r int) bool {
+		if lessErr != nil {
+			return false
+		}
+
+		res, err := i.genv.apply(less, &p.ExprList{Lst: []interface{ p.Expression }{keys[order[l]], keys[order[r]]}})
+		lessErr = err
+		return err == nil && !p.IsFalse(res)
+	})
+
+	if lessErr != nil {
+		return &p.Void, lessErr
+	}
+
+	sorted := make([]p.Expression, len(items))
+	for j, idx := range order {
+		sorted[j] = items[idx]
+	}
+
+	i.stats.conses += len(sorted)
+
+	return p.List(sorted...), nil
+}
+
+// (map <procedure> <list> [lists...])
+// returns the list of the results of the procedure applied to the items
+// of the lists at the same positions, as long as the shortest list
+func (i *Interpreter) procMap(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	results, err := i.mapLists("map", args)
+	if err != nil {
+		return &p.Void, err
+	}
+
+	i.stats.conses += len(results)
+
+	return p.List(results...), nil
+}
+
+// (for-each <procedure> <list> [lists...])
+// applies the procedure to the items of the lists at the same positions
+// in order, as long as the shortest list
+func (i *Interpreter) procForEach(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	if _, err = i.mapLists("for-each", args); err != nil {
+		return &p.Void, err
+	}
+
+	return &p.Void, nil
+}
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------------- Helper functions --------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// applies the procedure given first to the items of the lists given after it
+// at the same positions, returns the results, stops at the first error
+func (i *Interpreter) mapLists(procName string, args *p.ExprList) (results []p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen < 2 {
+		return nil, newError(errArityMismatch, procName, "at least 2", strconv.Itoa(argsLen))
+	}
+
+	proc := args.Lst[0]
+	if !isCallable(proc) {
+		return nil, newError(errContractViolation, procName, "procedure?", errString(proc))
+	}
+
+	lists := make([][]p.Expression, argsLen-1)
+	shortest := -1
+	for j, arg := range args.Lst[1:] {
+		if lists[j], err = toListItems(procName, arg); err != nil {
+			return nil, err
+		}
+		if shortest == -1 || len(lists[j]) < shortest {
+			shortest = len(lists[j])
+		}
+	}
+
+	results = make([]p.Expression, shortest)
+	for k := range results {
+		procArgs := &p.ExprList{Lst: make([]interface{ p.Expression }, len(lists))}
+		for j, items := range lists {
+			procArgs.Lst[j] = items[k]
+		}
+
+		if results[k], err = i.genv.apply(proc, procArgs); err != nil {
+			return nil, err
+		}
+	}
+
+	return results, nil
+}
+
+// returns the items of the given expression if it is a proper list
+// or an error, cyclic lists aren't proper lists
+func toListItems(procName string, arg p.Expression) (items []p.Expression, err *p.Error) {
+	if !isProperList(arg) {
+		return nil, newError(errContractViolation, procName, "list?", errString(arg))
+	}
+
+	items, _ = p.AsList(arg)
+	return items, nil
+}
+
+// tests whether the expression is a chain of pairs ending with the null symbol
+func isProperList(expr p.Expression) bool {
+	// the tortoise and the hare, a cyclic list isn't a list
+	slow, fast := expr, expr
+	for {
+		for step := 0; step < 2; step++ {
+			if p.IsNullSym(fast) {
+				return true
+			}
+
+			pair, isPair := fast.(*p.Pair)
+			if !isPair {
+				return false
+			}
+			fast = pair.Cdr
+		}
+
+		slow = slow.(*p.Pair).Cdr
+		if slow == fast {
+			return false
+		}
+	}
+}
+
+// returns the first pair of the list whose car passes the test with the
+// expression, #f if there is none
+func procMember(args *p.ExprList, procName string, test func(lhs p.Expression, rhs p.Expression) bool) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen != 2 {
+		return &p.Void, newError(errArityMismatch, procName, "2", strconv.Itoa(argsLen))
+	}
+
+	if !isProperList(args.Lst[1]) {
+		return &p.Void, newError(errContractViolation, procName, "list?", errString(args.Lst[1]))
+	}
+
+	for lst := args.Lst[1]; !p.IsNullSym(lst); {
+		pair := lst.(*p.Pair)
+		if test(args.Lst[0], pair.Car) {
+			return pair, nil
+		}
+		lst = pair.Cdr
+	}
+
+	return &p.False, nil
+}
+
+// returns the first pair of the association list whose car passes
+// the test with the key, #f if there is none
+func procAssoc(args *p.ExprList, procName string, test func(lhs p.Expression, rhs p.Expression) bool) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen != 2 {
+		return &p.Void, newError(errArityMismatch, procName, "2", strconv.Itoa(argsLen))
+	}
+
+	items, err := toListItems(procName, args.Lst[1])
+	if err != nil {
+		return &p.Void, err
+	}
+
+	for _, item := range items {
+		pair, isPair := item.(*p.Pair)
+		if !isPair {
+			return &p.Void, newError(errContractViolation, procName, "(listof pair?)", errString(args.Lst[1]))
+		}
+
+		if test(args.Lst[0], pair.Car) {
+			return pair, nil
+		}
+	}
+
+	return &p.False, nil
+}
+
+// returns the test of member or assoc, the optional third argument applied
+// as a procedure or equal? if it isn't given
+// an error of the procedure is stored in testErr and ends the search
+func (i *Interpreter) equalityArg(procName string, args *p.ExprList, testErr **p.Error) (test func(lhs p.Expression, rhs p.Expression) bool, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen < 2 || argsLen > 3 {
+		return nil, newError(errArityMismatch, procName, "2 or 3", strconv.Itoa(argsLen))
+	}
+
+	if argsLen == 2 {
+		return p.Equal, nil
+	}
+
+	proc := args.Lst[2]
+	if !isCallable(proc) {
+		return nil, newError(errContractViolation, procName, "procedure?", errString(proc))
+	}
+
+	return func(lhs p.Expression, rhs p.Expression) bool {
+		res, err := i.genv.apply(proc, &p.ExprList{Lst: []interface{ p.Expression }{lhs, rhs}})
+		if err != nil {
+			*testErr = err
+			return true
+		}
+
+		return !p.IsFalse(res)
+	}, nil
+}
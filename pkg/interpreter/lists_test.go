@@ -0,0 +1,19 @@
+package interpreter
+
+import "testing"
+
+func TestMapForEach(t *testing.T) {
+	checkEvals(t, []evalTest{
+		{"(map car '((1) (2)))", "(1 2)"},
+		{"(map + '(1 2 3) '(10 20 30))", "(11 22 33)"},
+		{"(map + '(1 2 3) '(10 20))", "(11 22)"},
+		{"(map (lambda (x) (* x x)) '())", "()"},
+		{"(define acc '())", "#<void>"},
+		{"(for-each (lambda (x y) (set! acc (cons (- x y) acc))) '(5 6) '(1 2))", "#<void>"},
+		{"acc", "(4 4)"},
+		{"(map car '(1 2))", "car: contract violation"},
+		{"(map 1 '(1 2))", "map: contract violation"},
+		{"(for-each car '(1 . 2))", "for-each: contract violation"},
+		{"(map car)", "map: arity mismatch;"},
+	})
+}
@@ -0,0 +1,123 @@
+package interpreter
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	p "github.com/dimbata23/golang-scheme-interpreter/pkg/parser"
+)
+
+// a few forms of a data file, repeated to make files of any size
+const loadChunk = `(define row '(1 2.5 "a row of the data file" #(a b c) #\x (nested (list 3/4))))
+(set! count (+ count 1))
+`
+
+// number of chunks between the samples of the heap
+const chunksPerSample = 500
+
+// writes a data file of about the given size into the directory,
+// which samples the heap as it's loaded
+func writeDataFile(tb testing.TB, dir string, size int) string {
+	tb.Helper()
+
+	path := filepath.Join(dir, fmt.Sprintf("data-%d.scm", size))
+	file, err := os.Create(path)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	defer file.Close()
+
+	w := bufio.NewWriter(file)
+	for n := 0; n*len(loadChunk) < size; n++ {
+		w.WriteString(loadChunk)
+		if n%chunksPerSample == 0 {
+			w.WriteString("(sample-heap)\n")
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		tb.Fatal(err)
+	}
+
+	return path
+}
+
+// loads the file in a new interpreter, returns the number of chunks
+// evaluated and the largest heap in use sampled while loading
+func loadDataFile(tb testing.TB, path string) (chunks int, peak uint64) {
+	tb.Helper()
+
+	i := NewInterpreter()
+	defer i.Close()
+
+	i.Define("count", p.NewNumber(0))
+	i.Define("sample-heap", &p.Procedure{Name: "sample-heap", Fn: func(*p.ExprList) (p.Expression, *p.Error) {
+		var stats runtime.MemStats
+		runtime.GC()
+		runtime.ReadMemStats(&stats)
+		if stats.HeapInuse > peak {
+			peak = stats.HeapInuse
+		}
+		return &p.Void, nil
+	}})
+
+	res := i.Eval(fmt.Sprintf("(load %q) count", path))
+	for _, r := range res {
+		if r.Err != nil {
+			tb.Fatalf("loading %s failed: %s", path, r.Err.Val)
+		}
+	}
+
+	count, _ := res[len(res)-1].Value.(*p.Number)
+	return int(count.Val), peak
+}
+
+// the heap in use while loading a file doesn't grow with the size
+// of the file, the forms are read and evaluated one at a time
+func TestLoadBoundedMemory(t *testing.T) {
+	if testing.Short() {
+		t.Skip("loads files of megabytes")
+	}
+
+	dir := t.TempDir()
+	small, large := 1<<20, 8<<20
+	_, smallPeak := loadDataFile(t, writeDataFile(t, dir, small))
+	chunks, largePeak := loadDataFile(t, writeDataFile(t, dir, large))
+
+	if want := (large + len(loadChunk) - 1) / len(loadChunk); chunks != want {
+		t.Fatalf("evaluated %d chunks of the file, want %d", chunks, want)
+	}
+
+	// a file 8 times the size leaves the heap well below the size of the file
+	if largePeak > smallPeak+uint64(large-small)/4 {
+		t.Errorf("the heap grows from %d bytes loading %d bytes to %d bytes loading %d bytes",
+			smallPeak, small, largePeak, large)
+	}
+}
+
+// loads files of increasing sizes, the peak heap stays about the same
+// while the bytes allocated per load grow with the file
+func BenchmarkLoad(b *testing.B) {
+	dir := b.TempDir()
+	for _, size := range []int{1 << 20, 4 << 20, 16 << 20} {
+		path := writeDataFile(b, dir, size)
+		b.Run(fmt.Sprintf("%dMB", size>>20), func(b *testing.B) {
+			b.SetBytes(int64(size))
+			b.ReportAllocs()
+
+			var peak uint64
+			for n := 0; n < b.N; n++ {
+				_, loadPeak := loadDataFile(b, path)
+				if loadPeak > peak {
+					peak = loadPeak
+				}
+			}
+
+			b.ReportMetric(float64(peak), "peak-heap-bytes")
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package interpreter
+
+import (
+	"strconv"
+
+	p "github.com/dimbata23/golang-scheme-interpreter/pkg/parser"
+)
+
+func init() {
+	registerSpecialForm("define-macro", (*environment).evalDefineMacro)
+}
+
+// macro, the code of its uses is rewritten before they are evaluated
+// the transformer of a define-macro is applied to the unevaluated operands
+// and returns the code to evaluate instead, a define-syntax has rules
+type macro struct {
+	name        string
+	transformer p.Expression
+	rules       *syntaxRules
+}
+
+/// ------------------------------------------------------------------------ ///
+/// ------------------------- Special form methods ------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// (define-macro (<name> [params...]) <transformer body expressions...>)
+// or
+// (define-macro <name> <transformer expression>)
+// the operands are given to the transformer as data, e.g. (swap! a b) gives
+// it the symbols a and b, and the data it returns is evaluated as code
+func (env *environment) evalDefineMacro(lst *p.ExprList) (ex p.Expression, err *p.Error) {
+	lstLen := len(lst.Lst)
+	if lstLen < 3 {
+		return &p.Void, newError(errBadSyntax, "define-macro", "at least 2 arguments", strconv.Itoa(lstLen-1))
+	}
+
+	var ident string
+	var transformer p.Expression
+
+	switch firstArg := lst.Lst[1].(type) {
+	case *p.ExprList:
+		name, isVar := firstArg.Lst[0].(*p.Variable)
+		if !isVar || firstArg.Qlevel > 0 {
+			return &p.Void, newError(errBadSyntax, "define-macro", "identifier", errString(firstArg.Lst[0]))
+		}
+
+		ident = name.Val
+		params := p.ExprList{Lst: firstArg.Lst[1:], Tail: firstArg.Tail}
+		if err := env.checkParams("define-macro", &params); err != nil {
+			return &p.Void, err
+		}
+		transformer = &p.Lambda{Name: ident, Params: &params, Body: &p.ExprList{Lst: lst.Lst[2:]}, Pos: lst.Pos, File: env.interp.source, Env: env}
+
+	case *p.Variable:
+		if lstLen > 3 {
+			return &p.Void, newError(errBadSyntax, "define-macro", "exactly one expression after identifier")
+		}
+
+		ident = firstArg.Val
+		transformer, err = env.eval(lst.Lst[2])
+		if err != nil {
+			return &p.Void, err
+		}
+
+		if !isCallable(transformer) {
+			return &p.Void, newError(errContractViolation, "define-macro", "procedure?", errString(transformer))
+		}
+
+	default:
+		return &p.Void, newError(errBadSyntax, "define-macro", "identifier or list", errString(lst.Lst[1]))
+	}
+
+	if err := env.checkRedefinition("define-macro", ident); err != nil {
+		return &p.Void, err
+	}
+
+	env.vars[ident] = &macro{name: ident, transformer: transformer}
+	if env.parent == nil && lst.Pos.Line != 0 {
+		env.interp.defined(ident, lst.Pos)
+	}
+
+	return &p.Void, nil
+}
+
+/// ------------------------------------------------------------------------ ///
+/// ----------------------- Macro procedure methods ------------------------ ///
+/// ------------------------------------------------------------------------ ///
+
+// (gensym [prefix])
+// returns a new symbol for the code a transformer returns, e.g. g1, the
+// prefix is g unless given, the count is kept by the interpreter and
+// is skipped past the names the interpreter has already read
+func (i *Interpreter) procGensym(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen > 1 {
+		return &p.Void, newError(errArityMismatch, "gensym", "0 or 1", strconv.Itoa(argsLen))
+	}
+
+	prefix := "g"
+	if argsLen == 1 {
+		if name, isSym := p.AsSymbolName(args.Lst[0]); isSym {
+			prefix = name
+		} else if str, isStr := args.Lst[0].(*p.String); isStr {
+			prefix = string(str.Val)
+		} else {
+			return &p.Void, newError(errContractViolation, "gensym", "(or/c symbol? string?)", errString(args.Lst[0]))
+		}
+	}
+
+	for {
+		i.gensyms++
+		name := prefix + strconv.Itoa(i.gensyms)
+		if i.symbols.Intern(name) {
+			return p.NewSymbol(name), nil
+		}
+	}
+}
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------------- Helper functions --------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// returns the macro the head of the list is bound to, if it is one
+func (env *environment) macroOf(lst *p.ExprList) (m *macro, isMacro bool) {
+	head, isVar := lst.Lst[0].(*p.Variable)
+	if !isVar {
+		return nil, false
+	}
+
+	owner := env.owner(head.Val)
+	if owner == nil {
+		return nil, false
+	}
+
+	m, isMacro = owner.vars[head.Val].(*macro)
+	return m, isMacro
+}
+
+// applies the transformer of the macro to the operands of the list
+// returning the resulting code
+func (env *environment) expandMacro(m *macro, lst *p.ExprList) (ex p.Expression, err *p.Error) {
+	if m.rules != nil {
+		return m.rules.expand(m.name, lst)
+	}
+
+	// the operands are copies so the transformer changing them doesn't
+	// change the data of the code kept for the next expansions
+	args := p.ExprList{Lst: make([]interface{ p.Expression }, len(lst.Lst)-1)}
+	for i, operand := range lst.Lst[1:] {
+		args.Lst[i] = p.Clone(p.Quote(operand))
+	}
+
+	res, err := env.apply(m.transformer, &args)
+	if err != nil {
+		return &p.Void, err
+	}
+
+	return p.Unquote(res), nil
+}
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------------- String() methods --------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// printed as `#<macro:<name>>`
+func (m *macro) String(_ int) string {
+	return "#<macro:" + m.name + ">"
+}
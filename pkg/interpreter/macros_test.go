@@ -0,0 +1,28 @@
+package interpreter
+
+import "testing"
+
+func TestBegin(t *testing.T) {
+	checkEvals(t, []evalTest{
+		{"(begin)", "#<void>"},
+		{"(begin 1 2 3)", "3"},
+		{"(begin (define x 1) (define y 2))", "#<void>"},
+		{"(+ x y)", "3"},
+		{"(let ((n 0)) (begin (set! n (+ n 1)) (set! n (+ n 1))) n)", "2"},
+		{"(let loop ((i 0)) (if (< i 100000) (begin (loop (+ i 1))) i))", "100000"},
+	})
+}
+
+// the macros expanding to begin splice their body into the code around them
+func TestMacrosExpandingToBegin(t *testing.T) {
+	checkEvals(t, []evalTest{
+		{"(define-macro (my-begin . body) (cons 'begin body))", "#<void>"},
+		{"(my-begin 1 2)", "2"},
+		{"(my-begin (define z 3) (define w 4))", "#<void>"},
+		{"(* z w)", "12"},
+		{"(define-syntax swap! (syntax-rules () ((_ a b) (let ((tmp a)) (begin (set! a b) (set! b tmp))))))", "#<void>"},
+		{"(define p 1)", "#<void>"},
+		{"(define q 2)", "#<void>"},
+		{"(begin (swap! p q) (list p q))", "(2 1)"},
+	})
+}
@@ -0,0 +1,174 @@
+package interpreter
+
+import (
+	"strconv"
+
+	p "github.com/dimbata23/golang-scheme-interpreter/pkg/parser"
+)
+
+func init() {
+	registerSpecialForm("with-continuation-mark", (*environment).evalWithContinuationMark)
+}
+
+// a continuation mark set by with-continuation-mark, linking to the mark
+// of the enclosing form so the current marks are never modified in place
+type markFrame struct {
+	key  p.Expression
+	val  p.Expression
+	next *markFrame
+}
+
+// scheme continuation mark set, the marks at the time it was taken
+type markSet struct {
+	frames *markFrame
+}
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------- Public functions and methods --------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// returns the values of the current continuation marks with
+// the given key, innermost first, e.g. for a debugger to show them
+func (i *Interpreter) ContinuationMarks(key p.Expression) []p.Expression {
+	return markValues(i.marks, key)
+}
+
+/// ------------------------------------------------------------------------ ///
+/// ------------------------- Special form methods ------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// (with-continuation-mark <key expression> <value expression> <body expression>)
+// the body is evaluated with the value marked under the key, the marks
+// are removed once it returns or fails
+func (env *environment) evalWithContinuationMark(lst *p.ExprList) (ex p.Expression, err *p.Error) {
+	lstLen := len(lst.Lst)
+	if lstLen != 4 {
+		return &p.Void, newError(errBadSyntax, "with-continuation-mark", "exactly 3 arguments", strconv.Itoa(lstLen-1))
+	}
+
+	key, err := env.eval(lst.Lst[1])
+	if err != nil {
+		return &p.Void, err
+	}
+
+	val, err := env.eval(lst.Lst[2])
+	if err != nil {
+		return &p.Void, err
+	}
+
+	interp := env.interp
+	outer := interp.marks
+	interp.marks = &markFrame{key: key, val: val, next: outer}
+	defer func() { interp.marks = outer }()
+
+	return env.eval(lst.Lst[3])
+}
+
+/// ------------------------------------------------------------------------ ///
+/// ------------------------ Mark procedure methods ------------------------ ///
+/// ------------------------------------------------------------------------ ///
+
+// (current-continuation-marks)
+func (i *Interpreter) procCurrentContinuationMarks(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen != 0 {
+		return &p.Void, newError(errArityMismatch, "current-continuation-marks", "0", strconv.Itoa(argsLen))
+	}
+
+	return &markSet{frames: i.marks}, nil
+}
+
+// (continuation-mark-set? <expression>)
+func procIsContinuationMarkSet(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen != 1 {
+		return &p.Void, newError(errArityMismatch, "continuation-mark-set?", "1", strconv.Itoa(argsLen))
+	}
+
+	if _, isSet := args.Lst[0].(*markSet); isSet {
+		return &p.True, nil
+	}
+
+	return &p.False, nil
+}
+
+// (continuation-mark-set->list <mark set> <key>)
+// returns the values marked under the key, innermost first
+func (i *Interpreter) procContinuationMarkSetToList(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen != 2 {
+		return &p.Void, newError(errArityMismatch, "continuation-mark-set->list", "2", strconv.Itoa(argsLen))
+	}
+
+	frames, err := i.toMarkFrames("continuation-mark-set->list", args.Lst[0])
+	if err != nil {
+		return &p.Void, err
+	}
+
+	return p.List(markValues(frames, args.Lst[1])...), nil
+}
+
+// (continuation-mark-set-first <mark set or #f> <key> [default])
+// returns the innermost value marked under the key or the default, #f if
+// not given, #f as the mark set stands for the current marks
+func (i *Interpreter) procContinuationMarkSetFirst(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen < 2 || argsLen > 3 {
+		return &p.Void, newError(errArityMismatch, "continuation-mark-set-first", "2 or 3", strconv.Itoa(argsLen))
+	}
+
+	frames, err := i.toMarkFrames("continuation-mark-set-first", args.Lst[0])
+	if err != nil {
+		return &p.Void, err
+	}
+
+	for frame := frames; frame != nil; frame = frame.next {
+		if p.Equal(frame.key, args.Lst[1]) {
+			return frame.val, nil
+		}
+	}
+
+	if argsLen == 3 {
+		return args.Lst[2], nil
+	}
+
+	return &p.False, nil
+}
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------------- Helper functions --------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// returns the marks of the mark set, of the current ones for #f, or an error
+func (i *Interpreter) toMarkFrames(procName string, arg p.Expression) (frames *markFrame, err *p.Error) {
+	if p.IsFalse(arg) {
+		return i.marks, nil
+	}
+
+	set, isSet := arg.(*markSet)
+	if !isSet {
+		return nil, newError(errContractViolation, procName, "(or/c continuation-mark-set? #f)", errString(arg))
+	}
+
+	return set.frames, nil
+}
+
+// returns the values of the marks with the given key, innermost first
+func markValues(frames *markFrame, key p.Expression) []p.Expression {
+	vals := []p.Expression{}
+	for frame := frames; frame != nil; frame = frame.next {
+		if p.Equal(frame.key, key) {
+			vals = append(vals, frame.val)
+		}
+	}
+
+	return vals
+}
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------------- String() methods --------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+func (*markSet) String(_ int) string {
+	return "#<continuation-mark-set>"
+}
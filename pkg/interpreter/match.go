@@ -0,0 +1,387 @@
+package interpreter
+
+import (
+	"strconv"
+
+	p "github.com/dimbata23/golang-scheme-interpreter/pkg/parser"
+)
+
+func init() {
+	registerSpecialForm("match", (*environment).evalMatch)
+}
+
+/// ------------------------------------------------------------------------ ///
+/// ------------------------- Special form methods ------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// (match <expression> (<pattern> [#:when <guard>] <body expressions...>) ...)
+// a pattern is `_`, an identifier to bind, a literal, or one of
+// (list <patterns...>), (vector <patterns...>) or #(<patterns...>),
+// (cons <car> <cdr>), (? <predicate> [patterns...]), (and [patterns...])
+// and (or [patterns...]); inside list and vector patterns a pattern
+// followed by `...` matches zero or more items
+func (env *environment) evalMatch(lst *p.ExprList) (ex p.Expression, err *p.Error) {
+	lstLen := len(lst.Lst)
+	if lstLen < 2 {
+		return &p.Void, newError(errBadSyntax, "match", "at least 1 argument", strconv.Itoa(lstLen-1))
+	}
+
+	val, err := env.eval(lst.Lst[1])
+	if err != nil {
+		return &p.Void, err
+	}
+
+	for _, ex := range lst.Lst[2:] {
+		clause, isLst := ex.(*p.ExprList)
+		if !isLst || clause.Qlevel > 0 || len(clause.Lst) < 2 {
+			return &p.Void, newError(errBadSyntax, "match", "(<pattern> <body expressions...>) as a clause", errString(ex))
+		}
+
+		binds := make(map[string]p.Expression)
+		matched, err := env.matchPattern(clause.Lst[0], val, binds)
+		if err != nil {
+			return &p.Void, err
+		}
+
+		if !matched {
+			continue
+		}
+
+		clauseEnv := &environment{parent: env, vars: binds, interp: env.interp}
+		env.interp.stats.environments++
+		body := clause.Lst[1:]
+
+		if guard, isKw := p.AsKeywordName(body[0]); isKw && guard == "when" {
+			if len(body) < 3 {
+				return &p.Void, newError(errBadSyntax, "match", "a guard and a body after #:when", errString(ex))
+			}
+
+			res, err := clauseEnv.eval(body[1])
+			if err != nil {
+				return &p.Void, err
+			}
+
+			if p.IsFalse(res) {
+				continue
+			}
+
+			body = body[2:]
+		}
+
+		var res p.Expression = &p.Void
+		for _, expr := range body {
+			res, err = clauseEnv.eval(expr)
+			if err != nil {
+				return &p.Void, err
+			}
+		}
+
+		return res, nil
+	}
+
+	return &p.Void, &p.Error{Val: "match: no matching clause for " + errString(val)}
+}
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------------- Helper functions --------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// tests whether the value matches the pattern
+// adding the variables bound by the pattern to binds
+func (env *environment) matchPattern(pat p.Expression, val p.Expression, binds map[string]p.Expression) (matched bool, err *p.Error) {
+	switch pt := pat.(type) {
+	case *p.Variable:
+		switch pt.Val {
+		case "_":
+			return true, nil
+		case "...":
+			return false, newError(errBadSyntax, "match", "a pattern before `...`", pt.Val)
+		}
+
+		if bound, isBound := binds[pt.Val]; isBound {
+			return p.Equal(bound, val), nil
+		}
+
+		binds[pt.Val] = val
+		return true, nil
+
+	case *p.ExprList:
+		if pt.Qlevel > 0 {
+			return p.Equal(p.Datum(pt), val), nil
+		}
+
+		if len(pt.Lst) == 0 {
+			return false, newError(errBadSyntax, "match", "a pattern", errString(pt))
+		}
+
+		head, isVar := pt.Lst[0].(*p.Variable)
+		if !isVar {
+			return false, newError(errBadSyntax, "match", "a pattern", errString(pt))
+		}
+
+		return env.matchCompound(head.Val, pt, val, binds)
+
+	case *p.Vector:
+		// the items of a vector literal are data, as patterns they are code
+		pats := make([]interface{ p.Expression }, len(pt.Items))
+		for i, item := range pt.Items {
+			pats[i] = p.Unquote(item)
+		}
+
+		return env.matchVector(pats, val, binds)
+	}
+
+	// literals
+	return p.Equal(p.Datum(pat), val), nil
+}
+
+// tests whether the value matches the compound pattern (<head> [patterns...])
+func (env *environment) matchCompound(head string, pat *p.ExprList, val p.Expression, binds map[string]p.Expression) (matched bool, err *p.Error) {
+	pats := pat.Lst[1:]
+
+	switch head {
+	case "list":
+		return env.matchList(pats, val, binds)
+
+	case "vector":
+		return env.matchVector(pats, val, binds)
+
+	case "cons":
+		if len(pats) != 2 {
+			return false, newError(errBadSyntax, "match", "(cons <car> <cdr>)", errString(pat))
+		}
+
+		pair, isPair := val.(*p.Pair)
+		if !isPair {
+			return false, nil
+		}
+
+		matched, err = env.matchPattern(pats[0], pair.Car, binds)
+		if !matched || err != nil {
+			return false, err
+		}
+
+		return env.matchPattern(pats[1], pair.Cdr, binds)
+
+	case "?":
+		if len(pats) < 1 {
+			return false, newError(errBadSyntax, "match", "(? <predicate> [patterns...])", errString(pat))
+		}
+
+		pred, err := env.eval(pats[0])
+		if err != nil {
+			return false, err
+		}
+
+		res, err := env.apply(pred, &p.ExprList{Lst: []interface{ p.Expression }{val}})
+		if err != nil {
+			return false, err
+		}
+
+		if p.IsFalse(res) {
+			return false, nil
+		}
+
+		return env.matchAll(pats[1:], val, binds)
+
+	case "and":
+		return env.matchAll(pats, val, binds)
+
+	case "or":
+		for _, pt := range pats {
+			tryBinds := copyBinds(binds)
+			matched, err := env.matchPattern(pt, val, tryBinds)
+			if err != nil {
+				return false, err
+			}
+
+			if matched {
+				for name, bound := range tryBinds {
+					binds[name] = bound
+				}
+				return true, nil
+			}
+		}
+
+		return false, nil
+	}
+
+	return false, newError(errBadSyntax, "match", "list, vector, cons, ?, and or or pattern", errString(pat))
+}
+
+// tests whether the value is a proper list matching the list patterns
+func (env *environment) matchList(pats []interface{ p.Expression }, val p.Expression, binds map[string]p.Expression) (matched bool, err *p.Error) {
+	items, isList := p.AsList(val)
+	if !isList {
+		return false, nil
+	}
+
+	return env.matchItems(pats, items, binds)
+}
+
+// tests whether the value is a vector whose items match the patterns
+func (env *environment) matchVector(pats []interface{ p.Expression }, val p.Expression, binds map[string]p.Expression) (matched bool, err *p.Error) {
+	vec, isVec := val.(*p.Vector)
+	if !isVec {
+		return false, nil
+	}
+
+	return env.matchItems(pats, vec.Items, binds)
+}
+
+// tests whether the items match the patterns of a list or a vector pattern
+// a pattern followed by `...` matches zero or more items and binds
+// each of its variables to the list of their values
+func (env *environment) matchItems(pats []interface{ p.Expression }, items []p.Expression, binds map[string]p.Expression) (matched bool, err *p.Error) {
+	ellipsis := -1
+	for i, pt := range pats {
+		if v, isVar := pt.(*p.Variable); isVar && v.Val == "..." {
+			if i == 0 || ellipsis != -1 {
+				return false, newError(errBadSyntax, "match", "one `...` after a pattern", p.List(toExprs(pats)...).String(0))
+			}
+			ellipsis = i - 1
+		}
+	}
+
+	if ellipsis == -1 {
+		if len(items) != len(pats) {
+			return false, nil
+		}
+
+		for i, pt := range pats {
+			matched, err := env.matchPattern(pt, items[i], binds)
+			if !matched || err != nil {
+				return false, err
+			}
+		}
+
+		return true, nil
+	}
+
+	before := pats[:ellipsis]
+	repeated := pats[ellipsis]
+	after := pats[ellipsis+2:]
+
+	repLen := len(items) - len(before) - len(after)
+	if repLen < 0 {
+		return false, nil
+	}
+
+	for i, pt := range before {
+		matched, err := env.matchPattern(pt, items[i], binds)
+		if !matched || err != nil {
+			return false, err
+		}
+	}
+
+	for i, pt := range after {
+		matched, err := env.matchPattern(pt, items[len(before)+repLen+i], binds)
+		if !matched || err != nil {
+			return false, err
+		}
+	}
+
+	vars := patternVars(repeated)
+	values := make(map[string][]p.Expression, len(vars))
+	for _, item := range items[len(before) : len(before)+repLen] {
+		itemBinds := make(map[string]p.Expression)
+		matched, err := env.matchPattern(repeated, item, itemBinds)
+		if !matched || err != nil {
+			return false, err
+		}
+
+		for _, name := range vars {
+			values[name] = append(values[name], itemBinds[name])
+		}
+	}
+
+	for _, name := range vars {
+		binds[name] = p.List(values[name]...)
+	}
+
+	return true, nil
+}
+
+// tests whether the value matches all of the given patterns
+func (env *environment) matchAll(pats []interface{ p.Expression }, val p.Expression, binds map[string]p.Expression) (matched bool, err *p.Error) {
+	for _, pt := range pats {
+		matched, err := env.matchPattern(pt, val, binds)
+		if !matched || err != nil {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+// returns the names of the variables bound by the given pattern
+func patternVars(pat p.Expression) (vars []string) {
+	switch pt := pat.(type) {
+	case *p.Variable:
+		switch pt.Val {
+		case "_", "...":
+			return nil
+		}
+		return []string{pt.Val}
+
+	case *p.ExprList:
+		if pt.Qlevel > 0 || len(pt.Lst) == 0 {
+			return nil
+		}
+
+		subpats := pt.Lst[1:]
+		if head, isVar := pt.Lst[0].(*p.Variable); isVar && head.Val == "?" && len(subpats) > 0 {
+			subpats = subpats[1:] // skip the predicate
+		}
+
+		for _, sub := range subpats {
+			for _, name := range patternVars(sub) {
+				if !containsString(vars, name) {
+					vars = append(vars, name)
+				}
+			}
+		}
+
+	case *p.Vector:
+		for _, item := range pt.Items {
+			for _, name := range patternVars(p.Unquote(item)) {
+				if !containsString(vars, name) {
+					vars = append(vars, name)
+				}
+			}
+		}
+	}
+
+	return vars
+}
+
+// returns a copy of the given bindings
+func copyBinds(binds map[string]p.Expression) map[string]p.Expression {
+	res := make(map[string]p.Expression, len(binds))
+	for name, val := range binds {
+		res[name] = val
+	}
+
+	return res
+}
+
+// converts a slice of list items to a slice of expressions
+func toExprs(items []interface{ p.Expression }) []p.Expression {
+	res := make([]p.Expression, len(items))
+	for i, item := range items {
+		res[i] = item
+	}
+
+	return res
+}
+
+// tests whether the slice contains the given string
+func containsString(strs []string, str string) bool {
+	for _, s := range strs {
+		if s == str {
+			return true
+		}
+	}
+
+	return false
+}
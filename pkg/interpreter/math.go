@@ -0,0 +1,419 @@
+package interpreter
+
+import (
+	"math"
+	"math/big"
+	"strconv"
+
+	p "github.com/dimbata23/golang-scheme-interpreter/pkg/parser"
+)
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------------- Inner structure ---------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// the exact integer argument of a math function whose result is an exact
+// integer as well, e.g. 0 for sin as (sin 0) is 0
+type exactPoint struct {
+	arg float64
+	res float64
+}
+
+/// ------------------------------------------------------------------------ ///
+/// ------------------------ Math procedure methods ------------------------ ///
+/// ------------------------------------------------------------------------ ///
+
+// (sqrt <number>)
+// the square roots of exact squares are exact, there are no complex numbers
+// so negative numbers have no square root
+func procSqrt(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	num, err := toNumberArg("sqrt", args)
+	if err != nil {
+		return &p.Void, err
+	}
+
+	if num.Val < 0 {
+		return &p.Void, newError(errContractViolation, "sqrt", "(>=/c 0)", errString(num))
+	}
+
+	switch kindOf(num) {
+	case kindInteger:
+		val := toBigInt(num)
+		root := new(big.Int).Sqrt(val)
+		if new(big.Int).Mul(root, root).Cmp(val) == 0 {
+			return p.NewInteger(root), nil
+		}
+
+		// computed with big floats as bignums may be too big for a float64
+		res, _ := new(big.Float).Sqrt(new(big.Float).SetInt(val)).Float64()
+		return p.NewReal(res), nil
+
+	case kindRational:
+		numer, denom := num.Ratio.Num(), num.Ratio.Denom()
+		numRoot, denRoot := new(big.Int).Sqrt(numer), new(big.Int).Sqrt(denom)
+		if new(big.Int).Mul(numRoot, numRoot).Cmp(numer) == 0 && new(big.Int).Mul(denRoot, denRoot).Cmp(denom) == 0 {
+			return p.NewRational(new(big.Rat).SetFrac(numRoot, denRoot)), nil
+		}
+	}
+
+	return p.NewReal(math.Sqrt(num.Val)), nil
+}
+
+// (exp <number>)
+func procExp(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	return procMathFunc(args, "exp", "", math.Exp, nil, &exactPoint{0, 1})
+}
+
+// (log <number> [base])
+// the natural logarithm unless the base is given, the logarithm of
+// the exact 1 is the exact 0 in an exact base other than 1
+func procLog(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen < 1 || argsLen > 2 {
+		return &p.Void, newError(errArityMismatch, "log", "1 or 2", strconv.Itoa(argsLen))
+	}
+
+	nums, err := toNumbers("log", args.Lst)
+	if err != nil {
+		return &p.Void, err
+	}
+
+	for _, num := range nums {
+		if num.Val < 0 {
+			return &p.Void, newError(errContractViolation, "log", "(>=/c 0)", errString(num))
+		}
+	}
+
+	if isExactValue(nums[0], 1) && (argsLen == 1 || kindOf(nums[1]) != kindReal && !isExactValue(nums[1], 1)) {
+		return p.NewNumber(0), nil
+	}
+
+	res := math.Log(nums[0].Val)
+	if argsLen == 2 {
+		res /= math.Log(nums[1].Val)
+	}
+
+	return p.NewReal(res), nil
+}
+
+// (sin <number>)
+func procSin(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	return procMathFunc(args, "sin", "", math.Sin, nil, &exactPoint{0, 0})
+}
+
+// (cos <number>)
+func procCos(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	return procMathFunc(args, "cos", "", math.Cos, nil, &exactPoint{0, 1})
+}
+
+// (tan <number>)
+func procTan(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	return procMathFunc(args, "tan", "", math.Tan, nil, &exactPoint{0, 0})
+}
+
+// (asin <number>)
+func procAsin(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	return procMathFunc(args, "asin", "(real-in -1 1)", math.Asin, isUnitRange, &exactPoint{0, 0})
+}
+
+// (acos <number>)
+func procAcos(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	return procMathFunc(args, "acos", "(real-in -1 1)", math.Acos, isUnitRange, &exactPoint{1, 0})
+}
+
+// (atan <number>) or (atan <y> <x>)
+// with two arguments the angle of the point (x, y), the angle of
+// the exact 0 and of the point (0, x) for an exact positive x is the exact 0
+func procAtan(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen < 1 || argsLen > 2 {
+		return &p.Void, newError(errArityMismatch, "atan", "1 or 2", strconv.Itoa(argsLen))
+	}
+
+	nums, err := toNumbers("atan", args.Lst)
+	if err != nil {
+		return &p.Void, err
+	}
+
+	if isExactValue(nums[0], 0) && (argsLen == 1 || kindOf(nums[1]) != kindReal && nums[1].Val > 0) {
+		return p.NewNumber(0), nil
+	}
+
+	if argsLen == 2 {
+		return p.NewReal(math.Atan2(nums[0].Val, nums[1].Val)), nil
+	}
+
+	return p.NewReal(math.Atan(nums[0].Val)), nil
+}
+
+// (floor <number>)
+func procFloor(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	return procRounding(args, "floor", math.Floor, floorRat)
+}
+
+// (ceiling <number>)
+func procCeiling(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	return procRounding(args, "ceiling", math.Ceil, func(val *big.Rat) *big.Int {
+		res := floorRat(new(big.Rat).Neg(val))
+		return res.Neg(res)
+	})
+}
+
+// (truncate <number>)
+func procTruncate(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	return procRounding(args, "truncate", math.Trunc, func(val *big.Rat) *big.Int {
+		return new(big.Int).Quo(val.Num(), val.Denom())
+	})
+}
+
+// (round <number>)
+// halfway cases are rounded to the even integer
+func procRound(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	return procRounding(args, "round", math.RoundToEven, func(val *big.Rat) *big.Int {
+		floor := floorRat(val)
+		frac := new(big.Rat).Sub(val, new(big.Rat).SetInt(floor))
+		switch frac.Cmp(big.NewRat(1, 2)) {
+		case -1:
+			return floor
+		case 0:
+			if floor.Bit(0) == 0 {
+				return floor
+			}
+		}
+		return floor.Add(floor, big.NewInt(1))
+	})
+}
+
+// (abs <number>)
+func procAbs(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	num, err := toNumberArg("abs", args)
+	if err != nil {
+		return &p.Void, err
+	}
+
+	if num.Val >= 0 || math.IsNaN(num.Val) {
+		return num, nil
+	}
+
+	return arith("abs", opSub, p.NewNumber(0), num)
+}
+
+// (modulo <dividend> <divisor>)
+// unlike the remainder the result has the sign of the divisor
+func procModulo(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	res, err := procIntegerDivision(args, "modulo", true)
+	if err != nil {
+		return &p.Void, err
+	}
+
+	rem, div := res.(*p.Number), args.Lst[1].(*p.Number)
+	if rem.Val != 0 && (rem.Val < 0) != (div.Val < 0) {
+		return arith("modulo", opAdd, rem, div)
+	}
+
+	return rem, nil
+}
+
+// (gcd [integers...])
+// the greatest common divisor, 0 without integers
+func procGcd(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	return procDivisors(args, "gcd", 0, func(lhs *big.Int, rhs *big.Int) *big.Int {
+		return new(big.Int).GCD(nil, nil, lhs, rhs)
+	})
+}
+
+// (lcm [integers...])
+// the least common multiple, 1 without integers
+func procLcm(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	return procDivisors(args, "lcm", 1, func(lhs *big.Int, rhs *big.Int) *big.Int {
+		if lhs.Sign() == 0 || rhs.Sign() == 0 {
+			return new(big.Int)
+		}
+
+		gcd := new(big.Int).GCD(nil, nil, lhs, rhs)
+		return gcd.Mul(new(big.Int).Quo(lhs, gcd), rhs)
+	})
+}
+
+// (zero? <number>)
+func procIsZero(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	return procNumberTest(args, "zero?", "number?", nil, func(num *p.Number) bool {
+		return num.Val == 0
+	})
+}
+
+// (positive? <number>)
+func procIsPositive(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	return procNumberTest(args, "positive?", "number?", nil, func(num *p.Number) bool {
+		return num.Val > 0
+	})
+}
+
+// (negative? <number>)
+func procIsNegative(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	return procNumberTest(args, "negative?", "number?", nil, func(num *p.Number) bool {
+		return num.Val < 0
+	})
+}
+
+// (even? <integer>)
+func procIsEven(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	return procNumberTest(args, "even?", "integer?", isInteger, isEven)
+}
+
+// (odd? <integer>)
+func procIsOdd(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	return procNumberTest(args, "odd?", "integer?", isInteger, func(num *p.Number) bool {
+		return !isEven(num)
+	})
+}
+
+// (integer? <expression>)
+// inexact numbers with integral values are integers too
+func procIsInteger(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	return procTypeTest(args, "integer?", isInteger)
+}
+
+// (rational? <expression>)
+// every finite number is rational
+func procIsRational(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	return procTypeTest(args, "rational?", func(num *p.Number) bool {
+		return !math.IsInf(num.Val, 0) && !math.IsNaN(num.Val)
+	})
+}
+
+// (real? <expression>)
+// there are no complex numbers so every number is real
+func procIsReal(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	return procTypeTest(args, "real?", func(num *p.Number) bool {
+		return true
+	})
+}
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------------- Helper functions --------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// applies the function to the single number argument, the result is
+// inexact but at the exact point if there is one and the number is its
+// exact argument, the number has to pass the domain test if there is one
+func procMathFunc(args *p.ExprList, procName string, domain string, fn func(float64) float64, inDomain func(float64) bool, exact *exactPoint) (ex p.Expression, err *p.Error) {
+	num, err := toNumberArg(procName, args)
+	if err != nil {
+		return &p.Void, err
+	}
+
+	if inDomain != nil && !inDomain(num.Val) {
+		return &p.Void, newError(errContractViolation, procName, domain, errString(num))
+	}
+
+	if exact != nil && isExactValue(num, exact.arg) {
+		return p.NewNumber(exact.res), nil
+	}
+
+	return p.NewReal(fn(num.Val)), nil
+}
+
+// rounds the single number argument to an integer, exact numbers with
+// the rational function and inexact ones with the float function
+func procRounding(args *p.ExprList, procName string, fn func(float64) float64, ratFn func(*big.Rat) *big.Int) (ex p.Expression, err *p.Error) {
+	num, err := toNumberArg(procName, args)
+	if err != nil {
+		return &p.Void, err
+	}
+
+	switch kindOf(num) {
+	case kindReal:
+		return p.NewReal(fn(num.Val)), nil
+	case kindRational:
+		return p.NewInteger(ratFn(num.Ratio)), nil
+	}
+
+	return num, nil
+}
+
+// folds the absolute values of the integer arguments with the function
+// starting from the initial value, the result is inexact if one of the
+// integers is inexact
+func procDivisors(args *p.ExprList, procName string, initial int64, fn func(*big.Int, *big.Int) *big.Int) (ex p.Expression, err *p.Error) {
+	res := big.NewInt(initial)
+
+	isInexact := false
+	for _, arg := range args.Lst {
+		num, isNum := arg.(*p.Number)
+		if !isNum || !isInteger(num) {
+			return &p.Void, newError(errContractViolation, procName, "integer?", errString(arg))
+		}
+
+		val, _ := p.ToExact(num)
+		res = fn(res, new(big.Int).Abs(toBigInt(val)))
+		isInexact = isInexact || num.Inexact
+	}
+
+	if isInexact {
+		return p.ToInexact(p.NewInteger(res)), nil
+	}
+
+	return p.NewInteger(res), nil
+}
+
+// tests the single number argument, which has to pass the contract
+// test if there is one
+func procNumberTest(args *p.ExprList, procName string, contract string, inContract func(*p.Number) bool, test func(*p.Number) bool) (ex p.Expression, err *p.Error) {
+	num, err := toNumberArg(procName, args)
+	if err != nil {
+		if inContract != nil && len(args.Lst) == 1 {
+			err = newError(errContractViolation, procName, contract, errString(args.Lst[0]))
+		}
+		return &p.Void, err
+	}
+
+	if inContract != nil && !inContract(num) {
+		return &p.Void, newError(errContractViolation, procName, contract, errString(num))
+	}
+
+	if test(num) {
+		return &p.True, nil
+	}
+
+	return &p.False, nil
+}
+
+// tests whether the single argument is a number passing the test
+func procTypeTest(args *p.ExprList, procName string, test func(*p.Number) bool) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen != 1 {
+		return &p.Void, newError(errArityMismatch, procName, "1", strconv.Itoa(argsLen))
+	}
+
+	if num, isNum := args.Lst[0].(*p.Number); isNum && test(num) {
+		return &p.True, nil
+	}
+
+	return &p.False, nil
+}
+
+// tests whether the number is the exact integer
+func isExactValue(num *p.Number, val float64) bool {
+	return kindOf(num) == kindInteger && num.Exact == nil && num.Val == val
+}
+
+// tests whether the integer is even
+func isEven(num *p.Number) bool {
+	if num.Inexact {
+		return math.Mod(num.Val, 2) == 0
+	}
+
+	return toBigInt(num).Bit(0) == 0
+}
+
+// returns the largest integer not greater than the rational
+func floorRat(val *big.Rat) *big.Int {
+	// the denominator is positive so the euclidean division rounds down
+	return new(big.Int).Div(val.Num(), val.Denom())
+}
+
+// tests whether the number is in [-1, 1]
+func isUnitRange(val float64) bool {
+	return val >= -1 && val <= 1 || math.IsNaN(val)
+}
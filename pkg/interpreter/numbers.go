@@ -0,0 +1,164 @@
+package interpreter
+
+import (
+	"strconv"
+
+	p "github.com/dimbata23/golang-scheme-interpreter/pkg/parser"
+)
+
+/// ------------------------------------------------------------------------ ///
+/// ----------------------- Number procedure methods ----------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// (number->string <number> [radix])
+// numbers in radix 10 are written in the number format of the interpreter
+func (i *Interpreter) procNumberToString(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen < 1 || argsLen > 2 {
+		return &p.Void, newError(errArityMismatch, "number->string", "1 or 2", strconv.Itoa(argsLen))
+	}
+
+	num, isNum := args.Lst[0].(*p.Number)
+	if !isNum {
+		return &p.Void, newError(errContractViolation, "number->string", "number?", errString(args.Lst[0]))
+	}
+
+	radix, err := toRadix("number->string", args.Lst[1:])
+	if err != nil {
+		return &p.Void, err
+	}
+
+	if radix == 10 {
+		return p.NewString(p.FormatNumberWith(num, i.numberFormat)), nil
+	}
+
+	if num.Exact != nil {
+		return p.NewString(num.Exact.Text(radix)), nil
+	}
+
+	if num.Ratio != nil {
+		return p.NewString(num.Ratio.Num().Text(radix) + "/" + num.Ratio.Denom().Text(radix)), nil
+	}
+
+	str, isFormatted := p.FormatNumber(num.Val, radix)
+	if !isFormatted {
+		return &p.Void, newError(errContractViolation, "number->string", "integer? for a radix other than 10", errString(args.Lst[0]))
+	}
+
+	return p.NewString(str), nil
+}
+
+// (string->number <string> [radix])
+// returns #f if the string isn't a number
+func procStringToNumber(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen < 1 || argsLen > 2 {
+		return &p.Void, newError(errArityMismatch, "string->number", "1 or 2", strconv.Itoa(argsLen))
+	}
+
+	str, isStr := p.AsString(args.Lst[0])
+	if !isStr {
+		return &p.Void, newError(errContractViolation, "string->number", "string?", errString(args.Lst[0]))
+	}
+
+	radix, err := toRadix("string->number", args.Lst[1:])
+	if err != nil {
+		return &p.Void, err
+	}
+
+	num, err := p.ParseNumberRadix(str, radix)
+	if err != nil {
+		return &p.False, nil
+	}
+
+	return num, nil
+}
+
+// (exact? <number>)
+func procIsExact(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	num, err := toNumberArg("exact?", args)
+	if err != nil {
+		return &p.Void, err
+	}
+
+	if num.Inexact {
+		return &p.False, nil
+	}
+
+	return &p.True, nil
+}
+
+// (inexact? <number>)
+func procIsInexact(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	num, err := toNumberArg("inexact?", args)
+	if err != nil {
+		return &p.Void, err
+	}
+
+	if num.Inexact {
+		return &p.True, nil
+	}
+
+	return &p.False, nil
+}
+
+// (exact->inexact <number>)
+func procExactToInexact(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	num, err := toNumberArg("exact->inexact", args)
+	if err != nil {
+		return &p.Void, err
+	}
+
+	return p.ToInexact(num), nil
+}
+
+// (inexact->exact <number>)
+// reals which aren't integers become the rational of the same value
+func procInexactToExact(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	num, err := toNumberArg("inexact->exact", args)
+	if err != nil {
+		return &p.Void, err
+	}
+
+	res, isExact := p.ToExact(num)
+	if !isExact {
+		return &p.Void, newError(errContractViolation, "inexact->exact", "rational?", errString(args.Lst[0]))
+	}
+
+	return res, nil
+}
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------------- Helper functions --------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// returns the single number argument of the procedure or an error
+func toNumberArg(procName string, args *p.ExprList) (num *p.Number, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen != 1 {
+		return nil, newError(errArityMismatch, procName, "1", strconv.Itoa(argsLen))
+	}
+
+	num, isNum := args.Lst[0].(*p.Number)
+	if !isNum {
+		return nil, newError(errContractViolation, procName, "number?", errString(args.Lst[0]))
+	}
+
+	return num, nil
+}
+
+// returns the optional radix argument, 10 by default, or an error
+func toRadix(procName string, args []interface{ p.Expression }) (radix int, err *p.Error) {
+	if len(args) == 0 {
+		return 10, nil
+	}
+
+	num, isNum := p.AsNumber(args[0])
+	switch {
+	case !isNum:
+	case num == 2, num == 8, num == 10, num == 16:
+		return int(num), nil
+	}
+
+	return 0, newError(errContractViolation, procName, "(or/c 2 8 10 16)", errString(args[0]))
+}
@@ -0,0 +1,95 @@
+package interpreter
+
+import (
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	p "github.com/dimbata23/golang-scheme-interpreter/pkg/parser"
+)
+
+func TestNumberToString(t *testing.T) {
+	checkEvals(t, []evalTest{
+		{"(number->string 255 16)", `"ff"`},
+		{"(number->string -255 2)", `"-11111111"`},
+		{"(number->string 123456789012345678901234567890 16)", `"18ee90ff6c373e0ee4e3f0ad2"`},
+		{"(number->string 1/3 2)", `"1/11"`},
+		{"(number->string 1/3)", `"1/3"`},
+		{"(number->string 1e21)", `"1000000000000000000000.0"`},
+		{"(number->string +inf.0 8)", `"+inf.0"`},
+		{"(number->string 1.5 16)", "number->string: contract violation"},
+		{"(number->string 1 3)", "number->string: contract violation"},
+	})
+}
+
+func TestStringToNumber(t *testing.T) {
+	checkEvals(t, []evalTest{
+		{`(string->number "ff" 16)`, "255"},
+		{`(string->number "#xff")`, "255"},
+		{`(string->number "#b-101")`, "-5"},
+		{`(string->number "1/3" 8)`, "1/3"},
+		{`(string->number "18" 8)`, "#f"},
+		{`(string->number "#e1.5")`, "3/2"},
+		{`(string->number "#i1/2")`, "0.5"},
+		{`(string->number "abc")`, "#f"},
+		{`(string->number "fffffffffffffffffffffffffffff" 16)`, "83076749736557242056487941267521535"},
+		{"#x-1F", "-31"},
+		{"#o777", "511"},
+	})
+}
+
+// number->string, string->number, the reader and the printer agree
+// on the numbers in every radix
+func TestNumberConversionsRoundTrip(t *testing.T) {
+	i := NewInterpreter()
+	defer i.Close()
+
+	rnd := rand.New(rand.NewSource(3))
+	for n := 0; n < 500; n++ {
+		num := randomNumber(rnd)
+		radixes := []int{10}
+		if !num.Inexact {
+			radixes = []int{2, 8, 10, 16}
+		}
+
+		i.Define("n", num)
+		for _, radix := range radixes {
+			i.Define("radix", p.NewNumber(float64(radix)))
+			src := "(let ((str (number->string n radix))) (list (equal? (string->number str radix) n) str))"
+			res := i.Eval(src)
+			if len(res) != 1 || res[0].Err != nil {
+				t.Fatalf("%s with n = %s and radix = %d failed", src, p.WriteString(num), radix)
+			}
+
+			items, _ := p.AsList(res[0].Value)
+			str, _ := p.AsString(items[1])
+			if !p.Truthy(items[0]) {
+				t.Fatalf("%s in radix %d is %q which string->number reads back differently", p.WriteString(num), radix, str)
+			}
+
+			if radix == 10 && str != p.WriteString(num) {
+				t.Fatalf("number->string writes %s as %q, the printer as %q", p.WriteString(num), str, p.WriteString(num))
+			}
+		}
+	}
+}
+
+// returns a random number, an integer that fits in a float64 or a bigger
+// one, a fraction or a real
+func randomNumber(rnd *rand.Rand) *p.Number {
+	switch rnd.Intn(4) {
+	case 0:
+		return p.NewNumber(float64(rnd.Int63n(1<<53) - 1<<52))
+	case 1:
+		val := new(big.Int).Rand(rnd, new(big.Int).Lsh(big.NewInt(1), uint(54+rnd.Intn(200))))
+		if rnd.Intn(2) == 0 {
+			val.Neg(val)
+		}
+		return p.NewInteger(val)
+	case 2:
+		return p.NewRational(big.NewRat(rnd.Int63n(2000000)-1000000, rnd.Int63n(1000000)+1))
+	}
+
+	return p.NewReal(rnd.NormFloat64() * math.Pow(10, float64(rnd.Intn(60)-30)))
+}
@@ -0,0 +1,83 @@
+package interpreter
+
+import (
+	"strings"
+
+	p "github.com/dimbata23/golang-scheme-interpreter/pkg/parser"
+)
+
+// keyword options of a builtin by their names, the convention for builtins
+// taking many flags is to take them as keyword and value pairs after the
+// positional arguments, e.g. (sort lst < #:key car)
+type options map[string]p.Expression
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------------- Helper functions --------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// splits the arguments into the positional ones and the keyword options
+// following them, the names of the options have to be among the allowed ones
+// and every option can be given once
+func splitOptions(procName string, args []interface{ p.Expression }, allowed ...string) (positional []interface{ p.Expression }, opts options, err *p.Error) {
+	end := len(args)
+	for j, arg := range args {
+		if _, isKw := p.AsKeywordName(arg); isKw {
+			end = j
+			break
+		}
+	}
+
+	opts = options{}
+	for j := end; j < len(args); j += 2 {
+		name, isKw := p.AsKeywordName(args[j])
+		if !isKw {
+			return nil, nil, &p.Error{Val: procName + ": expected a keyword option, given " + errString(args[j])}
+		}
+
+		if !isAllowedOption(name, allowed) {
+			return nil, nil, &p.Error{Val: procName + ": unknown option `#:" + name + "`, expected one of " + optionNames(allowed)}
+		}
+
+		if _, isGiven := opts[name]; isGiven {
+			return nil, nil, &p.Error{Val: procName + ": option `#:" + name + "` given more than once"}
+		}
+
+		if j+1 == len(args) {
+			return nil, nil, &p.Error{Val: procName + ": missing a value after option `#:" + name + "`"}
+		}
+
+		opts[name] = args[j+1]
+	}
+
+	return args[:end], opts, nil
+}
+
+// returns the value of the option or the default if it isn't given
+func (opts options) get(name string, def p.Expression) p.Expression {
+	if val, isGiven := opts[name]; isGiven {
+		return val
+	}
+
+	return def
+}
+
+// tests whether the option name is one of the allowed ones
+func isAllowedOption(name string, allowed []string) bool {
+	for _, opt := range allowed {
+		if opt == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// returns the allowed options written as keywords, e.g. `#:key`
+func optionNames(allowed []string) string {
+	names := make([]string, len(allowed))
+	for j, opt := range allowed {
+		names[j] = "`#:" + opt + "`"
+	}
+
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,156 @@
+package interpreter
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+
+	p "github.com/dimbata23/golang-scheme-interpreter/pkg/parser"
+)
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------- Public functions and methods --------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// sets the directory relative paths are resolved against, e.g. by load,
+// an empty directory is the working directory of the process
+func (i *Interpreter) SetDirectory(dir string) {
+	i.directory = dir
+}
+
+/// ------------------------------------------------------------------------ ///
+/// ------------------------ Path procedure methods ------------------------ ///
+/// ------------------------------------------------------------------------ ///
+
+// (current-directory [path])
+// returns the current directory or, given a path, changes it
+// relative paths are resolved against the previous current directory
+func (i *Interpreter) procCurrentDirectory(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen > 1 {
+		return &p.Void, newError(errArityMismatch, "current-directory", "0 or 1", strconv.Itoa(argsLen))
+	}
+
+	if argsLen == 0 {
+		dir, err := i.currentDirectory()
+		if err != nil {
+			return &p.Void, err
+		}
+		return p.NewString(dir), nil
+	}
+
+	path, isStr := p.AsString(args.Lst[0])
+	if !isStr {
+		return &p.Void, newError(errContractViolation, "current-directory", "string?", errString(args.Lst[0]))
+	}
+
+	dir, err := i.currentDirectory()
+	if err != nil {
+		return &p.Void, err
+	}
+
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+
+	if info, statErr := os.Stat(path); statErr != nil || !info.IsDir() {
+		return &p.Void, &p.Error{Val: "current-directory: not a directory\n  path: " + path}
+	}
+
+	i.directory = filepath.Clean(path)
+
+	return &p.Void, nil
+}
+
+// (build-path <base> [parts...])
+func procBuildPath(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen < 1 {
+		return &p.Void, newError(errArityMismatch, "build-path", "at least 1", strconv.Itoa(argsLen))
+	}
+
+	parts := make([]string, argsLen)
+	for j, arg := range args.Lst {
+		part, isStr := p.AsString(arg)
+		if !isStr {
+			return &p.Void, newError(errContractViolation, "build-path", "string?", errString(arg))
+		}
+
+		if j > 0 && filepath.IsAbs(part) {
+			return &p.Void, newError(errContractViolation, "build-path", "a relative path after the base", part)
+		}
+		parts[j] = part
+	}
+
+	return p.NewString(filepath.Join(parts...)), nil
+}
+
+// (path-directory <path>)
+// returns the path without its last element, "." if it has only one
+func procPathDirectory(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	path, err := toPath("path-directory", args)
+	if err != nil {
+		return &p.Void, err
+	}
+
+	return p.NewString(filepath.Dir(path)), nil
+}
+
+// (path-extension <path>)
+// returns the extension of the last element of the path with its dot, e.g.
+// ".scm", or #f if it has none
+func procPathExtension(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	path, err := toPath("path-extension", args)
+	if err != nil {
+		return &p.Void, err
+	}
+
+	ext := filepath.Ext(path)
+	if ext == "" || ext == filepath.Base(path) {
+		return &p.False, nil
+	}
+
+	return p.NewString(ext), nil
+}
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------------- Helper functions --------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// returns the directory relative paths are resolved against
+func (i *Interpreter) currentDirectory() (dir string, err *p.Error) {
+	if i.directory != "" {
+		return i.directory, nil
+	}
+
+	dir, osErr := os.Getwd()
+	if osErr != nil {
+		return "", &p.Error{Val: "current-directory: " + osErr.Error()}
+	}
+
+	return dir, nil
+}
+
+// returns the path resolved against the current directory
+func (i *Interpreter) resolvePath(path string) string {
+	if i.directory == "" || filepath.IsAbs(path) {
+		return path
+	}
+
+	return filepath.Join(i.directory, path)
+}
+
+// returns the only argument as a path or an error
+func toPath(procName string, args *p.ExprList) (path string, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen != 1 {
+		return "", newError(errArityMismatch, procName, "1", strconv.Itoa(argsLen))
+	}
+
+	path, isStr := p.AsString(args.Lst[0])
+	if !isStr {
+		return "", newError(errContractViolation, procName, "string?", errString(args.Lst[0]))
+	}
+
+	return path, nil
+}
@@ -0,0 +1,93 @@
+package interpreter
+
+import (
+	p "github.com/dimbata23/golang-scheme-interpreter/pkg/parser"
+)
+
+/// ------------------------------------------------------------------------ ///
+/// -------------------------- Public definitions -------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// pool of sandboxed interpreters with a prelude already loaded, for handing
+// out one interpreter per request, interpreters don't share any definitions
+type Pool struct {
+	prelude string            // scheme source loaded into every interpreter
+	idle    chan *Interpreter // interpreters ready to be handed out
+}
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------- Public functions and methods --------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// creates a pool keeping up to size interpreters ready, the prelude is
+// loaded before the interpreters are sandboxed so it can import libraries
+// returns an error if the prelude couldn't be loaded, whatever the size
+func NewPool(size int, prelude string) (pool *Pool, err *p.Error) {
+	pool = &Pool{prelude: prelude, idle: make(chan *Interpreter, size)}
+
+	i, err := pool.warm()
+	if err != nil {
+		return nil, err
+	}
+
+	if size == 0 {
+		i.Close()
+		return pool, nil
+	}
+	pool.idle <- i
+
+	for j := 1; j < size; j++ {
+		i, err := pool.warm()
+		if err != nil {
+			return nil, err
+		}
+		pool.idle <- i
+	}
+
+	return pool, nil
+}
+
+// returns a sandboxed interpreter with the prelude loaded, or the error
+// of loading the prelude if no interpreter was ready and it failed
+// the interpreter should be given back with Put after use
+func (pool *Pool) Get() (i *Interpreter, err *p.Error) {
+	select {
+	case i := <-pool.idle:
+		return i, nil
+	default:
+		return pool.warm()
+	}
+}
+
+// gives back an interpreter taken with Get, it is closed and replaced
+// with a new interpreter so nothing defined during its use is kept
+func (pool *Pool) Put(i *Interpreter) {
+	i.Close()
+	go func() {
+		i, err := pool.warm()
+		if err != nil {
+			return
+		}
+
+		select {
+		case pool.idle <- i:
+		default: // the pool is full
+		}
+	}()
+}
+
+/// ------------------------------------------------------------------------ ///
+/// -------------------- Private functions and methods --------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// creates a new sandboxed interpreter with the prelude loaded
+func (pool *Pool) warm() (i *Interpreter, err *p.Error) {
+	i = NewInterpreter()
+	if _, err := i.genv.evalSource(pool.prelude); err != nil {
+		i.Close()
+		return nil, err
+	}
+
+	i.Sandbox()
+	return i, nil
+}
@@ -0,0 +1,707 @@
+package interpreter
+
+import (
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	p "github.com/dimbata23/golang-scheme-interpreter/pkg/parser"
+)
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------- Public functions and methods --------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// returns an input port reading from the given reader
+// which can be bound with Define to be used by scheme code
+func (i *Interpreter) NewPortFromReader(r io.Reader) *p.Port {
+	return p.NewInputPort("reader", r)
+}
+
+// returns an output port writing to the given writer
+// which can be bound with Define to be used by scheme code
+func (i *Interpreter) NewPortFromWriter(w io.Writer) *p.Port {
+	return p.NewOutputPort("writer", w)
+}
+
+// returns the port of the interpreter reading the standard input,
+// a front-end reading the standard input too should read through it,
+// otherwise it loses what the port has read ahead
+func (i *Interpreter) StdinPort() *p.Port {
+	if i.stdin == nil {
+		i.stdin = p.NewInputPort("stdin", os.Stdin)
+	}
+
+	return i.stdin
+}
+
+// sets the port the input procedures read from by default,
+// nil restores the standard input
+func (i *Interpreter) SetInputPort(port *p.Port) {
+	i.inputPort = port
+}
+
+// sets the port the output procedures write to by default,
+// nil restores the standard output
+func (i *Interpreter) SetOutputPort(port *p.Port) {
+	i.outputPort = port
+}
+
+/// ------------------------------------------------------------------------ ///
+/// ------------------------ Port procedure methods ------------------------ ///
+/// ------------------------------------------------------------------------ ///
+
+// (current-input-port)
+func (i *Interpreter) procCurrentInputPort(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen != 0 {
+		return &p.Void, newError(errArityMismatch, "current-input-port", "0", strconv.Itoa(argsLen))
+	}
+
+	return i.currentInputPort(), nil
+}
+
+// (current-output-port)
+func (i *Interpreter) procCurrentOutputPort(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen != 0 {
+		return &p.Void, newError(errArityMismatch, "current-output-port", "0", strconv.Itoa(argsLen))
+	}
+
+	return i.currentOutputPort(), nil
+}
+
+// (input-port? <expression>)
+func procIsInputPort(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen != 1 {
+		return &p.Void, newError(errArityMismatch, "input-port?", "1", strconv.Itoa(argsLen))
+	}
+
+	if port, isPort := args.Lst[0].(*p.Port); isPort && port.IsInput() {
+		return &p.True, nil
+	}
+
+	return &p.False, nil
+}
+
+// (output-port? <expression>)
+func procIsOutputPort(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen != 1 {
+		return &p.Void, newError(errArityMismatch, "output-port?", "1", strconv.Itoa(argsLen))
+	}
+
+	if port, isPort := args.Lst[0].(*p.Port); isPort && port.IsOutput() {
+		return &p.True, nil
+	}
+
+	return &p.False, nil
+}
+
+// (write-char <char> [port])
+func (i *Interpreter) procWriteChar(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen < 1 || argsLen > 2 {
+		return &p.Void, newError(errArityMismatch, "write-char", "1 or 2", strconv.Itoa(argsLen))
+	}
+
+	ch, isChar := args.Lst[0].(*p.Char)
+	if !isChar {
+		return &p.Void, newError(errContractViolation, "write-char", "char?", errString(args.Lst[0]))
+	}
+
+	port, err := i.toOutputPort("write-char", args.Lst[1:])
+	if err != nil {
+		return &p.Void, err
+	}
+
+	if ioerr := port.WriteString(string(ch.Val)); ioerr != nil {
+		return &p.Void, &p.Error{Val: "write-char: " + ioerr.Error()}
+	}
+
+	return &p.Void, nil
+}
+
+// (display <expression> [port])
+// strings and characters are written as their characters
+func (i *Interpreter) procDisplay(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	return i.procPrint(args, "display", p.DisplayString)
+}
+
+// (write <expression> [port])
+// the expression is written in the syntax read reads back
+func (i *Interpreter) procWrite(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	return i.procPrint(args, "write", p.WriteString)
+}
+
+// (newline [port])
+func (i *Interpreter) procNewline(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen > 1 {
+		return &p.Void, newError(errArityMismatch, "newline", "0 or 1", strconv.Itoa(argsLen))
+	}
+
+	port, err := i.toOutputPort("newline", args.Lst)
+	if err != nil {
+		return &p.Void, err
+	}
+
+	if ioerr := port.WriteString("\n"); ioerr != nil {
+		return &p.Void, &p.Error{Val: "newline: " + ioerr.Error()}
+	}
+
+	return &p.Void, nil
+}
+
+// (write-string <string> [port])
+func (i *Interpreter) procWriteString(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen < 1 || argsLen > 2 {
+		return &p.Void, newError(errArityMismatch, "write-string", "1 or 2", strconv.Itoa(argsLen))
+	}
+
+	str, isStr := p.AsString(args.Lst[0])
+	if !isStr {
+		return &p.Void, newError(errContractViolation, "write-string", "string?", errString(args.Lst[0]))
+	}
+
+	port, err := i.toOutputPort("write-string", args.Lst[1:])
+	if err != nil {
+		return &p.Void, err
+	}
+
+	if ioerr := port.WriteString(str); ioerr != nil {
+		return &p.Void, &p.Error{Val: "write-string: " + ioerr.Error()}
+	}
+
+	return &p.Void, nil
+}
+
+// (char-ready? [port])
+func (i *Interpreter) procCharReady(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	port, err := i.toInputPort("char-ready?", args)
+	if err != nil {
+		return &p.Void, err
+	}
+
+	if port.Ready() {
+		return &p.True, nil
+	}
+
+	return &p.False, nil
+}
+
+// (read-char [port])
+// returns #f if the port has a timeout and no character arrived in time
+func (i *Interpreter) procReadChar(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	port, err := i.toInputPort("read-char", args)
+	if err != nil {
+		return &p.Void, err
+	}
+
+	return portRead("read-char", port.ReadChar)
+}
+
+// (peek-char [port])
+// returns #f if the port has a timeout and no character arrived in time
+func (i *Interpreter) procPeekChar(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	port, err := i.toInputPort("peek-char", args)
+	if err != nil {
+		return &p.Void, err
+	}
+
+	return portRead("peek-char", port.PeekChar)
+}
+
+// (read-line [port])
+// returns the characters up to the next newline without it,
+// or the eof object if the port has no more characters
+func (i *Interpreter) procReadLine(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	port, err := i.toInputPort("read-line", args)
+	if err != nil {
+		return &p.Void, err
+	}
+
+	line, ioerr := port.ReadLine()
+	if ioerr == io.EOF {
+		return &p.EOFObject, nil
+	}
+	if ioerr != nil {
+		return &p.Void, &p.Error{Val: "read-line: " + ioerr.Error()}
+	}
+
+	return p.NewString(line), nil
+}
+
+// (read [port])
+// reads the next datum of the port, consuming only its characters,
+// returns the eof object if the port has no more data
+func (i *Interpreter) procRead(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	port, err := i.toInputPort("read", args)
+	if err != nil {
+		return &p.Void, err
+	}
+
+	src, ioerr := readDatumSource(port)
+	if ioerr == io.EOF {
+		return &p.EOFObject, nil
+	}
+	if ioerr != nil {
+		return &p.Void, &p.Error{Val: "read: " + ioerr.Error()}
+	}
+
+	datum, err := i.newParser(src).NextDatum()
+	if err != nil {
+		return &p.Void, &p.Error{Val: "read: " + err.Val}
+	}
+	if datum == nil {
+		return &p.EOFObject, nil
+	}
+
+	return datum, nil
+}
+
+// (read-all [port])
+// returns a list of all the data left in the port
+func (i *Interpreter) procReadAll(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	port, err := i.toInputPort("read-all", args)
+	if err != nil {
+		return &p.Void, err
+	}
+
+	var src strings.Builder
+	for {
+		r, ioerr := port.ReadChar()
+		if ioerr == io.EOF {
+			break
+		}
+		if ioerr != nil {
+			return &p.Void, &p.Error{Val: "read-all: " + ioerr.Error()}
+		}
+		src.WriteRune(r)
+	}
+
+	var data []p.Expression
+	par := i.newParser(src.String())
+	for {
+		datum, err := par.NextDatum()
+		if datum == nil {
+			break // parser has finished
+		}
+		if err != nil {
+			return &p.Void, &p.Error{Val: "read-all: " + err.Val}
+		}
+		data = append(data, datum)
+	}
+
+	i.stats.conses += len(data)
+
+	return p.List(data...), nil
+}
+
+// (with-input-from-string <string> <thunk>)
+// calls the thunk with a port reading the string as the current input port
+func (i *Interpreter) procWithInputFromString(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen != 2 {
+		return &p.Void, newError(errArityMismatch, "with-input-from-string", "2", strconv.Itoa(argsLen))
+	}
+
+	str, isStr := p.AsString(args.Lst[0])
+	if !isStr {
+		return &p.Void, newError(errContractViolation, "with-input-from-string", "string?", errString(args.Lst[0]))
+	}
+
+	if !isCallable(args.Lst[1]) {
+		return &p.Void, newError(errContractViolation, "with-input-from-string", "procedure?", errString(args.Lst[1]))
+	}
+
+	prev := i.inputPort
+	i.inputPort = p.NewStringInputPort(str)
+	ex, err = i.genv.apply(args.Lst[1], &p.ExprList{})
+	i.inputPort = prev
+
+	return ex, err
+}
+
+// (open-input-string <string>)
+func procOpenInputString(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen != 1 {
+		return &p.Void, newError(errArityMismatch, "open-input-string", "1", strconv.Itoa(argsLen))
+	}
+
+	str, isStr := p.AsString(args.Lst[0])
+	if !isStr {
+		return &p.Void, newError(errContractViolation, "open-input-string", "string?", errString(args.Lst[0]))
+	}
+
+	return p.NewStringInputPort(str), nil
+}
+
+// (open-output-string)
+// the written characters are returned by get-output-string
+func procOpenOutputString(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen != 0 {
+		return &p.Void, newError(errArityMismatch, "open-output-string", "0", strconv.Itoa(argsLen))
+	}
+
+	return p.NewStringOutputPort(), nil
+}
+
+// (get-output-string <port>)
+func procGetOutputString(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen != 1 {
+		return &p.Void, newError(errArityMismatch, "get-output-string", "1", strconv.Itoa(argsLen))
+	}
+
+	port, _ := args.Lst[0].(*p.Port)
+	if port == nil {
+		return &p.Void, newError(errContractViolation, "get-output-string", "string-port?", errString(args.Lst[0]))
+	}
+
+	str, isStrPort := port.Contents()
+	if !isStrPort {
+		return &p.Void, newError(errContractViolation, "get-output-string", "string-port?", errString(args.Lst[0]))
+	}
+
+	return p.NewString(str), nil
+}
+
+// (close-port <port>)
+// closes the port and the file it reads or writes, if any,
+// closing a port again does nothing
+func (i *Interpreter) procClosePort(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen != 1 {
+		return &p.Void, newError(errArityMismatch, "close-port", "1", strconv.Itoa(argsLen))
+	}
+
+	port, isPort := args.Lst[0].(*p.Port)
+	if !isPort {
+		return &p.Void, newError(errContractViolation, "close-port", "port?", errString(args.Lst[0]))
+	}
+
+	return &p.Void, i.closePort("close-port", port)
+}
+
+// (set-port-read-timeout! <port> <seconds or #f>)
+func procSetPortReadTimeout(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen != 2 {
+		return &p.Void, newError(errArityMismatch, "set-port-read-timeout!", "2", strconv.Itoa(argsLen))
+	}
+
+	port, isPort := args.Lst[0].(*p.Port)
+	if !isPort {
+		return &p.Void, newError(errContractViolation, "set-port-read-timeout!", "input-port?", errString(args.Lst[0]))
+	}
+
+	if p.IsFalse(args.Lst[1]) {
+		port.Timeout = 0
+		return &p.Void, nil
+	}
+
+	secs, isNum := args.Lst[1].(*p.Number)
+	if !isNum || secs.Val < 0 {
+		return &p.Void, newError(errContractViolation, "set-port-read-timeout!", "(or/c #f (>=/c 0))", errString(args.Lst[1]))
+	}
+
+	port.Timeout = time.Duration(secs.Val * float64(time.Second))
+
+	return &p.Void, nil
+}
+
+// (eof-object)
+func procEOFObject(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen != 0 {
+		return &p.Void, newError(errArityMismatch, "eof-object", "0", strconv.Itoa(argsLen))
+	}
+
+	return &p.EOFObject, nil
+}
+
+// (eof-object? <expression>)
+func procIsEOFObject(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen != 1 {
+		return &p.Void, newError(errArityMismatch, "eof-object?", "1", strconv.Itoa(argsLen))
+	}
+
+	if _, isEOF := args.Lst[0].(*p.EOFExpr); isEOF {
+		return &p.True, nil
+	}
+
+	return &p.False, nil
+}
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------------- Helper functions --------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// returns the current input port of the interpreter
+func (i *Interpreter) currentInputPort() *p.Port {
+	if i.inputPort == nil {
+		return i.StdinPort()
+	}
+
+	return i.inputPort
+}
+
+// returns the optional port argument or the current input port
+func (i *Interpreter) toInputPort(procName string, args *p.ExprList) (port *p.Port, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen > 1 {
+		return nil, newError(errArityMismatch, procName, "0 or 1", strconv.Itoa(argsLen))
+	}
+
+	if argsLen == 0 {
+		return i.currentInputPort(), nil
+	}
+
+	port, isPort := args.Lst[0].(*p.Port)
+	if !isPort || !port.IsInput() {
+		return nil, newError(errContractViolation, procName, "input-port?", errString(args.Lst[0]))
+	}
+
+	return port, nil
+}
+
+// returns the current output port of the interpreter
+func (i *Interpreter) currentOutputPort() *p.Port {
+	if i.outputPort == nil {
+		if i.stdout == nil {
+			i.stdout = p.NewOutputPort("stdout", os.Stdout)
+		}
+		return i.stdout
+	}
+
+	return i.outputPort
+}
+
+// writes the line to the current output port, used for what the
+// interpreter prints itself, e.g. results, errors and warnings
+func (i *Interpreter) println(str string) {
+	i.currentOutputPort().WriteString(str + "\n")
+}
+
+// returns the optional port argument or the current output port
+func (i *Interpreter) toOutputPort(procName string, args []interface{ p.Expression }) (port *p.Port, err *p.Error) {
+	if len(args) == 0 {
+		return i.currentOutputPort(), nil
+	}
+
+	port, isPort := args[0].(*p.Port)
+	if !isPort || !port.IsOutput() {
+		return nil, newError(errContractViolation, procName, "output-port?", errString(args[0]))
+	}
+
+	return port, nil
+}
+
+// writes the expression printed by the function to the optional port
+// argument or the current output port
+func (i *Interpreter) procPrint(args *p.ExprList, procName string, print func(p.Expression) string) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen < 1 || argsLen > 2 {
+		return &p.Void, newError(errArityMismatch, procName, "1 or 2", strconv.Itoa(argsLen))
+	}
+
+	port, err := i.toOutputPort(procName, args.Lst[1:])
+	if err != nil {
+		return &p.Void, err
+	}
+
+	if ioerr := port.WriteString(print(args.Lst[0])); ioerr != nil {
+		return &p.Void, &p.Error{Val: procName + ": " + ioerr.Error()}
+	}
+
+	return &p.Void, nil
+}
+
+// reads the characters of the next datum of the port, up to its end
+// and not further, e.g. the characters after an identifier are only
+// peeked at, err is io.EOF if the port has only whitespace left
+func readDatumSource(port *p.Port) (src string, err error) {
+	var sb strings.Builder
+	depth := 0
+	skipped := 0 // number of data commented out with #; still to read
+
+	// reads the characters while they pass the test
+	readWhile := func(test func(r rune) bool) (err error) {
+		for {
+			r, err := port.PeekChar()
+			if err == io.EOF || err == nil && !test(r) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+
+			port.ReadChar()
+			sb.WriteRune(r)
+		}
+	}
+
+	for {
+		r, err := port.ReadChar()
+		if err == io.EOF && strings.TrimSpace(sb.String()) == "" {
+			return "", io.EOF
+		}
+		if err == io.EOF {
+			return sb.String(), nil // the parser reports the unfinished datum
+		}
+		if err != nil {
+			return "", err
+		}
+		sb.WriteRune(r)
+
+		complete := true
+		switch {
+		case unicode.IsSpace(r):
+			continue
+		case r == ';':
+			err = readWhile(func(r rune) bool { return r != '\n' })
+			complete = false // a comment isn't a datum
+		case r == '\'':
+			complete = false // the quoted datum follows
+		case r == '(':
+			depth++
+			complete = false
+		case r == ')':
+			depth-- // an unexpected one at the top is reported by the parser
+		case r == '"':
+			escaped := false
+			err = readWhile(func(r rune) bool {
+				prev := escaped
+				escaped = !prev && r == '\\'
+				return prev || r != '"'
+			})
+			if err == nil {
+				err = readRune(port, &sb)
+			}
+		case r == '#':
+			if next, _ := port.PeekChar(); next == '\\' {
+				port.ReadChar()
+				sb.WriteRune('\\')
+				if err = readRune(port, &sb); err == nil {
+					err = readWhile(func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) })
+				}
+				break
+			}
+
+			if next, _ := port.PeekChar(); next == ';' {
+				port.ReadChar()
+				sb.WriteRune(';')
+				if depth == 0 {
+					skipped++
+				}
+				complete = false
+				break
+			}
+
+			if next, _ := port.PeekChar(); next == '|' {
+				err = readBlockComment(port, &sb)
+				complete = false
+				break
+			}
+
+			// a vector, a hash table or a reader syntax has
+			// its datum after its name, e.g. #date"2024-01-01"
+			err = readWhile(func(r rune) bool { return !unicode.IsSpace(r) && !strings.ContainsRune(`)"(;`, r) })
+			if next, _ := port.PeekChar(); next == '(' || next == '"' {
+				complete = false
+			}
+		default:
+			// the parts of an identifier surrounded by `|` can have
+			// any characters, inside of them `\` escapes the next one
+			inPipes, escaped := r == '|', false
+			err = readWhile(func(r rune) bool {
+				switch {
+				case escaped:
+					escaped = false
+				case inPipes && r == '\\':
+					escaped = true
+				case r == '|':
+					inPipes = !inPipes
+				case !inPipes:
+					return !unicode.IsSpace(r) && r != ')' && r != ';'
+				}
+				return true
+			})
+		}
+
+		if err == io.EOF {
+			return sb.String(), nil
+		}
+		if err != nil {
+			return "", err
+		}
+
+		if complete && depth <= 0 && skipped > 0 {
+			skipped--
+		} else if complete && depth <= 0 {
+			return sb.String(), nil
+		}
+	}
+}
+
+// reads a block comment after its `#` with the comments nested in it
+func readBlockComment(port *p.Port, sb *strings.Builder) error {
+	depth := 0
+	prev := '#'
+	for {
+		r, err := port.ReadChar()
+		if err != nil {
+			return err
+		}
+		sb.WriteRune(r)
+
+		switch {
+		case prev == '#' && r == '|':
+			depth++
+			r = 0 // the `|` can't also close the comment
+		case prev == '|' && r == '#':
+			depth--
+			r = 0
+		}
+
+		if depth == 0 {
+			return nil
+		}
+		prev = r
+	}
+}
+
+// reads the next character of the port into the builder
+func readRune(port *p.Port, sb *strings.Builder) error {
+	r, err := port.ReadChar()
+	if err == nil {
+		sb.WriteRune(r)
+	}
+
+	return err
+}
+
+// reads a character using the given read function
+// and converts the result to a scheme expression
+func portRead(procName string, read func() (rune, error)) (ex p.Expression, err *p.Error) {
+	r, ioerr := read()
+	switch ioerr {
+	case nil:
+		return &p.Char{Val: r}, nil
+	case io.EOF:
+		return &p.EOFObject, nil
+	case p.ErrTimeout:
+		return &p.False, nil
+	}
+
+	return &p.Void, &p.Error{Val: procName + ": " + ioerr.Error()}
+}
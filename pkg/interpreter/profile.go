@@ -0,0 +1,118 @@
+package interpreter
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	p "github.com/dimbata23/golang-scheme-interpreter/pkg/parser"
+)
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------------- Inner structure ---------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// profiling information about a single procedure
+type profileEntry struct {
+	name   string        // name of the procedure
+	calls  int           // number of times the procedure was called
+	time   time.Duration // time spent inside the procedure, including callees
+	active int           // number of unfinished calls of the procedure
+	start  time.Time     // when the outermost unfinished call started
+}
+
+/// ------------------------------------------------------------------------ ///
+/// ------------------------ Profile procedure methods --------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// (profile <thunk>)
+// calls the thunk printing how many times each procedure was called
+// during its execution and how much time was spent inside of it
+// returns the result of the thunk
+func (i *Interpreter) procProfile(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen != 1 {
+		return &p.Void, newError(errArityMismatch, "profile", "1", strconv.Itoa(argsLen))
+	}
+
+	thunk := args.Lst[0]
+	if !isCallable(thunk) {
+		return &p.Void, newError(errContractViolation, "profile", "procedure?", errString(thunk))
+	}
+
+	entries := make(map[string]*profileEntry)
+	hook := func(proc p.Expression, _ *p.ExprList) func() {
+		name := procName(proc)
+		entry, isFound := entries[name]
+		if !isFound {
+			entry = &profileEntry{name: name}
+			entries[name] = entry
+		}
+
+		entry.calls++
+		if entry.active == 0 {
+			entry.start = time.Now()
+		}
+		entry.active++
+
+		return func() {
+			entry.active--
+			if entry.active == 0 {
+				entry.time += time.Since(entry.start)
+			}
+		}
+	}
+
+	i.callHooks = append(i.callHooks, hook)
+	start := time.Now()
+	ex, err = i.genv.apply(thunk, &p.ExprList{})
+	total := time.Since(start)
+	i.callHooks = i.callHooks[:len(i.callHooks)-1]
+
+	i.printProfile(entries, total)
+
+	return ex, err
+}
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------------- Helper functions --------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// prints the collected profile entries sorted by the time spent in them
+func (i *Interpreter) printProfile(entries map[string]*profileEntry, total time.Duration) {
+	sorted := make([]*profileEntry, 0, len(entries))
+	calls := 0
+	for _, entry := range entries {
+		sorted = append(sorted, entry)
+		calls += entry.calls
+	}
+
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].time != sorted[j].time {
+			return sorted[i].time > sorted[j].time
+		}
+		return sorted[i].name < sorted[j].name
+	})
+
+	i.println(fmt.Sprintf("profile: %d calls in %v", calls, total))
+	i.println(fmt.Sprintf("  %8s  %12s  %s", "calls", "time", "procedure"))
+	for _, entry := range sorted {
+		i.println(fmt.Sprintf("  %8d  %12v  %s", entry.calls, entry.time, entry.name))
+	}
+}
+
+// returns the name of the given procedure or lambda
+func procName(proc p.Expression) string {
+	switch pr := proc.(type) {
+	case *p.Procedure:
+		if len(pr.Name) != 0 {
+			return pr.Name
+		}
+		return "procedure"
+	case *p.Lambda:
+		return lambdaName(pr)
+	}
+
+	return proc.String(0)
+}
@@ -0,0 +1,115 @@
+package interpreter
+
+import (
+	"os"
+	"path/filepath"
+
+	p "github.com/dimbata23/golang-scheme-interpreter/pkg/parser"
+)
+
+// file a program given as a directory starts from
+const MainFile = "main.scm"
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------- Public functions and methods --------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// runs the program at the path, a directory with a main.scm or a single file,
+// without printing the results of its top-level expressions, the program can
+// import the other files of its directory as libraries, e.g. (import utils)
+// for utils.scm, or load them with relative paths
+// if the program defines a main procedure it is called with the command-line
+// arguments as a list of strings, the first error stops the program
+// returns the code the program asked to exit with, exit can be called
+// from anywhere in the program
+func (i *Interpreter) Run(path string, args []string) (code int, err *p.Error) {
+	entry, dir, err := programEntry(path)
+	if err != nil {
+		return 1, err
+	}
+
+	file, ioerr := os.Open(entry)
+	if ioerr != nil {
+		return 1, newError(errCouldntLoadFile, ioerr.Error())
+	}
+	defer file.Close()
+
+	i.SetDirectory(dir)
+	prev := i.source
+	i.source, i.running = entry, true
+	defer func() { i.source, i.running = prev, false }()
+
+	par := i.newParserFromReader(file)
+	for {
+		expr, err := par.Next()
+		if expr == nil {
+			break // parser has finished
+		}
+
+		form := expr
+		if err == nil {
+			i.coverForm(expr)
+			_, err = i.genv.eval(expr)
+		} else {
+			form = nil
+		}
+
+		if code, isExit := i.exitCode(err); isExit {
+			return code, nil
+		}
+
+		if err != nil {
+			i.setLastError(err)
+			return 1, failedAt(err, form, par.Pos())
+		}
+	}
+
+	main, isDefined := i.genv.vars["main"]
+	if !isDefined || !isCallable(main) {
+		return 0, nil
+	}
+
+	argv := make([]p.Expression, len(args))
+	for j, arg := range args {
+		argv[j] = p.NewString(arg)
+	}
+
+	if _, err := i.genv.apply(main, &p.ExprList{Lst: []interface{ p.Expression }{p.List(argv...)}}); err != nil {
+		if code, isExit := i.exitCode(err); isExit {
+			return code, nil
+		}
+
+		i.setLastError(err)
+		return 1, err
+	}
+
+	return 0, nil
+}
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------------- Helper functions --------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// returns the file the program at the path starts from and its directory
+func programEntry(path string) (entry string, dir string, err *p.Error) {
+	info, statErr := os.Stat(path)
+	if statErr != nil {
+		return "", "", newError(errCouldntLoadFile, statErr.Error())
+	}
+
+	if !info.IsDir() {
+		entry = path
+	} else {
+		entry = filepath.Join(path, MainFile)
+		if _, statErr := os.Stat(entry); statErr != nil {
+			return "", "", &p.Error{Val: "run: the program has no " + MainFile + "\n  directory: " + path}
+		}
+	}
+
+	dir, absErr := filepath.Abs(filepath.Dir(entry))
+	if absErr != nil {
+		return "", "", newError(errCouldntLoadFile, absErr.Error())
+	}
+
+	return entry, dir, nil
+}
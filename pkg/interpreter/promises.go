@@ -0,0 +1,93 @@
+package interpreter
+
+import (
+	"strconv"
+
+	p "github.com/dimbata23/golang-scheme-interpreter/pkg/parser"
+)
+
+func init() {
+	registerSpecialForm("delay", (*environment).evalDelay)
+}
+
+/// ------------------------------------------------------------------------ ///
+/// ------------------------- Special form methods ------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// (delay <expression>)
+// returns a promise evaluating the expression in the current environment
+// when it is first forced
+func (env *environment) evalDelay(lst *p.ExprList) (ex p.Expression, err *p.Error) {
+	lstLen := len(lst.Lst)
+	if lstLen != 2 {
+		return &p.Void, newError(errBadSyntax, "delay", "exactly 1 argument", strconv.Itoa(lstLen-1))
+	}
+
+	return &p.Promise{Expr: lst.Lst[1], Env: env}, nil
+}
+
+/// ------------------------------------------------------------------------ ///
+/// ----------------------- Promise procedure methods ---------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// (force <promise>)
+// the value of the promise, which is evaluated only the first time,
+// anything else than a promise is returned as it is
+func procForce(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen != 1 {
+		return &p.Void, newError(errArityMismatch, "force", "1", strconv.Itoa(argsLen))
+	}
+
+	promise, isPromise := args.Lst[0].(*p.Promise)
+	if !isPromise {
+		return args.Lst[0], nil
+	}
+
+	if promise.Forced {
+		return promise.Val, nil
+	}
+
+	val, err := promise.Env.(*environment).eval(promise.Expr)
+	if err != nil {
+		return &p.Void, err
+	}
+
+	// the expression may have forced the promise itself, its value is kept
+	if !promise.Forced {
+		promise.Val, promise.Forced = val, true
+		promise.Expr, promise.Env = nil, nil
+	}
+
+	return promise.Val, nil
+}
+
+// (make-promise <value>)
+// returns a promise which is already forced to the value,
+// a promise is returned as it is
+func procMakePromise(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen != 1 {
+		return &p.Void, newError(errArityMismatch, "make-promise", "1", strconv.Itoa(argsLen))
+	}
+
+	if promise, isPromise := args.Lst[0].(*p.Promise); isPromise {
+		return promise, nil
+	}
+
+	return &p.Promise{Val: args.Lst[0], Forced: true}, nil
+}
+
+// (promise? <expression>)
+func procIsPromise(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen != 1 {
+		return &p.Void, newError(errArityMismatch, "promise?", "1", strconv.Itoa(argsLen))
+	}
+
+	if _, isPromise := args.Lst[0].(*p.Promise); isPromise {
+		return &p.True, nil
+	}
+
+	return &p.False, nil
+}
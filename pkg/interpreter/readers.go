@@ -0,0 +1,54 @@
+package interpreter
+
+import (
+	"io"
+
+	p "github.com/dimbata23/golang-scheme-interpreter/pkg/parser"
+)
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------- Public functions and methods --------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// registers the handler of the `#<name>` reader syntax for all the input
+// the interpreter reads, e.g. "re" for #re"pattern" the handler is given
+// the string and returns the value it stands for, nil removes the syntax
+func (i *Interpreter) SetReaderHandler(name string, handler p.ReaderHandler) {
+	if handler == nil {
+		delete(i.readers, name)
+		return
+	}
+
+	if i.readers == nil {
+		i.readers = map[string]p.ReaderHandler{}
+	}
+	i.readers[name] = handler
+}
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------------- Helper functions --------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// creates a parser of the input knowing the custom reader syntax
+func (i *Interpreter) newParser(input string) *p.Parser {
+	return i.withReaders(p.NewParser(input))
+}
+
+// creates a parser of the input of the reader knowing the custom reader syntax
+func (i *Interpreter) newParserFromReader(r io.Reader) *p.Parser {
+	return i.withReaders(p.NewParserFromReader(r))
+}
+
+// registers the handlers of the custom reader syntax with the parser,
+// which interns its keywords and symbols in the tables of the interpreter
+// and has the limits of the interpreter
+func (i *Interpreter) withReaders(par *p.Parser) *p.Parser {
+	par.SetKeywordTable(i.keywords)
+	par.SetSymbolTable(i.symbols)
+	par.SetLimits(i.parserLimits)
+	for name, handler := range i.readers {
+		par.SetReaderHandler(name, handler)
+	}
+
+	return par
+}
@@ -0,0 +1,210 @@
+package interpreter
+
+import (
+	"strconv"
+	"strings"
+
+	p "github.com/dimbata23/golang-scheme-interpreter/pkg/parser"
+)
+
+func init() {
+	registerSpecialForm("define-record-type", (*environment).evalDefineRecordType)
+}
+
+/// ------------------------------------------------------------------------ ///
+/// ------------------------- Special form methods ------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// (define-record-type <name> (<constructor> [fields...]) <predicate> (<field> <accessor> [modifier]) ...)
+// defines the record type, a constructor taking the values of the fields it
+// names, the other fields are void, a predicate and the accessors and the
+// modifiers of the fields, e.g.
+// (define-record-type point (make-point x y) point? (x point-x) (y point-y set-point-y!))
+func (env *environment) evalDefineRecordType(lst *p.ExprList) (ex p.Expression, err *p.Error) {
+	lstLen := len(lst.Lst)
+	if lstLen < 4 {
+		return &p.Void, newError(errBadSyntax, "define-record-type", "at least 3 arguments", strconv.Itoa(lstLen-1))
+	}
+
+	typeName, err := recordIdentifier(lst.Lst[1])
+	if err != nil {
+		return &p.Void, err
+	}
+
+	ctorSpec, isLst := lst.Lst[2].(*p.ExprList)
+	if !isLst || ctorSpec.Qlevel > 0 || len(ctorSpec.Lst) == 0 {
+		return &p.Void, newError(errBadSyntax, "define-record-type", "(<constructor> [fields...])", p.CodeString(lst.Lst[2]))
+	}
+
+	predName, err := recordIdentifier(lst.Lst[3])
+	if err != nil {
+		return &p.Void, err
+	}
+
+	// the fields in the order of their specs
+	rt := &p.RecordType{Name: strings.TrimSuffix(strings.TrimPrefix(typeName, "<"), ">")}
+	specs := make([][]string, 0, lstLen-4)
+	fields := map[string]int{}
+	for _, ex := range lst.Lst[4:] {
+		spec, isLst := ex.(*p.ExprList)
+		if !isLst || spec.Qlevel > 0 || len(spec.Lst) < 2 || len(spec.Lst) > 3 {
+			return &p.Void, newError(errBadSyntax, "define-record-type", "(<field> <accessor> [modifier]) as a field", p.CodeString(ex))
+		}
+
+		names := make([]string, len(spec.Lst))
+		for j, item := range spec.Lst {
+			if names[j], err = recordIdentifier(item); err != nil {
+				return &p.Void, err
+			}
+		}
+
+		if _, isDup := fields[names[0]]; isDup {
+			return &p.Void, newError(errBadSyntax, "define-record-type", "distinct field names", names[0])
+		}
+
+		fields[names[0]] = len(rt.Fields)
+		rt.Fields = append(rt.Fields, names[0])
+		specs = append(specs, names)
+	}
+
+	ctorName, err := recordIdentifier(ctorSpec.Lst[0])
+	if err != nil {
+		return &p.Void, err
+	}
+
+	ctorFields := make([]int, len(ctorSpec.Lst)-1)
+	for j, item := range ctorSpec.Lst[1:] {
+		name, err := recordIdentifier(item)
+		if err != nil {
+			return &p.Void, err
+		}
+
+		idx, isField := fields[name]
+		if !isField {
+			return &p.Void, newError(errBadSyntax, "define-record-type", "a field of the record type in the constructor", name)
+		}
+		ctorFields[j] = idx
+	}
+
+	names := []string{typeName, ctorName, predName}
+	vals := []p.Expression{rt, recordConstructor(rt, ctorName, ctorFields), recordPredicate(rt, predName)}
+	for _, spec := range specs {
+		idx := fields[spec[0]]
+		names, vals = append(names, spec[1]), append(vals, recordAccessor(rt, spec[1], predName, idx))
+		if len(spec) == 3 {
+			names, vals = append(names, spec[2]), append(vals, recordModifier(rt, spec[2], predName, idx))
+		}
+	}
+
+	for _, name := range names {
+		if err := env.checkRedefinition("define-record-type", name); err != nil {
+			return &p.Void, err
+		}
+	}
+
+	for j, name := range names {
+		env.vars[name] = vals[j]
+		if env.parent == nil && lst.Pos.Line != 0 {
+			env.interp.defined(name, lst.Pos)
+		}
+	}
+
+	return &p.Void, nil
+}
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------------- Helper functions --------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// returns the procedure creating a record from the values of the fields
+// at the given indices
+func recordConstructor(rt *p.RecordType, name string, fields []int) *p.Procedure {
+	return &p.Procedure{Name: name, Fn: func(args *p.ExprList) (ex p.Expression, err *p.Error) {
+		argsLen := len(args.Lst)
+		if argsLen != len(fields) {
+			return &p.Void, newError(errArityMismatch, name, strconv.Itoa(len(fields)), strconv.Itoa(argsLen))
+		}
+
+		rec := &p.Record{Type: rt, Vals: make([]p.Expression, len(rt.Fields))}
+		for j := range rec.Vals {
+			rec.Vals[j] = &p.Void
+		}
+
+		for j, idx := range fields {
+			rec.Vals[idx] = args.Lst[j]
+		}
+
+		return rec, nil
+	}}
+}
+
+// returns the procedure testing whether its argument is a record of the type
+func recordPredicate(rt *p.RecordType, name string) *p.Procedure {
+	return &p.Procedure{Name: name, Fn: func(args *p.ExprList) (ex p.Expression, err *p.Error) {
+		argsLen := len(args.Lst)
+		if argsLen != 1 {
+			return &p.Void, newError(errArityMismatch, name, "1", strconv.Itoa(argsLen))
+		}
+
+		if rec, isRec := args.Lst[0].(*p.Record); isRec && rec.Type == rt {
+			return &p.True, nil
+		}
+
+		return &p.False, nil
+	}}
+}
+
+// returns the procedure returning the value of the field at the index
+func recordAccessor(rt *p.RecordType, name string, predName string, idx int) *p.Procedure {
+	return &p.Procedure{Name: name, Fn: func(args *p.ExprList) (ex p.Expression, err *p.Error) {
+		argsLen := len(args.Lst)
+		if argsLen != 1 {
+			return &p.Void, newError(errArityMismatch, name, "1", strconv.Itoa(argsLen))
+		}
+
+		rec, err := toRecord(name, predName, rt, args.Lst[0])
+		if err != nil {
+			return &p.Void, err
+		}
+
+		return rec.Vals[idx], nil
+	}}
+}
+
+// returns the procedure setting the value of the field at the index
+func recordModifier(rt *p.RecordType, name string, predName string, idx int) *p.Procedure {
+	return &p.Procedure{Name: name, Fn: func(args *p.ExprList) (ex p.Expression, err *p.Error) {
+		argsLen := len(args.Lst)
+		if argsLen != 2 {
+			return &p.Void, newError(errArityMismatch, name, "2", strconv.Itoa(argsLen))
+		}
+
+		rec, err := toRecord(name, predName, rt, args.Lst[0])
+		if err != nil {
+			return &p.Void, err
+		}
+
+		rec.Vals[idx] = args.Lst[1]
+		return &p.Void, nil
+	}}
+}
+
+// returns the argument as a record of the type or an error
+func toRecord(procName string, predName string, rt *p.RecordType, arg p.Expression) (rec *p.Record, err *p.Error) {
+	rec, isRec := arg.(*p.Record)
+	if !isRec || rec.Type != rt {
+		return nil, newError(errContractViolation, procName, predName, errString(arg))
+	}
+
+	return rec, nil
+}
+
+// returns the name of an identifier of a record type definition or an error
+func recordIdentifier(expr p.Expression) (name string, err *p.Error) {
+	v, isVar := expr.(*p.Variable)
+	if !isVar {
+		return "", newError(errBadSyntax, "define-record-type", "an identifier", p.CodeString(expr))
+	}
+
+	return v.Val, nil
+}
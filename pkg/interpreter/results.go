@@ -0,0 +1,85 @@
+package interpreter
+
+import (
+	"github.com/dimbata23/golang-scheme-interpreter/pkg/lexer"
+	p "github.com/dimbata23/golang-scheme-interpreter/pkg/parser"
+)
+
+/// ------------------------------------------------------------------------ ///
+/// -------------------------- Public definitions -------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// result of a top-level expression together with the text it was parsed
+// from, e.g. for a notebook to pair every input with its output
+type Result struct {
+	Source string         // text of the expression in the input
+	Pos    lexer.Position // where the expression starts in the input
+	Value  p.Expression   // value of the expression, nil if it failed
+	Err    *p.Error       // error of the expression, nil if it succeeded
+}
+
+// hook called with the result of every interpreted top-level expression
+type ResultHook func(res Result)
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------- Public functions and methods --------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// sets a hook called with the result of every top-level expression
+// interpreted by Interpret and InterpretWith before the result is printed,
+// nil removes the hook
+func (i *Interpreter) OnResult(hook ResultHook) {
+	i.onResult = hook
+}
+
+// evaluates the expressions of the given string without printing their
+// results, returns the result of each expression in the order of the input
+// the evaluation stops after an (exit)
+func (i *Interpreter) Eval(input string) []Result {
+	par := i.newParser(input)
+	results := []Result{}
+
+	for {
+		expr, err := par.Next()
+		if expr == nil {
+			return results
+		}
+
+		if err == nil {
+			i.coverForm(expr)
+			expr, err = i.genv.eval(expr)
+		}
+
+		if _, isExit := i.exitCode(err); isExit {
+			return results
+		}
+
+		if err != nil {
+			i.setLastError(err)
+		}
+
+		results = append(results, makeResult(par, expr, err))
+	}
+}
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------------- Helper functions --------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// calls the result hook, if any, with the result of the expression
+// last parsed by the parser
+func (i *Interpreter) reportResult(par *p.Parser, val p.Expression, err *p.Error) {
+	if i.onResult != nil {
+		i.onResult(makeResult(par, val, err))
+	}
+}
+
+// returns the result of the expression last parsed by the parser
+func makeResult(par *p.Parser, val p.Expression, err *p.Error) Result {
+	res := Result{Source: par.Text(), Pos: par.Pos(), Value: val, Err: err}
+	if err != nil {
+		res.Value = nil
+	}
+
+	return res
+}
@@ -0,0 +1,46 @@
+package interpreter
+
+import (
+	"io"
+
+	p "github.com/dimbata23/golang-scheme-interpreter/pkg/parser"
+)
+
+// the special forms reaching outside of the interpreter
+var unsafeForms = []string{"load", "import"}
+
+// the builtins reaching outside of the interpreter, to the file system
+// or the process
+var unsafeBuiltins = []string{
+	"open-input-file",
+	"open-output-file",
+	"with-input-from-file",
+	"with-output-to-file",
+	"current-directory",
+	"exit",
+}
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------- Public functions and methods --------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// takes away the capabilities of the interpreter reaching outside of it,
+// for running untrusted code: the file system with load, import and the
+// file ports, exit and the console, the current input port becomes an empty
+// port and the output is discarded unless ports are set with SetInputPort
+// and SetOutputPort afterwards
+func (i *Interpreter) Sandbox() {
+	for _, name := range unsafeForms {
+		delete(i.forms, name)
+	}
+
+	for _, name := range unsafeBuiltins {
+		delete(i.genv.vars, name)
+		delete(i.registry, name)
+		delete(i.builtins, name)
+	}
+
+	i.inputPort = p.NewStringInputPort("")
+	i.inputPort.Name = "sandbox"
+	i.outputPort = p.NewOutputPort("sandbox", io.Discard)
+}
@@ -0,0 +1,137 @@
+package interpreter
+
+import (
+	"strconv"
+
+	p "github.com/dimbata23/golang-scheme-interpreter/pkg/parser"
+)
+
+// request of exit to end the program, the evaluation is unwound to the
+// top level by propagating the error like any other error
+type exitRequest struct {
+	err  *p.Error // the error being propagated
+	code int      // the code to exit with
+}
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------- Public functions and methods --------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// registers a function releasing a resource of the host, e.g. one opened
+// by an extension, which is called when the interpreter is closed
+func (i *Interpreter) OnClose(cleanup func()) {
+	i.cleanups = append(i.cleanups, func() *p.Error {
+		cleanup()
+		return nil
+	})
+}
+
+// shuts the interpreter down, the thunks registered with on-exit and the
+// functions registered with OnClose are called, the latest first, then the
+// file ports still open are closed and the suspended generators are stopped,
+// returns the errors of the thunks and of closing the ports
+// closing an interpreter again does nothing, it shouldn't be used after
+func (i *Interpreter) Close() (errs []*p.Error) {
+	if i.closed {
+		return nil
+	}
+	i.closed = true
+
+	for j := len(i.cleanups) - 1; j >= 0; j-- {
+		if err := i.cleanups[j](); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	i.cleanups = nil
+
+	for port := range i.openPorts {
+		if err := i.closePort("close-port", port); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	for gen := range i.suspended {
+		gen.stop()
+	}
+	i.suspended = nil
+
+	if i.stdin != nil {
+		i.stdin.Close() // stops reading ahead, the standard input stays open
+	}
+
+	return errs
+}
+
+/// ------------------------------------------------------------------------ ///
+/// ---------------------- Shutdown procedure methods ---------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// (exit [code])
+// ends the program with the code, 0 if it isn't given, #t is 0 and #f is 1
+// the evaluation is unwound to the top level from wherever exit is called
+func (i *Interpreter) procExit(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen > 1 {
+		return &p.Void, newError(errArityMismatch, "exit", "0 or 1", strconv.Itoa(argsLen))
+	}
+
+	code := 0
+	if argsLen == 1 {
+		switch arg := args.Lst[0].(type) {
+		case *p.Boolean:
+			if !arg.Val {
+				code = 1
+			}
+
+		case *p.Number:
+			if arg.Inexact || arg.Exact != nil || arg.Ratio != nil {
+				return &p.Void, newError(errContractViolation, "exit", "(or/c exact-integer? boolean?)", errString(arg))
+			}
+			code = int(arg.Val)
+
+		default:
+			return &p.Void, newError(errContractViolation, "exit", "(or/c exact-integer? boolean?)", errString(arg))
+		}
+	}
+
+	err = &p.Error{Val: "exit: the program exited with code " + strconv.Itoa(code)}
+	i.exiting = &exitRequest{err: err, code: code}
+	return &p.Void, err
+}
+
+// (on-exit <thunk>)
+// the thunk is called when the interpreter is closed, e.g. when the REPL
+// exits, after the ones registered later
+func (i *Interpreter) procOnExit(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen != 1 {
+		return &p.Void, newError(errArityMismatch, "on-exit", "1", strconv.Itoa(argsLen))
+	}
+
+	thunk := args.Lst[0]
+	if !isCallable(thunk) {
+		return &p.Void, newError(errContractViolation, "on-exit", "procedure?", errString(thunk))
+	}
+
+	i.cleanups = append(i.cleanups, func() *p.Error {
+		_, err := i.genv.apply(thunk, &p.ExprList{})
+		return err
+	})
+
+	return &p.Void, nil
+}
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------------- Helper functions --------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// returns the code the program exits with if the error is propagated by
+// exit, the exit is done once it is known
+func (i *Interpreter) exitCode(err *p.Error) (code int, isExit bool) {
+	if err == nil || i.exiting == nil || i.exiting.err != err {
+		return 0, false
+	}
+
+	code, i.exiting = i.exiting.code, nil
+	return code, true
+}
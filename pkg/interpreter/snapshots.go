@@ -0,0 +1,65 @@
+package interpreter
+
+import (
+	p "github.com/dimbata23/golang-scheme-interpreter/pkg/parser"
+)
+
+/// ------------------------------------------------------------------------ ///
+/// -------------------------- Public definitions -------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// copy of the global bindings of an interpreter taken by Snapshot,
+// the bound values are shared with the interpreter so changes made
+// inside of them, e.g. by vector-set!, aren't undone by restoring it
+type Snapshot struct {
+	vars        map[string]p.Expression // the global bindings
+	definitions map[string]Location     // where the global bindings were defined
+}
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------- Public functions and methods --------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// returns a snapshot of the global environment, e.g. for the REPL to undo
+// the definitions and assignments made after it
+func (i *Interpreter) Snapshot() *Snapshot {
+	snap := &Snapshot{
+		vars:        make(map[string]p.Expression, len(i.genv.vars)),
+		definitions: make(map[string]Location, len(i.definitions)),
+	}
+
+	for name, val := range i.genv.vars {
+		snap.vars[name] = val
+	}
+
+	for name, loc := range i.definitions {
+		snap.definitions[name] = loc
+	}
+
+	return snap
+}
+
+// restores the global environment to the snapshot, bindings made after it
+// are removed and the ones changed after it get their old values back,
+// the snapshot can be restored again
+func (i *Interpreter) Restore(snap *Snapshot) {
+	for name := range i.genv.vars {
+		if _, isKept := snap.vars[name]; !isKept {
+			delete(i.genv.vars, name)
+		}
+	}
+
+	for name, val := range snap.vars {
+		i.genv.vars[name] = val
+	}
+
+	i.definitions = make(map[string]Location, len(snap.definitions))
+	for name, loc := range snap.definitions {
+		i.definitions[name] = loc
+	}
+}
+
+// returns the number of global bindings in the snapshot
+func (snap *Snapshot) Len() int {
+	return len(snap.vars)
+}
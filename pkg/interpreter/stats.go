@@ -0,0 +1,56 @@
+package interpreter
+
+import (
+	"runtime"
+	"strconv"
+
+	p "github.com/dimbata23/golang-scheme-interpreter/pkg/parser"
+)
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------------- Inner structure ---------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// counters of the work done by an interpreter
+type runtimeStats struct {
+	steps        int // number of evaluated expressions
+	environments int // number of created environments
+	conses       int // number of pairs allocated by cons and list
+}
+
+/// ------------------------------------------------------------------------ ///
+/// ----------------------- Statistics procedure methods ------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// (runtime-statistics)
+// returns an association list of the evaluator counters
+// and the memory statistics of the go runtime
+func (i *Interpreter) procRuntimeStatistics(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen != 0 {
+		return &p.Void, newError(errArityMismatch, "runtime-statistics", "0", strconv.Itoa(argsLen))
+	}
+
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+
+	return p.List(
+		statsEntry("steps", float64(i.stats.steps)),
+		statsEntry("environments", float64(i.stats.environments)),
+		statsEntry("cons-cells", float64(i.stats.conses)),
+		statsEntry("heap-alloc-bytes", float64(mem.HeapAlloc)),
+		statsEntry("heap-objects", float64(mem.HeapObjects)),
+		statsEntry("total-alloc-bytes", float64(mem.TotalAlloc)),
+		statsEntry("gc-cycles", float64(mem.NumGC)),
+		statsEntry("gc-pause-total-ns", float64(mem.PauseTotalNs)),
+	), nil
+}
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------------- Helper functions --------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// returns the pair (<name> . <value>)
+func statsEntry(name string, val float64) p.Expression {
+	return p.Cons(p.NewSymbol(name), p.NewNumber(val))
+}
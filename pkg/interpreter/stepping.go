@@ -0,0 +1,144 @@
+package interpreter
+
+import (
+	p "github.com/dimbata23/golang-scheme-interpreter/pkg/parser"
+)
+
+/// ------------------------------------------------------------------------ ///
+/// -------------------------- Public definitions -------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// evaluation of scheme source advancing a bounded number of steps at a time,
+// e.g. for a teaching UI to animate it one reduction at a time
+// a step is the evaluation of a single expression, the evaluation runs on
+// its own goroutine which is paused between the calls of Step
+// note: a stepper that is abandoned before finishing keeps its goroutine
+// blocked until the program exits unless it is closed
+type Stepper struct {
+	interp  *Interpreter
+	src     string
+	resume  chan struct{}     // continues the evaluation after a pause
+	pauses  chan p.Expression // expressions the evaluation paused before, nil once done
+	started bool              // whether the evaluation has been started
+	done    bool              // whether the evaluation has finished
+	closed  bool              // whether the evaluation is being stopped
+	budget  int               // number of steps left before the next pause
+	steps   int               // number of steps taken so far
+	current p.Expression      // the expression the evaluation paused before
+	result  p.Expression      // value of the last expression once done
+	err     *p.Error          // error which stopped the evaluation
+	marks   *markFrame        // continuation marks of the evaluation when it paused
+}
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------- Public functions and methods --------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// prepares the stepwise evaluation of the given source in the global
+// environment, nothing is evaluated until the first call of Step
+func (i *Interpreter) NewStepper(input string) *Stepper {
+	return &Stepper{
+		interp: i,
+		src:    input,
+		resume: make(chan struct{}),
+		pauses: make(chan p.Expression),
+		result: &p.Void,
+	}
+}
+
+// evaluates at most n more steps, pausing before the next one
+// returns whether the evaluation has finished
+func (s *Stepper) Step(n int) (done bool) {
+	if s.done || n <= 0 {
+		return s.done
+	}
+
+	s.budget = n
+	s.run()
+	return s.done
+}
+
+// returns the expression which is evaluated by the next step,
+// nil before the first step and once the evaluation has finished
+func (s *Stepper) Current() p.Expression {
+	return s.current
+}
+
+// returns the number of steps taken so far
+func (s *Stepper) Steps() int {
+	return s.steps
+}
+
+// returns whether the evaluation has finished
+func (s *Stepper) Done() bool {
+	return s.done
+}
+
+// returns the value of the last expression of the source or the error
+// which stopped the evaluation, void until the evaluation has finished
+func (s *Stepper) Result() (ex p.Expression, err *p.Error) {
+	return s.result, s.err
+}
+
+// stops an unfinished evaluation, its result becomes a cancellation error
+func (s *Stepper) Close() {
+	if s.done {
+		return
+	}
+
+	if !s.started {
+		s.done = true
+		s.err = newError(errCancelled, "stepper closed")
+		return
+	}
+
+	s.closed = true
+	s.run()
+}
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------------- Helper functions --------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// continues the evaluation until it pauses or finishes
+// the evaluation keeps its own continuation marks between the steps
+func (s *Stepper) run() {
+	interp := s.interp
+	outer, marks := interp.stepper, interp.marks
+	interp.stepper = s
+	if s.started {
+		interp.marks = s.marks
+		s.resume <- struct{}{}
+	} else {
+		s.started = true
+		go s.evaluate()
+	}
+
+	s.current = <-s.pauses
+	s.marks = interp.marks
+	interp.stepper, interp.marks = outer, marks
+	s.done = s.current == nil
+}
+
+// evaluates the source reporting when it finishes
+func (s *Stepper) evaluate() {
+	s.result, s.err = s.interp.genv.evalSource(s.src)
+	s.pauses <- nil
+}
+
+// called before every step of the evaluation
+// pauses once the steps given to Step are taken
+func (s *Stepper) step(expr p.Expression) (err *p.Error) {
+	if s.budget == 0 && !s.closed {
+		s.pauses <- expr
+		<-s.resume
+	}
+
+	if s.closed {
+		return newError(errCancelled, "stepper closed")
+	}
+
+	s.budget--
+	s.steps++
+	return nil
+}
@@ -0,0 +1,550 @@
+package interpreter
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	p "github.com/dimbata23/golang-scheme-interpreter/pkg/parser"
+	"golang.org/x/text/unicode/norm"
+)
+
+/// ------------------------------------------------------------------------ ///
+/// ----------------------- String procedure methods ----------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// (make-string <length> [char])
+func procMakeString(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen < 1 || argsLen > 2 {
+		return &p.Void, newError(errArityMismatch, "make-string", "1 or 2", strconv.Itoa(argsLen))
+	}
+
+	length, err := toIndex("make-string", args.Lst[0])
+	if err != nil {
+		return &p.Void, err
+	}
+
+	fill := ' '
+	if argsLen == 2 {
+		ch, isChar := args.Lst[1].(*p.Char)
+		if !isChar {
+			return &p.Void, newError(errContractViolation, "make-string", "char?", errString(args.Lst[1]))
+		}
+		fill = ch.Val
+	}
+
+	res := &p.String{Val: make([]rune, length)}
+	for i := range res.Val {
+		res.Val[i] = fill
+	}
+
+	return res, nil
+}
+
+// (string? <expression>)
+func procIsString(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen != 1 {
+		return &p.Void, newError(errArityMismatch, "string?", "1", strconv.Itoa(argsLen))
+	}
+
+	if _, isStr := args.Lst[0].(*p.String); isStr {
+		return &p.True, nil
+	}
+
+	return &p.False, nil
+}
+
+// (string-length <string>)
+// the length is the number of characters, not bytes
+func procStringLength(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen != 1 {
+		return &p.Void, newError(errArityMismatch, "string-length", "1", strconv.Itoa(argsLen))
+	}
+
+	str, isStr := args.Lst[0].(*p.String)
+	if !isStr {
+		return &p.Void, newError(errContractViolation, "string-length", "string?", errString(args.Lst[0]))
+	}
+
+	return p.NewNumber(float64(len(str.Val))), nil
+}
+
+// (string-ref <string> <index>)
+func procStringRef(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen != 2 {
+		return &p.Void, newError(errArityMismatch, "string-ref", "2", strconv.Itoa(argsLen))
+	}
+
+	str, isStr := args.Lst[0].(*p.String)
+	if !isStr {
+		return &p.Void, newError(errContractViolation, "string-ref", "string?", errString(args.Lst[0]))
+	}
+
+	idx, err := toIndex("string-ref", args.Lst[1])
+	if err != nil {
+		return &p.Void, err
+	}
+
+	if idx >= len(str.Val) {
+		return &p.Void, newError(errIndexOutOfRange, "string-ref", indexRange(len(str.Val)), strconv.Itoa(idx))
+	}
+
+	return &p.Char{Val: str.Val[idx]}, nil
+}
+
+// (string-append [strings...])
+// always returns a new mutable string
+func procStringAppend(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	res := &p.String{Val: []rune{}}
+	for _, arg := range args.Lst {
+		str, isStr := arg.(*p.String)
+		if !isStr {
+			return &p.Void, newError(errContractViolation, "string-append", "string?", errString(arg))
+		}
+		res.Val = append(res.Val, str.Val...)
+	}
+
+	return res, nil
+}
+
+// (substring <string> <start> [end])
+func procSubstring(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen < 2 || argsLen > 3 {
+		return &p.Void, newError(errArityMismatch, "substring", "2 or 3", strconv.Itoa(argsLen))
+	}
+
+	str, isStr := args.Lst[0].(*p.String)
+	if !isStr {
+		return &p.Void, newError(errContractViolation, "substring", "string?", errString(args.Lst[0]))
+	}
+
+	start, end, err := toRange("substring", args.Lst[1:], len(str.Val))
+	if err != nil {
+		return &p.Void, err
+	}
+
+	res := &p.String{Val: make([]rune, end-start)}
+	copy(res.Val, str.Val[start:end])
+
+	return res, nil
+}
+
+// (string=? <strings...>)
+func procStringEqual(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	return procStringCompare(args, "string=?", func(cmp int) bool { return cmp == 0 })
+}
+
+// (string<? <strings...>)
+// strings are compared character by character
+func procStringLess(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	return procStringCompare(args, "string<?", func(cmp int) bool { return cmp < 0 })
+}
+
+// (string->list <string> [start] [end])
+func procStringToList(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen < 1 || argsLen > 3 {
+		return &p.Void, newError(errArityMismatch, "string->list", "1 to 3", strconv.Itoa(argsLen))
+	}
+
+	str, isStr := args.Lst[0].(*p.String)
+	if !isStr {
+		return &p.Void, newError(errContractViolation, "string->list", "string?", errString(args.Lst[0]))
+	}
+
+	start, end, err := toRange("string->list", args.Lst[1:], len(str.Val))
+	if err != nil {
+		return &p.Void, err
+	}
+
+	chars := make([]p.Expression, 0, end-start)
+	for _, r := range str.Val[start:end] {
+		chars = append(chars, &p.Char{Val: r})
+	}
+
+	return p.List(chars...), nil
+}
+
+// (list->string <list of chars>)
+func procListToString(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen != 1 {
+		return &p.Void, newError(errArityMismatch, "list->string", "1", strconv.Itoa(argsLen))
+	}
+
+	items, isList := p.AsList(args.Lst[0])
+	if !isList {
+		return &p.Void, newError(errContractViolation, "list->string", "(listof char?)", errString(args.Lst[0]))
+	}
+
+	res := &p.String{Val: make([]rune, len(items))}
+	for i, item := range items {
+		ch, isChar := item.(*p.Char)
+		if !isChar {
+			return &p.Void, newError(errContractViolation, "list->string", "char?", errString(item))
+		}
+		res.Val[i] = ch.Val
+	}
+
+	return res, nil
+}
+
+// (string-copy <string> [start] [end])
+func procStringCopy(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen < 1 || argsLen > 3 {
+		return &p.Void, newError(errArityMismatch, "string-copy", "1 to 3", strconv.Itoa(argsLen))
+	}
+
+	str, isStr := args.Lst[0].(*p.String)
+	if !isStr {
+		return &p.Void, newError(errContractViolation, "string-copy", "string?", errString(args.Lst[0]))
+	}
+
+	start, end, err := toRange("string-copy", args.Lst[1:], len(str.Val))
+	if err != nil {
+		return &p.Void, err
+	}
+
+	res := &p.String{Val: make([]rune, end-start)}
+	copy(res.Val, str.Val[start:end])
+
+	return res, nil
+}
+
+// (string-set! <string> <index> <char>)
+func procStringSet(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen != 3 {
+		return &p.Void, newError(errArityMismatch, "string-set!", "3", strconv.Itoa(argsLen))
+	}
+
+	str, err := toMutableString("string-set!", args.Lst[0])
+	if err != nil {
+		return &p.Void, err
+	}
+
+	idx, err := toIndex("string-set!", args.Lst[1])
+	if err != nil {
+		return &p.Void, err
+	}
+
+	if idx >= len(str.Val) {
+		return &p.Void, newError(errIndexOutOfRange, "string-set!", indexRange(len(str.Val)), strconv.Itoa(idx))
+	}
+
+	ch, isChar := args.Lst[2].(*p.Char)
+	if !isChar {
+		return &p.Void, newError(errContractViolation, "string-set!", "char?", errString(args.Lst[2]))
+	}
+
+	str.Val[idx] = ch.Val
+
+	return &p.Void, nil
+}
+
+// (string-fill! <string> <char> [start] [end])
+func procStringFill(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen < 2 || argsLen > 4 {
+		return &p.Void, newError(errArityMismatch, "string-fill!", "2 to 4", strconv.Itoa(argsLen))
+	}
+
+	str, err := toMutableString("string-fill!", args.Lst[0])
+	if err != nil {
+		return &p.Void, err
+	}
+
+	ch, isChar := args.Lst[1].(*p.Char)
+	if !isChar {
+		return &p.Void, newError(errContractViolation, "string-fill!", "char?", errString(args.Lst[1]))
+	}
+
+	start, end, err := toRange("string-fill!", args.Lst[2:], len(str.Val))
+	if err != nil {
+		return &p.Void, err
+	}
+
+	for i := start; i < end; i++ {
+		str.Val[i] = ch.Val
+	}
+
+	return &p.Void, nil
+}
+
+// (string-copy! <to> <at> <from> [start] [end])
+func procStringCopyTo(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen < 3 || argsLen > 5 {
+		return &p.Void, newError(errArityMismatch, "string-copy!", "3 to 5", strconv.Itoa(argsLen))
+	}
+
+	to, err := toMutableString("string-copy!", args.Lst[0])
+	if err != nil {
+		return &p.Void, err
+	}
+
+	at, err := toIndex("string-copy!", args.Lst[1])
+	if err != nil {
+		return &p.Void, err
+	}
+
+	if at > len(to.Val) {
+		return &p.Void, newError(errIndexOutOfRange, "string-copy!", indexRange(len(to.Val)+1), strconv.Itoa(at))
+	}
+
+	from, isStr := args.Lst[2].(*p.String)
+	if !isStr {
+		return &p.Void, newError(errContractViolation, "string-copy!", "string?", errString(args.Lst[2]))
+	}
+
+	start, end, err := toRange("string-copy!", args.Lst[3:], len(from.Val))
+	if err != nil {
+		return &p.Void, err
+	}
+
+	if end-start > len(to.Val)-at {
+		return &p.Void, newError(errContractViolation, "string-copy!", "not enough room in target string", strconv.Itoa(end-start))
+	}
+
+	copy(to.Val[at:], from.Val[start:end])
+
+	return &p.Void, nil
+}
+
+// (string-builder)
+func procStringBuilder(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen != 0 {
+		return &p.Void, newError(errArityMismatch, "string-builder", "0", strconv.Itoa(argsLen))
+	}
+
+	return &p.StringBuilder{}, nil
+}
+
+// (string-builder-add! <builder> [strings or chars...])
+func procStringBuilderAdd(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen < 1 {
+		return &p.Void, newError(errArityMismatch, "string-builder-add!", "at least 1", strconv.Itoa(argsLen))
+	}
+
+	sb, isSb := args.Lst[0].(*p.StringBuilder)
+	if !isSb {
+		return &p.Void, newError(errContractViolation, "string-builder-add!", "string-builder?", errString(args.Lst[0]))
+	}
+
+	for _, arg := range args.Lst[1:] {
+		switch arg := arg.(type) {
+		case *p.String:
+			sb.Builder.WriteString(string(arg.Val))
+		case *p.Char:
+			sb.Builder.WriteRune(arg.Val)
+		default:
+			return &p.Void, newError(errContractViolation, "string-builder-add!", "(or/c string? char?)", errString(arg))
+		}
+	}
+
+	return &p.Void, nil
+}
+
+// (string-builder->string <builder>)
+func procStringBuilderToString(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen != 1 {
+		return &p.Void, newError(errArityMismatch, "string-builder->string", "1", strconv.Itoa(argsLen))
+	}
+
+	sb, isSb := args.Lst[0].(*p.StringBuilder)
+	if !isSb {
+		return &p.Void, newError(errContractViolation, "string-builder->string", "string-builder?", errString(args.Lst[0]))
+	}
+
+	return &p.String{Val: []rune(sb.Builder.String())}, nil
+}
+
+// (string-upcase <string>)
+func procStringUpcase(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	return procStringConvert(args, "string-upcase", strings.ToUpper)
+}
+
+// (string-downcase <string>)
+func procStringDowncase(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	return procStringConvert(args, "string-downcase", strings.ToLower)
+}
+
+// (string-normalize-nfc <string>)
+func procStringNormalizeNFC(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	return procStringConvert(args, "string-normalize-nfc", norm.NFC.String)
+}
+
+// (string-normalize-nfd <string>)
+func procStringNormalizeNFD(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	return procStringConvert(args, "string-normalize-nfd", norm.NFD.String)
+}
+
+// (string->utf8 <string> [start] [end])
+// there are no bytevectors so the bytes are returned as a list of numbers
+func procStringToUTF8(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen < 1 || argsLen > 3 {
+		return &p.Void, newError(errArityMismatch, "string->utf8", "1 to 3", strconv.Itoa(argsLen))
+	}
+
+	str, isStr := args.Lst[0].(*p.String)
+	if !isStr {
+		return &p.Void, newError(errContractViolation, "string->utf8", "string?", errString(args.Lst[0]))
+	}
+
+	start, end, err := toRange("string->utf8", args.Lst[1:], len(str.Val))
+	if err != nil {
+		return &p.Void, err
+	}
+
+	bytes := []byte(string(str.Val[start:end]))
+	res := make([]p.Expression, len(bytes))
+	for i, b := range bytes {
+		res[i] = p.NewNumber(float64(b))
+	}
+
+	return p.List(res...), nil
+}
+
+// (utf8->string <list of bytes>)
+func procUTF8ToString(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen != 1 {
+		return &p.Void, newError(errArityMismatch, "utf8->string", "1", strconv.Itoa(argsLen))
+	}
+
+	items, isList := p.AsList(args.Lst[0])
+	if !isList {
+		return &p.Void, newError(errContractViolation, "utf8->string", "(listof byte?)", errString(args.Lst[0]))
+	}
+
+	bytes := make([]byte, len(items))
+	for i, item := range items {
+		num, isNum := p.AsNumber(item)
+		if !isNum || num < 0 || num > 255 || num != math.Trunc(num) {
+			return &p.Void, newError(errContractViolation, "utf8->string", "byte?", errString(item))
+		}
+		bytes[i] = byte(num)
+	}
+
+	if !utf8.Valid(bytes) {
+		return &p.Void, newError(errContractViolation, "utf8->string", "a valid UTF-8 encoding", errString(args.Lst[0]))
+	}
+
+	return p.NewString(string(bytes)), nil
+}
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------------- Helper functions --------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// applies the conversion to the text of the only argument returning a new string
+func procStringConvert(args *p.ExprList, procName string, convert func(string) string) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen != 1 {
+		return &p.Void, newError(errArityMismatch, procName, "1", strconv.Itoa(argsLen))
+	}
+
+	str, isStr := args.Lst[0].(*p.String)
+	if !isStr {
+		return &p.Void, newError(errContractViolation, procName, "string?", errString(args.Lst[0]))
+	}
+
+	return p.NewString(convert(string(str.Val))), nil
+}
+
+// tests whether every pair of adjacent strings satisfies the test
+// of the result of comparing them
+func procStringCompare(args *p.ExprList, procName string, test func(int) bool) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen < 1 {
+		return &p.Void, newError(errArityMismatch, procName, "at least 1", strconv.Itoa(argsLen))
+	}
+
+	strs := make([]string, argsLen)
+	for i, arg := range args.Lst {
+		str, isStr := arg.(*p.String)
+		if !isStr {
+			return &p.Void, newError(errContractViolation, procName, "string?", errString(arg))
+		}
+		strs[i] = string(str.Val)
+	}
+
+	for i := 1; i < argsLen; i++ {
+		if !test(strings.Compare(strs[i-1], strs[i])) {
+			return &p.False, nil
+		}
+	}
+
+	return &p.True, nil
+}
+
+// returns the given expression as a string that can be modified or an error
+func toMutableString(procName string, arg p.Expression) (str *p.String, err *p.Error) {
+	str, isStr := arg.(*p.String)
+	if !isStr || str.Immutable {
+		return nil, newError(errContractViolation, procName, "(and/c string? (not/c immutable?))", errString(arg))
+	}
+
+	return str, nil
+}
+
+// returns the given expression as a non-negative index or an error
+func toIndex(procName string, arg p.Expression) (idx int, err *p.Error) {
+	num, isNum := arg.(*p.Number)
+	if !isNum || num.Inexact || num.Val < 0 || num.Val != math.Trunc(num.Val) {
+		return 0, newError(errContractViolation, procName, "exact-nonnegative-integer?", errString(arg))
+	}
+
+	return int(num.Val), nil
+}
+
+// returns the range given by the optional [start] and [end] arguments
+// defaulting to the whole sequence of the given length
+func toRange(procName string, args []interface{ p.Expression }, length int) (start int, end int, err *p.Error) {
+	start, end = 0, length
+
+	if len(args) >= 1 {
+		start, err = toIndex(procName, args[0])
+		if err != nil {
+			return 0, 0, err
+		}
+
+		if start > length {
+			return 0, 0, newError(errIndexOutOfRange, procName, indexRange(length+1), strconv.Itoa(start))
+		}
+	}
+
+	if len(args) >= 2 {
+		end, err = toIndex(procName, args[1])
+		if err != nil {
+			return 0, 0, err
+		}
+
+		if end < start || end > length {
+			return 0, 0, newError(errIndexOutOfRange, procName, fmt.Sprintf("[%d, %d]", start, length), strconv.Itoa(end))
+		}
+	}
+
+	return start, end, nil
+}
+
+// returns a description of the valid indices of a sequence with the given length
+func indexRange(length int) string {
+	if length == 0 {
+		return "none, the sequence is empty"
+	}
+
+	return fmt.Sprintf("[0, %d]", length-1)
+}
@@ -0,0 +1,88 @@
+package interpreter
+
+import "testing"
+
+func TestStringsRunes(t *testing.T) {
+	checkEvals(t, []evalTest{
+		{`(string-length "héllo")`, "5"},
+		{`(string-length "日本語")`, "3"},
+		{`(string-length "")`, "0"},
+		{`(string-ref "日本語" 1)`, `#\本`},
+		{`(string-ref "abc" 3)`, "string-ref: index is out of range"},
+		{`(string-upcase "straße")`, `"STRAßE"`},
+		{`(string-downcase "ÀÉÎ")`, `"àéî"`},
+	})
+}
+
+func TestStringsUTF8(t *testing.T) {
+	checkEvals(t, []evalTest{
+		{`(string->utf8 "é")`, "(195 169)"},
+		{`(string->utf8 "日")`, "(230 151 165)"},
+		{`(string->utf8 "aé日" 1 2)`, "(195 169)"},
+		{`(string->utf8 "aé日" 2)`, "(230 151 165)"},
+		{`(string->utf8 "abc" 3)`, "()"},
+		{`(string->utf8 "abc" 0 0)`, "()"},
+		{`(string->utf8 "abc" 4)`, "string->utf8: index is out of range"},
+		{`(string->utf8 "abc" 2 1)`, "string->utf8: index is out of range"},
+		{`(utf8->string (string->utf8 "日本"))`, `"日本"`},
+		{`(utf8->string '())`, `""`},
+		{`(utf8->string '(255))`, "utf8->string: contract violation"},
+		{`(utf8->string '(230 151))`, "utf8->string: contract violation"},
+	})
+}
+
+func TestStringsNormalization(t *testing.T) {
+	checkEvals(t, []evalTest{
+		{`(string-length (string-normalize-nfd "é"))`, "2"},
+		{`(string-length (string-normalize-nfc "e\x301;"))`, "1"},
+		{`(equal? (string-normalize-nfc "e\x301;") "é")`, "#t"},
+		{`(equal? (string-normalize-nfd "é") "e\x301;")`, "#t"},
+		{`(string-normalize-nfc (string-normalize-nfd "Ångström"))`, `"Ångström"`},
+		{`(string-normalize-nfc "abc")`, `"abc"`},
+	})
+}
+
+func TestChars(t *testing.T) {
+	checkEvals(t, []evalTest{
+		{`(char-upcase #\ä)`, `#\Ä`},
+		{`(char-downcase #\Λ)`, `#\λ`},
+		{`(char-alphabetic? #\日)`, "#t"},
+		{`(char-alphabetic? #\1)`, "#f"},
+		{`(char-numeric? #\٣)`, "#t"},
+		{`(char-whitespace? #\x3000)`, "#t"},
+		{`(char-upper-case? #\Ä)`, "#t"},
+		{`(char-lower-case? #\Ä)`, "#f"},
+		{`(char->integer #\λ)`, "955"},
+		{`(integer->char 955)`, `#\λ`},
+		{`(char=? #\é #\é)`, "#t"},
+	})
+}
+
+// the mutable strings are changed in place, literals are immutable
+func TestStringsMutation(t *testing.T) {
+	checkEvals(t, []evalTest{
+		{`(define s (make-string 3 #\a))`, "#<void>"},
+		{`(string-set! s 1 #\b)`, "#<void>"},
+		{"s", `"aba"`},
+		{`(string-set! s 3 #\x)`, "string-set!: index is out of range"},
+		{`(string-fill! s #\z 1)`, "#<void>"},
+		{"s", `"azz"`},
+		{`(define t (string-copy "hello"))`, "#<void>"},
+		{`(string-copy! t 1 "XYZ" 1)`, "#<void>"},
+		{"t", `"hYZlo"`},
+		{`(string-copy! t 4 "XYZ")`, "string-copy!: contract violation"},
+		{`(string-set! "lit" 0 #\x)`, "string-set!: contract violation"},
+		{`(string-fill! "lit" #\x)`, "string-fill!: contract violation"},
+	})
+}
+
+func TestStringBuilders(t *testing.T) {
+	checkEvals(t, []evalTest{
+		{"(define b (string-builder))", "#<void>"},
+		{"(string-builder->string b)", `""`},
+		{`(string-builder-add! b "ab" #\c "日")`, "#<void>"},
+		{"(string-builder->string b)", `"abc日"`},
+		{"(string-builder-add! b 1)", "string-builder-add!: contract violation"},
+		{`(begin (string-builder-add! b "!") (string-builder->string b))`, `"abc日!"`},
+	})
+}
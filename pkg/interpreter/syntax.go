@@ -0,0 +1,483 @@
+package interpreter
+
+import (
+	"strconv"
+	"strings"
+
+	p "github.com/dimbata23/golang-scheme-interpreter/pkg/parser"
+)
+
+func init() {
+	registerSpecialForm("define-syntax", (*environment).evalDefineSyntax)
+}
+
+// identifier following a pattern or a template which repeats it
+const defaultEllipsis = "..."
+
+// transformer of a macro defined with syntax-rules
+type syntaxRules struct {
+	ellipsis string          // identifier used as the ellipsis
+	literals map[string]bool // identifiers matching only themselves
+	rules    []syntaxRule
+	env      *environment // environment the macro is defined in
+}
+
+// a pattern and the template it is rewritten to
+type syntaxRule struct {
+	pattern  *p.ExprList
+	template p.Expression
+}
+
+// value a pattern variable is bound to by a match, variables
+// under ellipses are bound to the sequence of their matches
+type patternBinding struct {
+	expr p.Expression
+	seq  []patternBinding
+}
+
+// state of the instantiation of a template for a single use of a macro
+type instantiation struct {
+	rules   *syntaxRules
+	name    string                 // name of the macro
+	renames map[string]*p.Variable // fresh names of the identifiers introduced by the template
+	binders map[string]bool        // identifiers the template binds, they are always renamed
+}
+
+/// ------------------------------------------------------------------------ ///
+/// ------------------------- Special form methods ------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// (define-syntax <name> (syntax-rules [ellipsis] (<literals...>) (<pattern> <template>) ...))
+// a use of the macro is rewritten by the template of the first matching
+// pattern, identifiers bound by lambda and let forms of the template
+// are renamed so they can't capture the ones of the use
+func (env *environment) evalDefineSyntax(lst *p.ExprList) (ex p.Expression, err *p.Error) {
+	lstLen := len(lst.Lst)
+	if lstLen != 3 {
+		return &p.Void, newError(errBadSyntax, "define-syntax", "exactly 2 arguments", strconv.Itoa(lstLen-1))
+	}
+
+	name, isVar := lst.Lst[1].(*p.Variable)
+	if !isVar {
+		return &p.Void, newError(errBadSyntax, "define-syntax", "identifier", errString(lst.Lst[1]))
+	}
+
+	rules, err := env.syntaxRules(lst.Lst[2])
+	if err != nil {
+		return &p.Void, err
+	}
+
+	if err := env.checkRedefinition("define-syntax", name.Val); err != nil {
+		return &p.Void, err
+	}
+
+	env.vars[name.Val] = &macro{name: name.Val, rules: rules}
+	if env.parent == nil && lst.Pos.Line != 0 {
+		env.interp.defined(name.Val, lst.Pos)
+	}
+
+	return &p.Void, nil
+}
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------------- Helper functions --------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// returns the transformer of the (syntax-rules ...) form
+func (env *environment) syntaxRules(expr p.Expression) (rules *syntaxRules, err *p.Error) {
+	form, isLst := expr.(*p.ExprList)
+	if !isLst || form.Qlevel > 0 || len(form.Lst) < 2 {
+		return nil, newError(errBadSyntax, "define-syntax", "(syntax-rules (<literals...>) <rules...>)", p.CodeString(expr))
+	}
+
+	if head, isVar := form.Lst[0].(*p.Variable); !isVar || head.Val != "syntax-rules" {
+		return nil, newError(errBadSyntax, "define-syntax", "(syntax-rules (<literals...>) <rules...>)", p.CodeString(expr))
+	}
+
+	rules = &syntaxRules{ellipsis: defaultEllipsis, literals: make(map[string]bool), env: env}
+	items := form.Lst[1:]
+	if ellipsis, isVar := items[0].(*p.Variable); isVar && len(items) > 1 {
+		rules.ellipsis = ellipsis.Val
+		items = items[1:]
+	}
+
+	literals, isLst := items[0].(*p.ExprList)
+	if !isLst || literals.Qlevel > 0 {
+		return nil, newError(errBadSyntax, "syntax-rules", "a list of literals", p.CodeString(items[0]))
+	}
+
+	for _, literal := range literals.Lst {
+		v, isVar := literal.(*p.Variable)
+		if !isVar {
+			return nil, newError(errBadSyntax, "syntax-rules", "an identifier as a literal", p.CodeString(literal))
+		}
+		rules.literals[v.Val] = true
+	}
+
+	for _, item := range items[1:] {
+		rule, isLst := item.(*p.ExprList)
+		if !isLst || rule.Qlevel > 0 || len(rule.Lst) != 2 {
+			return nil, newError(errBadSyntax, "syntax-rules", "(<pattern> <template>) as a rule", p.CodeString(item))
+		}
+
+		pattern, isLst := rule.Lst[0].(*p.ExprList)
+		if !isLst || pattern.Qlevel > 0 || len(pattern.Lst) == 0 {
+			return nil, newError(errBadSyntax, "syntax-rules", "a list as a pattern", p.CodeString(rule.Lst[0]))
+		}
+
+		rules.rules = append(rules.rules, syntaxRule{pattern: pattern, template: rule.Lst[1]})
+	}
+
+	return rules, nil
+}
+
+// rewrites the use of the macro by the first rule whose pattern it matches
+func (rules *syntaxRules) expand(name string, lst *p.ExprList) (ex p.Expression, err *p.Error) {
+	for _, rule := range rules.rules {
+		binds := make(map[string]patternBinding)
+		// the keyword at the start of the pattern is ignored
+		pattern := &p.ExprList{Lst: rule.pattern.Lst[1:], Tail: rule.pattern.Tail}
+		form := &p.ExprList{Lst: lst.Lst[1:], Tail: lst.Tail}
+
+		if !rules.match(pattern, form, binds) {
+			continue
+		}
+
+		inst := &instantiation{rules: rules, name: name, renames: make(map[string]*p.Variable), binders: make(map[string]bool)}
+		templateBinders(rule.template, inst.binders)
+		return inst.instantiate(rule.template, binds)
+	}
+
+	return &p.Void, newError(errBadSyntax, name, "a use matching one of the syntax rules", p.CodeString(lst))
+}
+
+// tests whether the code matches the pattern, binding its pattern variables
+func (rules *syntaxRules) match(pattern p.Expression, form p.Expression, binds map[string]patternBinding) bool {
+	switch pat := pattern.(type) {
+	case *p.Variable:
+		if rules.literals[pat.Val] {
+			v, isVar := form.(*p.Variable)
+			return isVar && v.Val == pat.Val
+		}
+
+		if pat.Val != "_" {
+			binds[pat.Val] = patternBinding{expr: form}
+		}
+		return true
+
+	case *p.ExprList:
+		if pat.Qlevel > 0 {
+			return p.Equal(p.Datum(pat), p.Quote(form))
+		}
+
+		lst, isLst := form.(*p.ExprList)
+		if !isLst || lst.Qlevel > 0 {
+			return false
+		}
+
+		return rules.matchList(pat, lst, binds)
+	}
+
+	return p.Equal(p.Quote(pattern), p.Quote(form))
+}
+
+// tests whether the items of the code list match the ones of the pattern,
+// an item followed by an ellipsis matches as many items as it can
+func (rules *syntaxRules) matchList(pat *p.ExprList, lst *p.ExprList, binds map[string]patternBinding) bool {
+	pats, items := pat.Lst, lst.Lst
+
+	for i, sub := range pats {
+		if i+1 >= len(pats) || !rules.isEllipsis(pats[i+1]) {
+			continue
+		}
+
+		before, after := pats[:i], pats[i+2:]
+		repeated := len(items) - len(before) - len(after)
+		if repeated < 0 || (pat.Tail == nil && lst.Tail != nil) {
+			return false
+		}
+
+		for j, sub := range before {
+			if !rules.match(sub, items[j], binds) {
+				return false
+			}
+		}
+
+		seqs := make(map[string][]patternBinding)
+		for _, name := range rules.patternVars(sub) {
+			seqs[name] = []patternBinding{}
+		}
+
+		for _, item := range items[len(before) : len(before)+repeated] {
+			itemBinds := make(map[string]patternBinding)
+			if !rules.match(sub, item, itemBinds) {
+				return false
+			}
+			for name := range seqs {
+				seqs[name] = append(seqs[name], itemBinds[name])
+			}
+		}
+
+		for name, seq := range seqs {
+			binds[name] = patternBinding{seq: seq}
+		}
+
+		rest := &p.ExprList{Lst: items[len(before)+repeated:], Tail: lst.Tail}
+		return rules.matchList(&p.ExprList{Lst: after, Tail: pat.Tail}, rest, binds)
+	}
+
+	if len(items) < len(pats) || (pat.Tail == nil && (len(items) != len(pats) || lst.Tail != nil)) {
+		return false
+	}
+
+	for i, sub := range pats {
+		if !rules.match(sub, items[i], binds) {
+			return false
+		}
+	}
+
+	if pat.Tail == nil {
+		return true
+	}
+
+	// the tail of a dotted pattern matches the rest of the list
+	var rest p.Expression = &p.ExprList{Lst: items[len(pats):], Tail: lst.Tail}
+	if len(items) == len(pats) && lst.Tail != nil {
+		rest = lst.Tail
+	}
+
+	return rules.match(pat.Tail, rest, binds)
+}
+
+// returns the names of the pattern variables of the pattern
+func (rules *syntaxRules) patternVars(pattern p.Expression) (names []string) {
+	p.Walk(pattern, func(expr p.Expression) bool {
+		switch pat := expr.(type) {
+		case *p.Variable:
+			if !rules.literals[pat.Val] && pat.Val != "_" && pat.Val != rules.ellipsis {
+				names = append(names, pat.Val)
+			}
+
+		case *p.ExprList:
+			return pat.Qlevel == 0
+		}
+
+		return false
+	})
+
+	return names
+}
+
+// tests whether the expression is the ellipsis identifier
+func (rules *syntaxRules) isEllipsis(expr p.Expression) bool {
+	v, isVar := expr.(*p.Variable)
+	return isVar && v.Val == rules.ellipsis
+}
+
+// returns the code of the template with the pattern variables replaced
+func (inst *instantiation) instantiate(template p.Expression, binds map[string]patternBinding) (ex p.Expression, err *p.Error) {
+	switch tmpl := template.(type) {
+	case *p.Variable:
+		bind, isBound := binds[tmpl.Val]
+		if !isBound {
+			return inst.rename(tmpl), nil
+		}
+
+		if bind.seq != nil {
+			return &p.Void, &p.Error{Val: inst.name + ": syntax-rules: missing ellipsis with pattern variable in template\n  variable: " + tmpl.Val}
+		}
+		return bind.expr, nil
+
+	case *p.Symbol, *p.Number, *p.Boolean:
+		if unquoted := p.Unquote(tmpl); unquoted != template {
+			return inst.instantiateQuoted(unquoted, binds)
+		}
+
+	case *p.ExprList:
+		if tmpl.Qlevel > 0 {
+			return inst.instantiateQuoted(p.Unquote(tmpl), binds)
+		}
+
+		return inst.instantiateList(tmpl, binds)
+	}
+
+	return template, nil
+}
+
+// returns the code of the template written as 'template, its quoted
+// identifiers aren't renamed but its pattern variables are replaced
+func (inst *instantiation) instantiateQuoted(template p.Expression, binds map[string]patternBinding) (ex p.Expression, err *p.Error) {
+	quoteInst := &instantiation{rules: inst.rules, name: inst.name}
+	res, err := quoteInst.instantiate(template, binds)
+	if err != nil {
+		return &p.Void, err
+	}
+
+	return &p.ExprList{Lst: []interface{ p.Expression }{&p.Variable{Val: "quote"}, res}}, nil
+}
+
+// returns the code of the list template, items followed by
+// ellipses are repeated for every match of their pattern variables
+func (inst *instantiation) instantiateList(tmpl *p.ExprList, binds map[string]patternBinding) (ex p.Expression, err *p.Error) {
+	// (... <template>) escapes the ellipses of the template
+	if len(tmpl.Lst) == 2 && inst.rules.isEllipsis(tmpl.Lst[0]) {
+		escaped := &instantiation{rules: &syntaxRules{literals: inst.rules.literals, env: inst.rules.env}, name: inst.name, renames: inst.renames, binders: inst.binders}
+		return escaped.instantiate(tmpl.Lst[1], binds)
+	}
+
+	if len(tmpl.Lst) == 2 {
+		if head, isVar := tmpl.Lst[0].(*p.Variable); isVar && head.Val == "quote" {
+			return inst.instantiateQuoted(tmpl.Lst[1], binds)
+		}
+	}
+
+	res := &p.ExprList{Lst: make([]interface{ p.Expression }, 0, len(tmpl.Lst)), Pos: tmpl.Pos}
+	for i := 0; i < len(tmpl.Lst); i++ {
+		sub := tmpl.Lst[i]
+
+		depth := 0
+		for i+1 < len(tmpl.Lst) && inst.rules.isEllipsis(tmpl.Lst[i+1]) {
+			depth++
+			i++
+		}
+
+		items, err := inst.repeat(sub, binds, depth)
+		if err != nil {
+			return &p.Void, err
+		}
+		res.Lst = append(res.Lst, items...)
+	}
+
+	if tmpl.Tail != nil {
+		tail, err := inst.instantiate(tmpl.Tail, binds)
+		if err != nil {
+			return &p.Void, err
+		}
+
+		// a tail that is a list continues the list
+		if tailLst, isLst := tail.(*p.ExprList); isLst && tailLst.Qlevel == 0 {
+			res.Lst = append(res.Lst, tailLst.Lst...)
+			res.Tail = tailLst.Tail
+		} else if !p.IsNullSym(tail) {
+			res.Tail = tail
+		}
+	}
+
+	return res, nil
+}
+
+// returns the instantiations of the template followed by depth ellipses
+func (inst *instantiation) repeat(template p.Expression, binds map[string]patternBinding, depth int) (items []interface{ p.Expression }, err *p.Error) {
+	if depth == 0 {
+		item, err := inst.instantiate(template, binds)
+		if err != nil {
+			return nil, err
+		}
+		return []interface{ p.Expression }{item}, nil
+	}
+
+	count, names := -1, []string{}
+	for _, name := range inst.rules.patternVars(template) {
+		bind, isBound := binds[name]
+		if !isBound || bind.seq == nil {
+			continue
+		}
+
+		if count != -1 && len(bind.seq) != count {
+			return nil, &p.Error{Val: inst.name + ": syntax-rules: incompatible ellipsis match counts for template\n  variables: " + strings.Join(append(names, name), " ")}
+		}
+		count = len(bind.seq)
+		names = append(names, name)
+	}
+
+	if count == -1 {
+		return nil, &p.Error{Val: inst.name + ": syntax-rules: no pattern variables before ellipsis in template\n  template: " + p.CodeString(template)}
+	}
+
+	for j := 0; j < count; j++ {
+		itemBinds := make(map[string]patternBinding, len(binds))
+		for name, bind := range binds {
+			itemBinds[name] = bind
+		}
+		for _, name := range names {
+			itemBinds[name] = binds[name].seq[j]
+		}
+
+		repeated, err := inst.repeat(template, itemBinds, depth-1)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, repeated...)
+	}
+
+	return items, nil
+}
+
+// returns the identifier introduced by the template as it is used in the
+// expansion, the ones the template binds get fresh names
+func (inst *instantiation) rename(v *p.Variable) *p.Variable {
+	if inst.renames == nil || !inst.binders[v.Val] {
+		return v
+	}
+
+	if fresh, isRenamed := inst.renames[v.Val]; isRenamed {
+		return fresh
+	}
+
+	interp := inst.rules.env.interp
+	interp.macroRenames++
+	fresh := &p.Variable{Val: v.Val + "." + strconv.Itoa(interp.macroRenames)}
+	inst.renames[v.Val] = fresh
+
+	return fresh
+}
+
+// adds the identifiers bound by the lambda and let forms of the template,
+// their parameters and the names of their bindings, to the set
+func templateBinders(template p.Expression, binders map[string]bool) {
+	tmpl, isLst := template.(*p.ExprList)
+	if !isLst || tmpl.Qlevel > 0 || len(tmpl.Lst) == 0 {
+		return
+	}
+
+	addVar := func(expr p.Expression) {
+		if v, isVar := expr.(*p.Variable); isVar {
+			binders[v.Val] = true
+		}
+	}
+
+	head, _ := tmpl.Lst[0].(*p.Variable)
+	switch {
+	case head == nil || len(tmpl.Lst) < 2:
+	case head.Val == "quote":
+		return
+
+	case head.Val == "lambda":
+		addVar(tmpl.Lst[1])
+		if params, isLst := tmpl.Lst[1].(*p.ExprList); isLst {
+			for _, param := range params.Lst {
+				addVar(param)
+			}
+			addVar(params.Tail)
+		}
+
+	case head.Val == "let" || head.Val == "let*" || head.Val == "letrec" || head.Val == "letrec*":
+		bindings := tmpl.Lst[1]
+		if name, isVar := bindings.(*p.Variable); isVar && len(tmpl.Lst) > 2 {
+			addVar(name) // named let
+			bindings = tmpl.Lst[2]
+		}
+
+		if lst, isLst := bindings.(*p.ExprList); isLst {
+			for _, binding := range lst.Lst {
+				if binding, isLst := binding.(*p.ExprList); isLst && len(binding.Lst) > 0 {
+					addVar(binding.Lst[0])
+				}
+			}
+		}
+	}
+
+	for _, item := range tmpl.Lst {
+		templateBinders(item, binders)
+	}
+}
@@ -0,0 +1,87 @@
+package interpreter
+
+import (
+	p "github.com/dimbata23/golang-scheme-interpreter/pkg/parser"
+)
+
+// an expression in a tail position, returned by the special forms and the
+// applications for eval to continue with instead of nesting a call, so
+// loops written as tail calls run in constant space
+type tailCall struct {
+	env  *environment // the environment the expression is evaluated in
+	expr p.Expression
+	file string // name of the file of the expression, for its errors and definitions
+}
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------------- Helper functions --------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// returns the expression as a tail call, evaluated by eval once
+// the special form returns it
+func (env *environment) tail(expr p.Expression) *tailCall {
+	return &tailCall{env: env, expr: expr, file: env.interp.source}
+}
+
+// evaluates the result of a special form or a lambda body if it is a tail
+// call, for the callers which need its value rather than returning it
+func resolveTail(ex p.Expression, err *p.Error) (p.Expression, *p.Error) {
+	if tc, isTail := ex.(*tailCall); isTail && err == nil {
+		return tc.env.eval(tc)
+	}
+
+	return ex, err
+}
+
+// applies the procedure like apply does, except that the last expression
+// of the body of a lambda is returned as a tail call, unless hooks are
+// notified of the applications, they are told when the application returns
+func (env *environment) applyTail(pr p.Expression, args *p.ExprList) (ex p.Expression, err *p.Error) {
+	lambda, isLambda := pr.(*p.Lambda)
+	if !isLambda || len(env.interp.callHooks) != 0 {
+		return env.apply(pr, args)
+	}
+
+	paramLen := len(lambda.Params.Lst)
+	argsLen := len(args.Lst)
+	if paramLen != argsLen && (lambda.Params.Tail == nil || argsLen < paramLen) {
+		return &p.Void, lambdaArityError(lambda, args)
+	}
+
+	ex, err = env.callLambda(lambda, args)
+	if err != nil {
+		err.Stack = append(err.Stack, frameName(pr))
+	}
+
+	return ex, err
+}
+
+// binds the parameters of the lambda to the arguments and evaluates its
+// body, the last expression of the body is returned as a tail call
+func (env *environment) callLambda(lambda *p.Lambda, args *p.ExprList) (ex p.Expression, err *p.Error) {
+	parent := env
+	if closure, isEnv := lambda.Env.(*environment); isEnv {
+		parent = closure
+	}
+
+	// errors in the body are located in the file of the lambda
+	lambdaEnv, err := makeEnvironment("lambda", parent, lambda.Params, args)
+	if err != nil {
+		return &p.Void, err
+	}
+
+	prev := env.interp.source
+	env.interp.source = lambda.File
+	ex, err = lambdaEnv.evalBody(lambda.Body.Lst)
+	env.interp.source = prev
+
+	return ex, err
+}
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------------- String() methods --------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+func (*tailCall) String(_ int) string {
+	return "#<tail-call>"
+}
@@ -0,0 +1,90 @@
+package interpreter
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	p "github.com/dimbata23/golang-scheme-interpreter/pkg/parser"
+)
+
+/// ------------------------------------------------------------------------ ///
+/// ------------------------ Timeout procedure methods --------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// (sleep <seconds>)
+// stops early if the evaluation is cancelled
+func (i *Interpreter) procSleep(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen != 1 {
+		return &p.Void, newError(errArityMismatch, "sleep", "1", strconv.Itoa(argsLen))
+	}
+
+	secs, err := toSeconds("sleep", args.Lst[0])
+	if err != nil {
+		return &p.Void, err
+	}
+
+	timer := time.NewTimer(secs)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return &p.Void, nil
+	case <-i.ctx.Done():
+		return &p.Void, newError(errCancelled, i.ctx.Err().Error())
+	}
+}
+
+// (with-timeout <seconds> <thunk> [on-timeout thunk])
+// calls the thunk cancelling it if it runs longer than the given seconds
+// returns the result of the thunk, or the result of calling on-timeout
+// when it is cancelled, #f if on-timeout isn't given
+func (i *Interpreter) procWithTimeout(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen < 2 || argsLen > 3 {
+		return &p.Void, newError(errArityMismatch, "with-timeout", "2 or 3", strconv.Itoa(argsLen))
+	}
+
+	secs, err := toSeconds("with-timeout", args.Lst[0])
+	if err != nil {
+		return &p.Void, err
+	}
+
+	for _, thunk := range args.Lst[1:] {
+		if !isCallable(thunk) {
+			return &p.Void, newError(errContractViolation, "with-timeout", "procedure?", errString(thunk))
+		}
+	}
+
+	parent := i.ctx
+	ctx, cancel := context.WithTimeout(parent, secs)
+	i.ctx = ctx
+	ex, err = i.genv.apply(args.Lst[1], &p.ExprList{})
+	i.ctx = parent
+	cancel()
+
+	if err == nil || ctx.Err() != context.DeadlineExceeded || parent.Err() != nil {
+		return ex, err
+	}
+
+	if argsLen == 3 {
+		return i.genv.apply(args.Lst[2], &p.ExprList{})
+	}
+
+	return &p.False, nil
+}
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------------- Helper functions --------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// returns the given non-negative number of seconds as a duration or an error
+func toSeconds(procName string, arg p.Expression) (secs time.Duration, err *p.Error) {
+	num, isNum := p.AsNumber(arg)
+	if !isNum || num < 0 {
+		return 0, newError(errContractViolation, procName, "(>=/c 0)", errString(arg))
+	}
+
+	return time.Duration(num * float64(time.Second)), nil
+}
@@ -0,0 +1,63 @@
+package interpreter
+
+import (
+	"strconv"
+
+	p "github.com/dimbata23/golang-scheme-interpreter/pkg/parser"
+)
+
+/// ------------------------------------------------------------------------ ///
+/// ----------------------- Values procedure methods ----------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// (values [values...])
+// a single value is returned as it is
+func procValues(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	if len(args.Lst) == 1 {
+		return args.Lst[0], nil
+	}
+
+	return &p.MultipleValues{Vals: toExprs(args.Lst)}, nil
+}
+
+// (call-with-values <producer> <consumer>)
+// calls the consumer with the values returned by the producer thunk
+func (i *Interpreter) procCallWithValues(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen != 2 {
+		return &p.Void, newError(errArityMismatch, "call-with-values", "2", strconv.Itoa(argsLen))
+	}
+
+	for _, arg := range args.Lst {
+		if !isCallable(arg) {
+			return &p.Void, newError(errContractViolation, "call-with-values", "procedure?", errString(arg))
+		}
+	}
+
+	res, err := i.genv.apply(args.Lst[0], &p.ExprList{})
+	if err != nil {
+		return &p.Void, err
+	}
+
+	return i.genv.apply(args.Lst[1], &p.ExprList{Lst: valuesOf(res)})
+}
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------------- Helper functions --------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// returns the values of the result of an expression, the single value
+// unless it returned multiple values
+func valuesOf(res p.Expression) []interface{ p.Expression } {
+	mv, isValues := res.(*p.MultipleValues)
+	if !isValues {
+		return []interface{ p.Expression }{res}
+	}
+
+	vals := make([]interface{ p.Expression }, len(mv.Vals))
+	for i, val := range mv.Vals {
+		vals[i] = val
+	}
+
+	return vals
+}
@@ -0,0 +1,174 @@
+package interpreter
+
+import (
+	"strconv"
+
+	p "github.com/dimbata23/golang-scheme-interpreter/pkg/parser"
+)
+
+/// ------------------------------------------------------------------------ ///
+/// ----------------------- Vector procedure methods ----------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// (vector [items...])
+func procVector(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	return p.NewVector(toExprs(args.Lst)), nil
+}
+
+// (make-vector <length> [fill])
+// the items are 0 unless the fill is given
+func procMakeVector(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen < 1 || argsLen > 2 {
+		return &p.Void, newError(errArityMismatch, "make-vector", "1 or 2", strconv.Itoa(argsLen))
+	}
+
+	length, err := toIndex("make-vector", args.Lst[0])
+	if err != nil {
+		return &p.Void, err
+	}
+
+	var fill p.Expression = p.NewNumber(0)
+	if argsLen == 2 {
+		fill = args.Lst[1]
+	}
+
+	items := make([]p.Expression, length)
+	for i := range items {
+		items[i] = fill
+	}
+
+	return p.NewVector(items), nil
+}
+
+// (vector? <expression>)
+func procIsVector(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen != 1 {
+		return &p.Void, newError(errArityMismatch, "vector?", "1", strconv.Itoa(argsLen))
+	}
+
+	if _, isVec := args.Lst[0].(*p.Vector); isVec {
+		return &p.True, nil
+	}
+
+	return &p.False, nil
+}
+
+// (vector-length <vector>)
+func procVectorLength(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen != 1 {
+		return &p.Void, newError(errArityMismatch, "vector-length", "1", strconv.Itoa(argsLen))
+	}
+
+	vec, err := toVector("vector-length", args.Lst[0])
+	if err != nil {
+		return &p.Void, err
+	}
+
+	return p.NewNumber(float64(len(vec.Items))), nil
+}
+
+// (vector-ref <vector> <index>)
+func procVectorRef(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen != 2 {
+		return &p.Void, newError(errArityMismatch, "vector-ref", "2", strconv.Itoa(argsLen))
+	}
+
+	vec, err := toVector("vector-ref", args.Lst[0])
+	if err != nil {
+		return &p.Void, err
+	}
+
+	idx, err := toVectorIndex("vector-ref", vec, args.Lst[1])
+	if err != nil {
+		return &p.Void, err
+	}
+
+	return vec.Items[idx], nil
+}
+
+// (vector-set! <vector> <index> <value>)
+// vector literals can't be changed
+func procVectorSet(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen != 3 {
+		return &p.Void, newError(errArityMismatch, "vector-set!", "3", strconv.Itoa(argsLen))
+	}
+
+	vec, isVec := args.Lst[0].(*p.Vector)
+	if !isVec || vec.Immutable {
+		return &p.Void, newError(errContractViolation, "vector-set!", "(and/c vector? (not/c immutable?))", errString(args.Lst[0]))
+	}
+
+	idx, err := toVectorIndex("vector-set!", vec, args.Lst[1])
+	if err != nil {
+		return &p.Void, err
+	}
+
+	vec.Items[idx] = args.Lst[2]
+
+	return &p.Void, nil
+}
+
+// (vector->list <vector>)
+func (i *Interpreter) procVectorToList(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen != 1 {
+		return &p.Void, newError(errArityMismatch, "vector->list", "1", strconv.Itoa(argsLen))
+	}
+
+	vec, err := toVector("vector->list", args.Lst[0])
+	if err != nil {
+		return &p.Void, err
+	}
+
+	i.stats.conses += len(vec.Items)
+
+	return p.List(vec.Items...), nil
+}
+
+// (list->vector <list>)
+func procListToVector(args *p.ExprList) (ex p.Expression, err *p.Error) {
+	argsLen := len(args.Lst)
+	if argsLen != 1 {
+		return &p.Void, newError(errArityMismatch, "list->vector", "1", strconv.Itoa(argsLen))
+	}
+
+	items, err := toListItems("list->vector", args.Lst[0])
+	if err != nil {
+		return &p.Void, err
+	}
+
+	return p.NewVector(items), nil
+}
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------------- Helper functions --------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// returns the given expression as a vector or an error
+func toVector(procName string, arg p.Expression) (vec *p.Vector, err *p.Error) {
+	vec, isVec := arg.(*p.Vector)
+	if !isVec {
+		return nil, newError(errContractViolation, procName, "vector?", errString(arg))
+	}
+
+	return vec, nil
+}
+
+// returns the given expression as an index of an item of the vector or an error
+func toVectorIndex(procName string, vec *p.Vector, arg p.Expression) (idx int, err *p.Error) {
+	idx, err = toIndex(procName, arg)
+	if err != nil {
+		return 0, err
+	}
+
+	if idx >= len(vec.Items) {
+		return 0, newError(errIndexOutOfRange, procName, indexRange(len(vec.Items)), strconv.Itoa(idx))
+	}
+
+	return idx, nil
+}
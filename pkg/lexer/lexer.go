@@ -4,6 +4,7 @@ package lexer
 
 import (
 	"fmt"
+	"io"
 	"strings"
 	"unicode"
 	"unicode/utf8"
@@ -15,19 +16,34 @@ import (
 
 // the lexer struct
 type Lexer struct {
-	input  string     // text being lexed
-	start  int        // starting position of current token
-	pos    int        // current position in the text
-	width  int        // width of last read rune
-	state  stateFn    // the state function used for lexing
-	level  int        // number of lists opened and not closed
-	tokens chan Token // output channel of read tokens
+	input   string     // text being lexed, only the part not yet discarded when reading from a reader
+	src     []byte     // the input given to NewLexerFromBytes, the views of the tokens are parts of it
+	reader  io.Reader  // where the rest of the input is read from, nil once it is all in input
+	readErr error      // an error reading the input, reported at its end
+	offset  int        // number of bytes of the input discarded before input
+	start   int        // starting position of current token
+	line    int        // line of the starting position
+	col     int        // column of the starting position
+	pos     int        // current position in the text
+	width   int        // width of last read rune
+	state   stateFn    // the state function used for lexing
+	opens   []Position // positions of the brackets opened and not closed
+	tokens  []Token    // lexed tokens not yet returned, from the next one on
+	queued  int        // index of the next token to return in tokens
 }
 
 // the basic token (unit) used by the lexer
 type Token struct {
 	Typ TokenType
 	Val string
+	Pos Position // where the token starts in the input
+}
+
+// position in the input of the lexer
+type Position struct {
+	Line   int // line number, starting from 1
+	Col    int // column number in runes, starting from 1
+	Offset int // offset in bytes, starting from 0
 }
 
 // token type used by the lexer
@@ -43,6 +59,12 @@ const (
 	TokenCloseBracket                  // a closing bracket `)`
 	TokenQuote                         // a quote `'`
 	TokenSkip                          // any whitespace or ignored lex tokens
+	TokenChar                          // a character literal `#\a`
+	TokenBoolean                       // a boolean literal `#t`, `#f`, `#true` or `#false`
+	TokenHashOpen                      // an opening of a hash table `#hash(`
+	TokenVectorOpen                    // an opening of a vector `#(`
+	TokenDot                           // a dot `.` separating the tail of a pair
+	TokenDatumComment                  // a `#;` commenting out the datum after it
 )
 
 /// ------------------------------------------------------------------------ ///
@@ -52,33 +74,76 @@ const (
 // creates a lexer from the given input
 func NewLexer(input string) *Lexer {
 	l := &Lexer{
-		input:  input,
-		state:  lexGeneral,
-		tokens: make(chan Token, 2),
+		input: input,
+		line:  1,
+		col:   1,
+		state: lexGeneral,
 	}
 
 	return l
 }
 
+// creates a lexer reading its input from the reader as it is needed,
+// the input before the current token is discarded so the memory used
+// doesn't grow with the length of the input
+func NewLexerFromReader(r io.Reader) *Lexer {
+	l := NewLexer("")
+	l.reader = r
+	return l
+}
+
+// creates a lexer from the given input, the views of the tokens
+// returned by View are parts of it, so it mustn't be modified
+func NewLexerFromBytes(input []byte) *Lexer {
+	l := NewLexer(string(input))
+	l.src = input
+	return l
+}
+
 // returns the next token from the input
 // or nil when the input has finished
 func (l *Lexer) NextToken() *Token {
-	for {
-		select {
-		case token := <-l.tokens:
-			if token.Typ == TokenEOF {
-				return nil
-			}
+	token, ok := l.Next()
+	if !ok {
+		return nil
+	}
 
-			return &token
-		default:
-			if l.state != nil {
-				l.state = l.state(l)
-			} else {
-				l.state = lexGeneral
-			}
+	return &token
+}
+
+// returns the next token from the input like NextToken but by value,
+// so lexing allocates nothing for the tokens, ok is false when the input
+// has finished, the state functions are called directly until one of them
+// emits a token
+func (l *Lexer) Next() (token Token, ok bool) {
+	for l.queued == len(l.tokens) {
+		if l.state != nil {
+			l.state = l.state(l)
+		} else {
+			l.state = lexGeneral
 		}
 	}
+
+	token = l.tokens[l.queued]
+	l.queued++
+	if l.queued == len(l.tokens) {
+		// all the tokens are returned, the queue is reused
+		l.tokens, l.queued = l.tokens[:0], 0
+	}
+
+	return token, token.Typ != TokenEOF
+}
+
+// returns the value of the token as bytes, for a lexer created with
+// NewLexerFromBytes it is the part of the input the token was lexed from
+// rather than a copy, for other lexers and for errors it is a copy
+func (l *Lexer) View(token Token) []byte {
+	end := token.Pos.Offset + len(token.Val)
+	if l.src == nil || token.Typ == TokenError || end > len(l.src) {
+		return []byte(token.Val)
+	}
+
+	return l.src[token.Pos.Offset:end:end]
 }
 
 /// ------------------------------------------------------------------------ ///
@@ -87,6 +152,23 @@ func (l *Lexer) NextToken() *Token {
 
 const eof rune = -1 // the end of file rune
 
+const hashOpen = "#hash(" // the opening of a hash table
+
+const vectorOpen = "#(" // the opening of a vector
+
+const blockCommentOpen = "#|" // the opening of a block comment, which can be nested
+
+const blockCommentClose = "|#" // the closing of a block comment
+
+const datumComment = "#;" // the prefix of a commented out datum
+
+// texts lexed as boolean literals rather than identifiers
+var booleanLiterals = map[string]bool{"#t": true, "#f": true, "#true": true, "#false": true}
+
+const readChunkSize = 4096 // number of bytes read from a reader at a time
+
+const discardSize = 4096 // number of lexed bytes of the input kept before discarding them
+
 // state function type returning another state function
 // after lexing a part of the input
 type stateFn func(*Lexer) stateFn
@@ -95,14 +177,67 @@ type stateFn func(*Lexer) stateFn
 /// -------------------- Private functions and methods --------------------- ///
 /// ------------------------------------------------------------------------ ///
 
-// sends tokens through the channel
+// queues the current token, its value is a part of the input, not a copy
 func (l *Lexer) emit(t TokenType) {
-	l.tokens <- Token{t, l.input[l.start:l.pos]}
+	l.tokens = append(l.tokens, Token{t, l.input[l.start:l.pos], l.position()})
+	l.advance()
+}
+
+// sends the current token as a boolean if it is a boolean literal
+// or as an identifier otherwise
+func (l *Lexer) emitIdentifier() {
+	if booleanLiterals[l.input[l.start:l.pos]] {
+		l.emit(TokenBoolean)
+	} else {
+		l.emit(TokenIdentifier)
+	}
+}
+
+// returns the position of the current token
+func (l *Lexer) position() Position {
+	return Position{Line: l.line, Col: l.col, Offset: l.offset + l.start}
+}
+
+// moves the start of the current token to the current position
+// keeping track of the line and column of the start
+func (l *Lexer) advance() {
+	for _, r := range l.input[l.start:l.pos] {
+		if r == '\n' {
+			l.line++
+			l.col = 1
+		} else {
+			l.col++
+		}
+	}
+
 	l.start = l.pos
+	if l.reader != nil && l.start >= discardSize {
+		l.offset += l.start
+		l.input = l.input[l.start:]
+		l.pos -= l.start
+		l.start = 0
+	}
+}
+
+// reads more of the input from the reader, if any, until there are
+// at least n bytes after the current position or the input has ended
+func (l *Lexer) fill(n int) {
+	for l.reader != nil && len(l.input)-l.pos < n {
+		buf := make([]byte, readChunkSize)
+		cnt, err := l.reader.Read(buf)
+		l.input += string(buf[:cnt])
+		if err != nil {
+			if err != io.EOF {
+				l.readErr = err
+			}
+			l.reader = nil
+		}
+	}
 }
 
 // get the next rune in the input or eof
 func (l *Lexer) next() (r rune) {
+	l.fill(utf8.UTFMax)
 	if l.pos >= len(l.input) {
 		l.width = 0
 		return eof
@@ -116,7 +251,7 @@ func (l *Lexer) next() (r rune) {
 
 // ignore the current token
 func (l *Lexer) ignore() {
-	l.start = l.pos
+	l.advance()
 }
 
 // returns back the last read rune (can only be used once after next())
@@ -131,12 +266,33 @@ func (l *Lexer) peek() rune {
 	return r
 }
 
-// emits a formatted error token to the channel
+// emits a formatted error token
 func (l *Lexer) errorf(format string, args ...interface{}) stateFn {
-	l.tokens <- Token{TokenError, fmt.Sprintf(format, args...)}
+	return l.errorAt(l.position(), format, args...)
+}
+
+// emits a formatted error token at the given position
+func (l *Lexer) errorAt(pos Position, format string, args ...interface{}) stateFn {
+	l.tokens = append(l.tokens, Token{TokenError, fmt.Sprintf(format, args...), pos})
 	return nil
 }
 
+// consumes the part of an identifier after a `|` up to the closing `|`,
+// inside of which `\|` and `\\` stand for `|` and `\`
+// returns false if the input ends before it
+func (l *Lexer) acceptPipePart() bool {
+	for {
+		switch l.next() {
+		case '\\':
+			l.next()
+		case '|':
+			return true
+		case eof:
+			return false
+		}
+	}
+}
+
 // consumes the next rune if it's from the valid set
 func (l *Lexer) accept(valid string) bool {
 	if strings.ContainsRune(valid, l.next()) {
@@ -164,15 +320,32 @@ func (l *Lexer) acceptRun(valid string) int {
 // reads and emits open bracket
 func lexOpenBracket(l *Lexer) stateFn {
 	l.pos++
-	l.level++
+	l.opens = append(l.opens, l.position())
 	l.emit(TokenOpenBracket)
 	return lexGeneral
 }
 
+// reads and emits the opening of a hash table
+func lexHashOpen(l *Lexer) stateFn {
+	l.pos += len(hashOpen)
+	l.opens = append(l.opens, l.position())
+	l.emit(TokenHashOpen)
+	return lexGeneral
+}
+
+// reads and emits the opening of a vector
+func lexVectorOpen(l *Lexer) stateFn {
+	l.pos += len(vectorOpen)
+	l.opens = append(l.opens, l.position())
+	l.emit(TokenVectorOpen)
+	return lexGeneral
+}
+
 // lexes the next rune and returns a state function based on it
 // or nil on error
 func lexGeneral(l *Lexer) stateFn {
 	for {
+		l.fill(len(hashOpen))
 		if strings.HasPrefix(l.input[l.pos:], ")") {
 			return lexCloseBracket
 		}
@@ -181,19 +354,57 @@ func lexGeneral(l *Lexer) stateFn {
 			return lexOpenBracket
 		}
 
+		if strings.HasPrefix(l.input[l.pos:], hashOpen) {
+			return lexHashOpen
+		}
+
+		if strings.HasPrefix(l.input[l.pos:], vectorOpen) {
+			return lexVectorOpen
+		}
+
+		if strings.HasPrefix(l.input[l.pos:], blockCommentOpen) {
+			return lexBlockComment
+		}
+
+		if strings.HasPrefix(l.input[l.pos:], datumComment) {
+			l.pos += len(datumComment)
+			l.emit(TokenDatumComment)
+			return lexGeneral
+		}
+
 		switch r := l.next(); {
 		case r == eof:
-			if l.level > 0 {
-				l.emit(TokenEOF)
-				return l.errorf("expected a `)` to close `(`")
-			} else {
-				l.emit(TokenEOF)
-				return lexGeneral
+			if len(l.opens) > 0 {
+				// reported before the end of the input so the parser
+				// gets the error while reading the unclosed list
+				open := l.opens[len(l.opens)-1]
+				l.opens = nil
+				return l.errorAt(open, "read-syntax: expected a `)` to close `(` opened at %s", open)
+			}
+
+			if l.readErr != nil {
+				err := l.readErr
+				l.readErr = nil
+				return l.errorf("read-syntax: couldn't read the input: %s", err)
 			}
+
+			l.emit(TokenEOF)
+			return lexGeneral
 		case unicode.IsSpace(r):
 			l.ignore()
+		case r == ';':
+			// a comment up to the end of the line
+			for r != '\n' && r != eof {
+				r = l.next()
+			}
+			l.ignore()
 		case r == '"':
 			return lexDoubleQuote
+		case r == '|':
+			l.backup()
+			return lexIdentifier
+		case r == '#' && l.peek() == '\\':
+			return lexChar
 		case r == '\'':
 			l.backup()
 			return lexQuote
@@ -207,25 +418,73 @@ func lexGeneral(l *Lexer) stateFn {
 	}
 }
 
+// skips a block comment with the block comments nested in it
+func lexBlockComment(l *Lexer) stateFn {
+	open := l.position()
+	l.pos += len(blockCommentOpen)
+
+	for depth := 1; depth > 0; {
+		l.fill(len(blockCommentClose))
+		switch {
+		case strings.HasPrefix(l.input[l.pos:], blockCommentOpen):
+			l.pos += len(blockCommentOpen)
+			depth++
+		case strings.HasPrefix(l.input[l.pos:], blockCommentClose):
+			l.pos += len(blockCommentClose)
+			depth--
+		case l.next() == eof:
+			return l.errorAt(open, "read-syntax: expected a `|#` to close `#|` opened at %s", open)
+		}
+	}
+
+	l.ignore()
+	return lexGeneral
+}
+
 // reads and emits a number token
 func lexNumber(l *Lexer) stateFn {
 	// optional leading sign
 	bSigned := l.accept("+-")
 	const digits = "0123456789"
 	cnt := l.acceptRun(digits)
-	if l.accept(".") {
+	isDecimal := l.accept(".")
+	if isDecimal {
 		cnt++
 		cnt += l.acceptRun(digits)
 	}
 
+	// the exponent of a decimal with a digit, e.g. `6.02e23` or `-1.5e-3`
+	hasDigit := cnt > 1 || cnt == 1 && !isDecimal
+	if hasDigit && l.accept("eE") {
+		l.accept("+-")
+		if l.acceptRun(digits) == 0 {
+			return lexIdentifier
+		}
+		isDecimal = true // not the numerator of a rational
+	}
+
+	// the denominator of a rational, e.g. `3/4`
+	if !isDecimal && cnt > 0 && l.peek() == '/' {
+		l.next()
+		if l.acceptRun(digits) == 0 {
+			return lexIdentifier
+		}
+	}
+
 	extAlpha := "+-.*/<=>!?:$%_&~^"
 
 	r := l.peek()
 
-	if unicode.IsLetter(r) || strings.ContainsRune(extAlpha, r) {
+	if unicode.IsLetter(r) || r == '|' || strings.ContainsRune(extAlpha, r) {
 		return lexIdentifier
 	}
 
+	if !bSigned && cnt == 1 && l.input[l.start:l.pos] == "." {
+		// a lonely dot of a dotted pair
+		l.emit(TokenDot)
+		return lexGeneral
+	}
+
 	if bSigned && cnt == 0 {
 		// special case: just + or just -
 		l.emit(TokenIdentifier)
@@ -238,34 +497,55 @@ func lexNumber(l *Lexer) stateFn {
 
 // reads and emits a closing bracket token
 func lexCloseBracket(l *Lexer) stateFn {
+	pos := l.position()
 	l.next()
 	l.emit(TokenCloseBracket)
-	if l.level > 0 {
-		l.level--
-	} else {
-		return l.errorf("read-syntax: unexpected `)`")
-	}
-
-	if l.level > 0 {
-		return lexGeneral
+	if len(l.opens) == 0 {
+		return l.errorAt(pos, "read-syntax: unexpected `)`")
 	}
 
+	l.opens = l.opens[:len(l.opens)-1]
 	return lexGeneral
 }
 
-// reads and emits a string token
+// reads and emits a string token, the character after a `\` doesn't
+// close it, the parser replaces the escape sequences
 func lexDoubleQuote(l *Lexer) stateFn {
 	for {
-		r := l.next()
-		if r == '"' {
+		switch l.next() {
+		case '\\':
+			if l.next() == eof {
+				return l.errorf("expected a `\"` to close `\"`")
+			}
+		case '"':
 			l.emit(TokenString)
 			return lexGeneral
-		} else if r == eof {
+		case eof:
 			return l.errorf("expected a `\"` to close `\"`")
 		}
 	}
 }
 
+// reads and emits a character token
+func lexChar(l *Lexer) stateFn {
+	l.next() // the `\` after `#`
+	if l.next() == eof {
+		return l.errorf("read-syntax: expected a character after `#\\`")
+	}
+
+	// character names such as `#\space` continue with letters
+	for {
+		r := l.next()
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			l.backup()
+			break
+		}
+	}
+
+	l.emit(TokenChar)
+	return lexGeneral
+}
+
 // reads and emits a quote token
 func lexQuote(l *Lexer) stateFn {
 	l.pos++
@@ -273,16 +553,30 @@ func lexQuote(l *Lexer) stateFn {
 	return lexGeneral
 }
 
-// reads and emits an identifier token
+// reads and emits an identifier token or a boolean token, parts of an
+// identifier surrounded by `|` can have any characters, e.g. `a|b c|d`
 func lexIdentifier(l *Lexer) stateFn {
 	for {
 		switch r := l.next(); {
+		case r == '|':
+			if !l.acceptPipePart() {
+				return l.errorf("expected a `|` to close `|`")
+			}
+
 		case r == eof:
-			return l.errorf("expected a `)` to close `(`")
+			// the end of the input is reported by lexGeneral
+			l.emitIdentifier()
+			return lexGeneral
 
-		case unicode.IsSpace(r) || r == ')':
+		case unicode.IsSpace(r) || r == ')' || r == ';':
 			l.backup()
-			l.emit(TokenIdentifier)
+			l.emitIdentifier()
+			return lexGeneral
+
+		case (r == '"' || r == '(') && l.input[l.start] == '#':
+			// the datum of a reader syntax such as #date"2024-01-01"
+			l.backup()
+			l.emitIdentifier()
 			return lexGeneral
 		}
 	}
@@ -292,6 +586,11 @@ func lexIdentifier(l *Lexer) stateFn {
 /// -------------------------- Utility functions --------------------------- ///
 /// ------------------------------------------------------------------------ ///
 
+// returns the position as `line:column`
+func (pos Position) String() string {
+	return fmt.Sprintf("%d:%d", pos.Line, pos.Col)
+}
+
 // used for debug info
 func (i Token) String() string {
 	switch i.Typ {
@@ -309,12 +608,24 @@ func (i Token) String() string {
 		str += "Identifier"
 	case TokenString:
 		str += "String"
+	case TokenChar:
+		str += "Char"
+	case TokenBoolean:
+		str += "Boolean"
 	case TokenOpenBracket:
 		str += "OpenBracket"
+	case TokenHashOpen:
+		str += "HashOpen"
+	case TokenVectorOpen:
+		str += "VectorOpen"
+	case TokenDot:
+		str += "Dot"
 	case TokenCloseBracket:
 		str += "CloseBracket"
 	case TokenQuote:
 		str += "Quote"
+	case TokenDatumComment:
+		str += "DatumComment"
 	case TokenSkip:
 		str += "Skip"
 	}
@@ -0,0 +1,187 @@
+package lexer
+
+import (
+	"strings"
+	"testing"
+)
+
+// scheme code with every kind of token, repeated for the large inputs
+const sample = `; a comment
+(define (fact n) (if (< n 2) 1 (* n (fact (- n 1)))))
+(display "fact: \"10\"") #;(ignored) #| block #| nested |# |#
+'(1 2.5 #\a #\space #t #false . tail) #(1 2) #hash((a . 1))
+|odd symbol| λ
+`
+
+func TestLexerTokens(t *testing.T) {
+	tests := []struct {
+		input string
+		typs  []TokenType
+		vals  []string
+	}{
+		{"(+ 1 2)", []TokenType{TokenOpenBracket, TokenIdentifier, TokenNumber, TokenNumber, TokenCloseBracket}, []string{"(", "+", "1", "2", ")"}},
+		{`"a \"b\""`, []TokenType{TokenString}, []string{`"a \"b\""`}},
+		{"#\\a #t #false", []TokenType{TokenChar, TokenBoolean, TokenBoolean}, []string{"#\\a", "#t", "#false"}},
+		{"'(a . b)", []TokenType{TokenQuote, TokenOpenBracket, TokenIdentifier, TokenDot, TokenIdentifier, TokenCloseBracket}, []string{"'", "(", "a", ".", "b", ")"}},
+		{"#(1) #hash()", []TokenType{TokenVectorOpen, TokenNumber, TokenCloseBracket, TokenHashOpen, TokenCloseBracket}, []string{"#(", "1", ")", "#hash(", ")"}},
+		{"#;x", []TokenType{TokenDatumComment, TokenIdentifier}, []string{"#;", "x"}},
+	}
+
+	for _, test := range tests {
+		var typs []TokenType
+		var vals []string
+		l := NewLexer(test.input)
+		for token := l.NextToken(); token != nil; token = l.NextToken() {
+			if token.Typ == TokenSkip {
+				continue
+			}
+			typs = append(typs, token.Typ)
+			vals = append(vals, token.Val)
+		}
+
+		if len(typs) != len(test.typs) {
+			t.Errorf("%q: got tokens %v %q, want %v %q", test.input, typs, vals, test.typs, test.vals)
+			continue
+		}
+
+		for i := range typs {
+			if typs[i] != test.typs[i] || vals[i] != test.vals[i] {
+				t.Errorf("%q: token %d is %v %q, want %v %q", test.input, i, typs[i], vals[i], test.typs[i], test.vals[i])
+			}
+		}
+	}
+}
+
+func TestLexerPositions(t *testing.T) {
+	l := NewLexer("(a\n  λb)")
+	want := []Position{{1, 1, 0}, {1, 2, 1}, {2, 3, 5}, {2, 5, 8}}
+	for i, pos := range want {
+		token, ok := l.Next()
+		if !ok {
+			t.Fatalf("the input ended before token %d", i)
+		}
+		if token.Pos != pos {
+			t.Errorf("token %d %q is at %+v, want %+v", i, token.Val, token.Pos, pos)
+		}
+	}
+}
+
+func TestLexerView(t *testing.T) {
+	src := []byte(sample)
+	l := NewLexerFromBytes(src)
+	for token, ok := l.Next(); ok; token, ok = l.Next() {
+		view := l.View(token)
+		if string(view) != token.Val {
+			t.Fatalf("the view of %q is %q", token.Val, view)
+		}
+		if len(view) != 0 && &view[0] != &src[token.Pos.Offset] {
+			t.Fatalf("the view of %q at %d is a copy", token.Val, token.Pos.Offset)
+		}
+	}
+
+	// the views of lexers of strings are copies
+	l = NewLexer("abc")
+	token, _ := l.Next()
+	if got := string(l.View(token)); got != "abc" {
+		t.Errorf("the view of a string lexer is %q, want %q", got, "abc")
+	}
+}
+
+func TestLexerReader(t *testing.T) {
+	input := strings.Repeat(sample, 500)
+	fromString := NewLexer(input)
+	fromReader := NewLexerFromReader(strings.NewReader(input))
+	for i := 0; ; i++ {
+		want, wantOk := fromString.Next()
+		got, gotOk := fromReader.Next()
+		if got != want || gotOk != wantOk {
+			t.Fatalf("token %d from the reader is %+v, want %+v", i, got, want)
+		}
+		if !wantOk {
+			break
+		}
+	}
+}
+
+func TestLexerErrors(t *testing.T) {
+	for _, input := range []string{`"unterminated`, "(a", "#| open"} {
+		l := NewLexer(input)
+		found := false
+		for token := l.NextToken(); token != nil; token = l.NextToken() {
+			found = found || token.Typ == TokenError
+		}
+		if !found {
+			t.Errorf("%q: no error token", input)
+		}
+	}
+}
+
+// returns the next token the way the lexer did before NextToken called
+// the state functions directly, sending every token through a channel
+func (l *Lexer) nextTokenChannel(tokens chan Token) *Token {
+	for {
+		select {
+		case token := <-tokens:
+			if token.Typ == TokenEOF {
+				return nil
+			}
+
+			return &token
+		default:
+			if l.queued < len(l.tokens) {
+				tokens <- l.tokens[l.queued]
+				l.queued++
+				continue
+			}
+
+			l.tokens, l.queued = l.tokens[:0], 0
+			if l.state != nil {
+				l.state = l.state(l)
+			} else {
+				l.state = lexGeneral
+			}
+		}
+	}
+}
+
+// the large input of the benchmarks, about 200KB
+var benchInput = strings.Repeat(sample, 1000)
+
+func BenchmarkLexerChannel(b *testing.B) {
+	b.SetBytes(int64(len(benchInput)))
+	for n := 0; n < b.N; n++ {
+		l := NewLexer(benchInput)
+		tokens := make(chan Token, 2)
+		for token := l.nextTokenChannel(tokens); token != nil; token = l.nextTokenChannel(tokens) {
+		}
+	}
+}
+
+func BenchmarkLexerNextToken(b *testing.B) {
+	b.SetBytes(int64(len(benchInput)))
+	for n := 0; n < b.N; n++ {
+		l := NewLexer(benchInput)
+		for token := l.NextToken(); token != nil; token = l.NextToken() {
+		}
+	}
+}
+
+func BenchmarkLexerNext(b *testing.B) {
+	src := []byte(benchInput)
+	b.SetBytes(int64(len(src)))
+	for n := 0; n < b.N; n++ {
+		l := NewLexerFromBytes(src)
+		for token, ok := l.Next(); ok; token, ok = l.Next() {
+			_ = l.View(token)
+		}
+	}
+}
+
+func BenchmarkLexerReader(b *testing.B) {
+	b.SetBytes(int64(len(benchInput)))
+	for n := 0; n < b.N; n++ {
+		l := NewLexerFromReader(strings.NewReader(benchInput))
+		for _, ok := l.Next(); ok; _, ok = l.Next() {
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package parser
+
+import (
+	"strconv"
+	"unicode"
+	"unicode/utf8"
+)
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------------- Inner structure ---------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// characters by the names of their `#\<name>` literals
+var charsByName = map[string]rune{
+	"nul":       0,
+	"null":      0,
+	"alarm":     7,
+	"backspace": 8,
+	"tab":       '\t',
+	"newline":   '\n',
+	"linefeed":  '\n',
+	"vtab":      '\v',
+	"page":      '\f',
+	"return":    '\r',
+	"escape":    27,
+	"altmode":   27,
+	"space":     ' ',
+	"delete":    127,
+	"rubout":    127,
+}
+
+// names the characters are printed with, the first of their names
+var charNames = map[rune]string{
+	0:    "nul",
+	7:    "alarm",
+	8:    "backspace",
+	'\t': "tab",
+	'\n': "newline",
+	'\v': "vtab",
+	'\f': "page",
+	'\r': "return",
+	27:   "escape",
+	' ':  "space",
+	127:  "delete",
+}
+
+/// ------------------------------------------------------------------------ ///
+/// -------------------- Private functions and methods --------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// returns the character of the text after `#\` of a character literal,
+// a single character, a name such as `space` or a code point in
+// hexadecimal after `x` or `u` such as `x41`
+func parseChar(text string) (val rune, ok bool) {
+	if utf8.RuneCountInString(text) == 1 {
+		val, _ = utf8.DecodeRuneInString(text)
+		return val, true
+	}
+
+	if val, isName := charsByName[text]; isName {
+		return val, true
+	}
+
+	if text[0] != 'x' && text[0] != 'u' {
+		return 0, false
+	}
+
+	code, err := strconv.ParseUint(text[1:], 16, 32)
+	if err != nil || !utf8.ValidRune(rune(code)) {
+		return 0, false
+	}
+
+	return rune(code), true
+}
+
+// returns the text of the character literal after `#\`
+func charText(val rune) string {
+	if name, isNamed := charNames[val]; isNamed {
+		return name
+	}
+
+	if !unicode.IsPrint(val) {
+		return "u" + strconv.FormatInt(int64(val), 16)
+	}
+
+	return string(val)
+}
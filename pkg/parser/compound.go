@@ -0,0 +1,290 @@
+package parser
+
+import (
+	"math"
+	"reflect"
+	"sort"
+)
+
+/// ------------------------------------------------------------------------ ///
+/// -------------------------- Public definitions -------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// scheme hash table comparing its keys structurally
+type HashTable struct {
+	entries map[uint64][]hashEntry // entries by the hashes of their keys
+	size    int                    // number of the entries
+}
+
+// scheme vector, a fixed length sequence indexed in constant time
+type Vector struct {
+	Items     []Expression
+	Immutable bool // true for vector literals
+}
+
+// type of a record, created by a record type definition
+type RecordType struct {
+	Name   string   // name of the record type
+	Fields []string // names of the fields of the records, in order
+}
+
+// scheme record, an instance of a record type
+type Record struct {
+	Type *RecordType
+	Vals []Expression // values of the fields, in the order of Type.Fields
+}
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------------- Inner structure ---------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// a key of a hash table with its value
+type hashEntry struct {
+	key Expression
+	val Expression
+}
+
+// number of the expressions inside a key its hash looks at, so hashing
+// huge and cyclic keys is bounded
+const hashedExprs = 32
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------- Public functions and methods --------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// creates an empty hash table
+func NewHashTable() *HashTable {
+	return &HashTable{entries: make(map[uint64][]hashEntry)}
+}
+
+// returns the value of the key and whether the key is in the table
+func (h *HashTable) Get(key Expression) (val Expression, ok bool) {
+	for _, entry := range h.entries[hashKey(key)] {
+		if Equal(entry.key, key) {
+			return entry.val, true
+		}
+	}
+
+	return nil, false
+}
+
+// sets the value of the key
+func (h *HashTable) Set(key Expression, val Expression) {
+	hash := hashKey(key)
+	bucket := h.entries[hash]
+	for j := range bucket {
+		if Equal(bucket[j].key, key) {
+			bucket[j] = hashEntry{key: key, val: val}
+			return
+		}
+	}
+
+	h.entries[hash] = append(bucket, hashEntry{key: key, val: val})
+	h.size++
+}
+
+// removes the key from the table, if it is in it
+func (h *HashTable) Delete(key Expression) {
+	hash := hashKey(key)
+	bucket := h.entries[hash]
+	for j := range bucket {
+		if !Equal(bucket[j].key, key) {
+			continue
+		}
+
+		if len(bucket) == 1 {
+			delete(h.entries, hash)
+		} else {
+			h.entries[hash] = append(bucket[:j:j], bucket[j+1:]...)
+		}
+		h.size--
+		return
+	}
+}
+
+// returns the number of keys in the table
+func (h *HashTable) Len() int {
+	return h.size
+}
+
+// returns the entries of the table as (key . value) pairs
+// sorted by the printed form of their keys so the order is deterministic
+func (h *HashTable) Pairs() []Expression {
+	type printedEntry struct {
+		hashEntry
+		printed string
+	}
+
+	entries := make([]printedEntry, 0, h.size)
+	for _, bucket := range h.entries {
+		for _, entry := range bucket {
+			entries = append(entries, printedEntry{entry, entry.key.String(1)})
+		}
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].printed < entries[j].printed })
+
+	res := make([]Expression, len(entries))
+	for i, entry := range entries {
+		res[i] = Cons(entry.key, entry.val)
+	}
+
+	return res
+}
+
+// creates a mutable vector of the given items
+func NewVector(items []Expression) *Vector {
+	return &Vector{Items: items}
+}
+
+/// ------------------------------------------------------------------------ ///
+/// -------------------- Private functions and methods --------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// returns the hash of a key of a hash table, keys which are Equal have
+// the same hash, the expressions Equal compares only to themselves, e.g.
+// procedures, records, ports and promises, are hashed by their identity
+func hashKey(key Expression) uint64 {
+	budget := hashedExprs
+	return hashExpr(key, &budget)
+}
+
+// returns the hash of the expression looking at no more than
+// the budget of the expressions inside it
+func hashExpr(expr Expression, budget *int) uint64 {
+	*budget--
+	if *budget < 0 {
+		return 0
+	}
+
+	switch ex := expr.(type) {
+	case *Number:
+		return hashNumber(ex)
+
+	case *String:
+		return hashString(1, string(ex.Val))
+
+	case *Char:
+		return hashMix(2, uint64(ex.Val))
+
+	case *Boolean:
+		if ex.Val {
+			return hashMix(3, 1)
+		}
+		return hashMix(3, 0)
+
+	case *Symbol:
+		if name, isName := AsSymbolName(ex); isName {
+			return hashString(4, name)
+		}
+		return hashMix(hashString(5, ex.val), uint64(ex.qlevel)) // null symbols
+
+	case *Pair:
+		// the cdrs of lists are hashed in a loop like Equal compares them
+		hash := uint64(6)
+		for {
+			hash = hashMix(hash, hashExpr(ex.Car, budget))
+			next, isPair := ex.Cdr.(*Pair)
+			if !isPair {
+				return hashMix(hash, hashExpr(ex.Cdr, budget))
+			}
+			if *budget < 0 {
+				return hash
+			}
+			ex = next
+		}
+
+	case *Vector:
+		hash := hashMix(7, uint64(len(ex.Items)))
+		for _, item := range ex.Items {
+			if *budget < 0 {
+				break
+			}
+			hash = hashMix(hash, hashExpr(item, budget))
+		}
+		return hash
+
+	case *HashTable:
+		return hashMix(8, uint64(ex.Len())) // the order of the entries doesn't matter
+
+	case *ExprList:
+		hash := hashMix(9, uint64(len(ex.Lst)))
+		for _, item := range ex.Lst {
+			if *budget < 0 {
+				break
+			}
+			hash = hashMix(hash, hashExpr(item, budget))
+		}
+		if ex.Tail != nil {
+			hash = hashMix(hash, hashExpr(ex.Tail, budget))
+		}
+		return hash
+	}
+
+	if val := reflect.ValueOf(expr); val.Kind() == reflect.Ptr {
+		return hashMix(10, uint64(val.Pointer()))
+	}
+
+	return 0
+}
+
+// returns the hash of the number, the same for numbers with the same
+// value and exactness
+func hashNumber(num *Number) uint64 {
+	hash := uint64(11)
+	if num.Inexact {
+		hash = 12
+	}
+
+	switch {
+	case num.Exact != nil:
+		return hashString(hash, num.Exact.String())
+
+	case num.Ratio != nil:
+		return hashString(hash, num.Ratio.String())
+
+	case math.IsNaN(num.Val):
+		return hashMix(hash, 0x7ff8000000000001) // NaNs are the same as each other
+
+	case num.Val == 0:
+		return hashMix(hash, 0) // -0.0 is the same as 0.0
+	}
+
+	return hashMix(hash, math.Float64bits(num.Val))
+}
+
+// returns the hash mixed with the bytes of the string
+func hashString(hash uint64, str string) uint64 {
+	for j := 0; j < len(str); j++ {
+		hash = hashMix(hash, uint64(str[j]))
+	}
+
+	return hashMix(hash, uint64(len(str)))
+}
+
+// returns the hash mixed with the value, a step of FNV-1a
+func hashMix(hash uint64, val uint64) uint64 {
+	return (hash ^ val) * 1099511628211
+}
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------------- String() methods --------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// printed as `#hash((<key> . <value>) ...)` which can be read back
+func (h *HashTable) String(_ int) string {
+	return printString(h, 0)
+}
+
+// printed as `#(<items> ...)` which can be read back
+func (v *Vector) String(_ int) string {
+	return printString(v, 0)
+}
+
+func (rt *RecordType) String(_ int) string {
+	return "#<record-type:" + rt.Name + ">"
+}
+
+// printed as `#<record:<type> <field>=<value> ...>`
+func (r *Record) String(_ int) string {
+	return printString(r, 0)
+}
@@ -0,0 +1,286 @@
+package parser
+
+import "math"
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------------- Inner structure ---------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// number of compound expressions compared before the ones being compared
+// are remembered, only data with more of them can be cyclic
+const cycleCheckAfter = 1000
+
+// state of a comparison of two expressions
+type equality struct {
+	steps int                    // number of compound expressions compared so far
+	seen  map[[2]Expression]bool // the compound expressions being compared, once there are many
+}
+
+// state of a copy of an expression
+type cloning struct {
+	copies map[Expression]Expression // the copies of the compound expressions copied so far
+}
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------- Public functions and methods --------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// tests whether the two expressions are structurally equal like equal? does,
+// numbers with the same value and exactness, strings with the same characters,
+// pairs, vectors, hash tables and lists of code with equal insides,
+// other expressions are equal only to themselves
+// cyclic data is equal if its infinite unfoldings are
+func Equal(lhs Expression, rhs Expression) bool {
+	return (&equality{}).equal(lhs, rhs)
+}
+
+// returns a copy of the expression and the mutable data inside it, pairs,
+// strings, vectors, hash tables, records and lists of code, the parts
+// shared in the expression and its cycles are shared in the copy as well
+// other expressions are returned as they are
+func Clone(expr Expression) Expression {
+	return (&cloning{copies: map[Expression]Expression{}}).clone(expr)
+}
+
+/// ------------------------------------------------------------------------ ///
+/// -------------------- Private functions and methods --------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// tests whether the two expressions are structurally equal
+func (eq *equality) equal(lhs Expression, rhs Expression) bool {
+	// the cdrs of lists are compared in a loop so long lists don't nest calls
+	for {
+		if lhs == rhs {
+			return true
+		}
+
+		l, isPair := lhs.(*Pair)
+		r, isOtherPair := rhs.(*Pair)
+		if !isPair || !isOtherPair {
+			break
+		}
+
+		if eq.isSeen(l, r) {
+			return true
+		}
+
+		if !eq.equal(l.Car, r.Car) {
+			return false
+		}
+
+		lhs, rhs = l.Cdr, r.Cdr
+	}
+
+	switch l := lhs.(type) {
+	case *Number:
+		r, isNum := rhs.(*Number)
+		return isNum && numbersEqv(l, r)
+
+	case *String:
+		r, isStr := rhs.(*String)
+		return isStr && string(l.Val) == string(r.Val)
+
+	case *Char:
+		r, isChar := rhs.(*Char)
+		return isChar && l.Val == r.Val
+
+	case *Boolean:
+		r, isBool := rhs.(*Boolean)
+		return isBool && l.Val == r.Val
+
+	case *Symbol:
+		r, isSym := rhs.(*Symbol)
+		if !isSym {
+			return false
+		}
+
+		lname, lok := AsSymbolName(l)
+		rname, rok := AsSymbolName(r)
+		if lok || rok {
+			return lok && rok && lname == rname
+		}
+
+		return *l == *r // null symbols
+
+	case *Vector:
+		r, isVec := rhs.(*Vector)
+		if !isVec || len(l.Items) != len(r.Items) {
+			return false
+		}
+
+		if eq.isSeen(l, r) {
+			return true
+		}
+
+		for i := range l.Items {
+			if !eq.equal(l.Items[i], r.Items[i]) {
+				return false
+			}
+		}
+
+		return true
+
+	case *HashTable:
+		r, isHash := rhs.(*HashTable)
+		if !isHash || l.Len() != r.Len() {
+			return false
+		}
+
+		if eq.isSeen(l, r) {
+			return true
+		}
+
+		for _, entry := range l.Pairs() {
+			pair := entry.(*Pair)
+			val, isKey := r.Get(pair.Car)
+			if !isKey || !eq.equal(pair.Cdr, val) {
+				return false
+			}
+		}
+
+		return true
+
+	case *ExprList:
+		r, isLst := rhs.(*ExprList)
+		if !isLst || len(l.Lst) != len(r.Lst) {
+			return false
+		}
+
+		// the dotted tails of code lists, if any
+		if (l.Tail == nil) != (r.Tail == nil) || l.Tail != nil && !eq.equal(l.Tail, r.Tail) {
+			return false
+		}
+
+		for i := range l.Lst {
+			if !eq.equal(l.Lst[i], r.Lst[i]) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	return lhs == rhs
+}
+
+// tests whether the two compound expressions are already being compared,
+// which makes them equal unless the comparison finds a difference elsewhere,
+// and remembers them otherwise once enough expressions have been compared
+func (eq *equality) isSeen(lhs Expression, rhs Expression) bool {
+	eq.steps++
+	if eq.steps < cycleCheckAfter {
+		return false
+	}
+
+	if eq.seen == nil {
+		eq.seen = map[[2]Expression]bool{}
+	}
+
+	key := [2]Expression{lhs, rhs}
+	if eq.seen[key] {
+		return true
+	}
+
+	eq.seen[key] = true
+	return false
+}
+
+// tests whether the two numbers have the same value and exactness
+// NaNs are the same as each other
+func numbersEqv(lhs *Number, rhs *Number) bool {
+	if lhs.Inexact != rhs.Inexact {
+		return false
+	}
+
+	if lhs.Exact != nil || rhs.Exact != nil {
+		return lhs.Exact != nil && rhs.Exact != nil && lhs.Exact.Cmp(rhs.Exact) == 0
+	}
+
+	if lhs.Ratio != nil || rhs.Ratio != nil {
+		return lhs.Ratio != nil && rhs.Ratio != nil && lhs.Ratio.Cmp(rhs.Ratio) == 0
+	}
+
+	return lhs.Val == rhs.Val || math.IsNaN(lhs.Val) && math.IsNaN(rhs.Val)
+}
+
+// returns the copy of the expression, the same copy for the same expression
+func (c *cloning) clone(expr Expression) Expression {
+	if copied, isCopied := c.copies[expr]; isCopied {
+		return copied
+	}
+
+	switch ex := expr.(type) {
+	case *Pair:
+		// the cdrs of lists are copied in a loop so long lists don't nest calls
+		res := &Pair{}
+		c.copies[ex] = res
+		for last := res; ; {
+			last.Car = c.clone(ex.Car)
+
+			next, isPair := ex.Cdr.(*Pair)
+			if !isPair {
+				last.Cdr = c.clone(ex.Cdr)
+				break
+			}
+
+			if copied, isCopied := c.copies[next]; isCopied {
+				last.Cdr = copied
+				break
+			}
+
+			pair := &Pair{}
+			c.copies[next] = pair
+			last.Cdr, last, ex = pair, pair, next
+		}
+
+		return res
+
+	case *String:
+		res := &String{Val: append([]rune{}, ex.Val...), Immutable: ex.Immutable}
+		c.copies[ex] = res
+		return res
+
+	case *Vector:
+		res := &Vector{Items: make([]Expression, len(ex.Items)), Immutable: ex.Immutable}
+		c.copies[ex] = res
+		for j, item := range ex.Items {
+			res.Items[j] = c.clone(item)
+		}
+
+		return res
+
+	case *HashTable:
+		res := NewHashTable()
+		c.copies[ex] = res
+		for _, entry := range ex.Pairs() {
+			pair := entry.(*Pair)
+			res.Set(c.clone(pair.Car), c.clone(pair.Cdr))
+		}
+
+		return res
+
+	case *Record:
+		res := &Record{Type: ex.Type, Vals: make([]Expression, len(ex.Vals))}
+		c.copies[ex] = res
+		for j, val := range ex.Vals {
+			res.Vals[j] = c.clone(val)
+		}
+
+		return res
+
+	case *ExprList:
+		res := &ExprList{Lst: make([]interface{ Expression }, len(ex.Lst)), Qlevel: ex.Qlevel, Pos: ex.Pos}
+		c.copies[ex] = res
+		for j, item := range ex.Lst {
+			res.Lst[j] = c.clone(item)
+		}
+
+		if ex.Tail != nil {
+			res.Tail = c.clone(ex.Tail)
+		}
+
+		return res
+	}
+
+	return expr
+}
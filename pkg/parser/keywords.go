@@ -0,0 +1,68 @@
+package parser
+
+import "sync"
+
+/// ------------------------------------------------------------------------ ///
+/// -------------------------- Public definitions -------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// scheme keyword like `#:key`, keywords are interned by a keyword table so
+// keywords with the same name from the same table are the same value
+type Keyword struct {
+	Name string // name of the keyword without the `#:` prefix
+}
+
+// every keyword created so far by the users of the table by name,
+// e.g. an interpreter and the parsers of its input share one
+type KeywordTable struct {
+	mu     sync.Mutex
+	byName map[string]*Keyword
+}
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------- Public functions and methods --------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// creates an empty keyword table
+func NewKeywordTable() *KeywordTable {
+	return &KeywordTable{byName: make(map[string]*Keyword)}
+}
+
+// returns the keyword of the table with the given name, creating it if needed
+func (kt *KeywordTable) Keyword(name string) *Keyword {
+	kt.mu.Lock()
+	defer kt.mu.Unlock()
+
+	kw, isFound := kt.byName[name]
+	if !isFound {
+		kw = &Keyword{Name: name}
+		kt.byName[name] = kw
+	}
+
+	return kw
+}
+
+// sets the table interning the keywords the parser reads,
+// every parser has a table of its own unless it is set
+func (p *Parser) SetKeywordTable(kt *KeywordTable) {
+	p.keywords = kt
+}
+
+// returns the name of the keyword in the expression, if it is one
+func AsKeywordName(expr Expression) (name string, ok bool) {
+	kw, isKw := expr.(*Keyword)
+	if !isKw {
+		return "", false
+	}
+
+	return kw.Name, true
+}
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------------- String() methods --------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// printed as `#:<name>` whatever the quotes
+func (kw *Keyword) String(_ int) string {
+	return "#:" + kw.Name
+}
@@ -0,0 +1,276 @@
+package parser
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+/// ------------------------------------------------------------------------ ///
+/// -------------------------- Public definitions -------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// how numbers are printed, the zero value prints them the way they are read
+type NumberFormat struct {
+	Digits    int    // significant digits reals are rounded to, 0 for the shortest form reading back the same
+	Separator string // put between the groups of three digits of integers, e.g. ",", empty for none
+}
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------------- Inner structure ---------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// the largest magnitude up to which every integer is exactly a float64
+const maxExactFloatVal = 1 << 53
+
+var maxExactFloat = big.NewInt(maxExactFloatVal)
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------- Public functions and methods --------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// parses a number the way the reader does, e.g. `42`, `-1.5e3`, `1/2`,
+// `+inf.0`, `+nan.0` or `#xff` with one of the radix prefixes #b #o #d #x
+func ParseNumber(str string) (ex Expression, err *Error) {
+	return ParseNumberRadix(str, 10)
+}
+
+// parses a number with the given default radix, a prefix overrides it
+func ParseNumberRadix(str string, radix int) (ex Expression, err *Error) {
+	num, isNum := parseNumber(str, radix)
+	if !isNum {
+		return &Void, &Error{Val: "read-syntax: bad number `" + str + "`"}
+	}
+
+	return num, nil
+}
+
+// returns the number as an exact integer or rational of the same value,
+// ok is false if it has no exact value, i.e. it is infinite or NaN
+func ToExact(num *Number) (res *Number, ok bool) {
+	if !num.Inexact {
+		return num, true
+	}
+
+	if math.IsInf(num.Val, 0) || math.IsNaN(num.Val) {
+		return nil, false
+	}
+
+	return NewRational(new(big.Rat).SetFloat64(num.Val)), true
+}
+
+// returns the number as an inexact real, the closest one to an exact number
+func ToInexact(num *Number) *Number {
+	if num.Inexact {
+		return num
+	}
+
+	return NewReal(num.Val)
+}
+
+// returns the number as it is printed in the given radix
+// radixes other than 10 only support integers and infinities
+func FormatNumber(val float64, radix int) (str string, ok bool) {
+	switch {
+	case math.IsNaN(val):
+		return "+nan.0", true
+	case math.IsInf(val, 1):
+		return "+inf.0", true
+	case math.IsInf(val, -1):
+		return "-inf.0", true
+	case radix == 10:
+		return strconv.FormatFloat(val, 'f', -1, 64), true
+	case val != math.Trunc(val):
+		return "", false
+	}
+
+	num, _ := big.NewFloat(val).Int(nil)
+	return num.Text(radix), true
+}
+
+// returns the number as it is printed in radix 10 in the given format
+// the digits of exact numbers are grouped and reals are rounded
+func FormatNumberWith(num *Number, format NumberFormat) string {
+	if num.Exact != nil {
+		return groupDigits(num.Exact.String(), format.Separator)
+	}
+
+	if num.Ratio != nil {
+		return groupDigits(num.Ratio.Num().String(), format.Separator) + "/" + groupDigits(num.Ratio.Denom().String(), format.Separator)
+	}
+
+	val := num.Val
+	if !num.Inexact {
+		str, _ := FormatNumber(val, 10)
+		return groupDigits(str, format.Separator)
+	}
+
+	isFinite := !math.IsInf(val, 0) && !math.IsNaN(val)
+	if format.Digits > 0 && isFinite {
+		val, _ = strconv.ParseFloat(strconv.FormatFloat(val, 'g', format.Digits, 64), 64)
+	}
+
+	// inexact integers keep a fraction so they aren't read back as exact
+	str, _ := FormatNumber(val, 10)
+	if isFinite && val == math.Trunc(val) {
+		str += ".0"
+	}
+
+	return str
+}
+
+/// ------------------------------------------------------------------------ ///
+/// -------------------- Private functions and methods --------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// returns the number in the string and whether it is a number
+// integers and fractions are exact and decimals inexact unless
+// a #e or #i prefix says otherwise
+func parseNumber(str string, radix int) (num *Number, ok bool) {
+	exactness := byte(0)
+	for len(str) >= 2 && str[0] == '#' {
+		switch str[1] {
+		case 'b', 'B':
+			radix = 2
+		case 'o', 'O':
+			radix = 8
+		case 'd', 'D':
+			radix = 10
+		case 'x', 'X':
+			radix = 16
+		case 'e', 'E', 'i', 'I':
+			exactness = str[1] | 0x20 // in lower case
+		default:
+			return nil, false
+		}
+		str = str[2:]
+	}
+
+	num, ok = parseReal(str, radix)
+	switch {
+	case !ok:
+		return nil, false
+	case exactness == 'e':
+		return ToExact(num)
+	case exactness == 'i':
+		return ToInexact(num), true
+	}
+
+	return num, true
+}
+
+// returns the number without a prefix in the string and whether it is a number
+func parseReal(str string, radix int) (num *Number, ok bool) {
+	switch str {
+	case "+inf.0":
+		return NewReal(math.Inf(1)), true
+	case "-inf.0":
+		return NewReal(math.Inf(-1)), true
+	case "+nan.0", "-nan.0":
+		return NewReal(math.NaN()), true
+	}
+
+	if slash := strings.IndexByte(str, '/'); slash != -1 {
+		num, isNum := parseInteger(str[:slash], radix, true)
+		den, isDen := parseInteger(str[slash+1:], radix, false)
+		if !isNum || !isDen || den.Sign() == 0 {
+			return nil, false
+		}
+
+		return NewRational(new(big.Rat).SetFrac(num, den)), true
+	}
+
+	if radix != 10 {
+		val, isNum := parseInteger(str, radix, true)
+		if !isNum {
+			return nil, false
+		}
+
+		return NewInteger(val), true
+	}
+
+	return parseDecimal(str)
+}
+
+// parses an integer written in the given radix, with an optional sign
+func parseInteger(str string, radix int, signed bool) (val *big.Int, ok bool) {
+	digits := str
+	if signed && len(digits) > 0 && (digits[0] == '+' || digits[0] == '-') {
+		digits = digits[1:]
+	}
+
+	if len(digits) == 0 || strings.ContainsAny(digits, "+-_") {
+		return nil, false
+	}
+
+	return new(big.Int).SetString(str, radix)
+}
+
+// parses a decimal number like `-12`, `.5` or `1.5e-3`
+// the number is an exact integer unless it has a fraction or an exponent
+func parseDecimal(str string) (num *Number, ok bool) {
+	digits, isReal := 0, false
+	for i, r := range str {
+		switch {
+		case r >= '0' && r <= '9':
+			digits++
+		case r == '+' || r == '-':
+			if i != 0 && str[i-1] != 'e' && str[i-1] != 'E' {
+				return nil, false
+			}
+		case r == '.' || r == 'e' || r == 'E':
+			isReal = true
+		default:
+			return nil, false // e.g. the `inf` and `0x` accepted by strconv
+		}
+	}
+
+	if digits == 0 {
+		return nil, false
+	}
+
+	if !isReal {
+		val, isNum := new(big.Int).SetString(str, 10)
+		if !isNum {
+			return nil, false
+		}
+
+		return NewInteger(val), true
+	}
+
+	val, err := strconv.ParseFloat(str, 64)
+	if err != nil && !errors.Is(err, strconv.ErrRange) {
+		return nil, false
+	}
+
+	return NewReal(val), true
+}
+
+// returns the digits of the integer with the separator between
+// every group of three of them, counting from the right
+func groupDigits(str string, separator string) string {
+	sign := ""
+	if strings.HasPrefix(str, "-") {
+		sign, str = "-", str[1:]
+	}
+
+	if separator == "" || len(str) <= 3 {
+		return sign + str
+	}
+
+	var sb strings.Builder
+	sb.WriteString(sign)
+	first := len(str) % 3
+	if first == 0 {
+		first = 3
+	}
+	sb.WriteString(str[:first])
+	for i := first; i < len(str); i += 3 {
+		sb.WriteString(separator)
+		sb.WriteString(str[i : i+3])
+	}
+
+	return sb.String()
+}
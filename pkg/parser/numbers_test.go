@@ -0,0 +1,216 @@
+package parser
+
+import (
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+// number of random numbers the round-trip tests check
+const roundTrips = 2000
+
+func TestParseNumber(t *testing.T) {
+	tests := []struct {
+		str  string
+		want string // as the printer prints the number, empty if it isn't one
+	}{
+		{"42", "42"},
+		{"-17", "-17"},
+		{"+5", "5"},
+		{"1.5", "1.5"},
+		{"-1.5e3", "-1500.0"},
+		{".5", "0.5"},
+		{"1e21", "1000000000000000000000.0"},
+		{"1/2", "1/2"},
+		{"-6/4", "-3/2"},
+		{"4/2", "2"},
+		{"123456789012345678901234567890", "123456789012345678901234567890"},
+		{"#xff", "255"},
+		{"#XFF", "255"},
+		{"#b-101", "-5"},
+		{"#o777", "511"},
+		{"#d10", "10"},
+		{"#x1/2", "1/2"},
+		{"#e1.5", "3/2"},
+		{"#i1/2", "0.5"},
+		{"#x#e10", "16"},
+		{"+inf.0", "+inf.0"},
+		{"-inf.0", "-inf.0"},
+		{"+nan.0", "+nan.0"},
+		{"abc", ""},
+		{"1/0", ""},
+		{"#b2", ""},
+		{"1.2.3", ""},
+		{"", ""},
+	}
+
+	for _, test := range tests {
+		num, err := ParseNumber(test.str)
+		if test.want == "" {
+			if err == nil {
+				t.Errorf("ParseNumber(%q) = %s, want an error", test.str, WriteString(num))
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("ParseNumber(%q) failed: %s", test.str, err.Val)
+			continue
+		}
+
+		if got := WriteString(num); got != test.want {
+			t.Errorf("ParseNumber(%q) = %s, want %s", test.str, got, test.want)
+		}
+	}
+}
+
+func TestParseNumberRadix(t *testing.T) {
+	tests := []struct {
+		str   string
+		radix int
+		want  string
+	}{
+		{"ff", 16, "255"},
+		{"-11111111", 2, "-255"},
+		{"17", 8, "15"},
+		{"1/11", 2, "1/3"},
+		{"#d10", 16, "10"},
+		{"fffffffffffffffffffffffffffff", 16, "83076749736557242056487941267521535"},
+	}
+
+	for _, test := range tests {
+		num, err := ParseNumberRadix(test.str, test.radix)
+		if err != nil {
+			t.Errorf("ParseNumberRadix(%q, %d) failed: %s", test.str, test.radix, err.Val)
+			continue
+		}
+
+		if got := WriteString(num); got != test.want {
+			t.Errorf("ParseNumberRadix(%q, %d) = %s, want %s", test.str, test.radix, got, test.want)
+		}
+	}
+
+	if _, err := ParseNumberRadix("18", 8); err == nil {
+		t.Errorf("ParseNumberRadix(%q, 8) accepted a digit of radix 10", "18")
+	}
+}
+
+// the printed numbers read back as the same numbers, by ParseNumber and
+// by the reader
+func TestNumberRoundTrip(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for n := 0; n < roundTrips; n++ {
+		num := randomNumber(rnd)
+		printed := WriteString(num)
+
+		parsed, err := ParseNumber(printed)
+		if err != nil {
+			t.Fatalf("%s doesn't parse back: %s", printed, err.Val)
+		}
+		if !Equal(parsed, num) {
+			t.Fatalf("%s parses back as %s", printed, WriteString(parsed))
+		}
+
+		read, err := NewParser(printed).Next()
+		if err != nil {
+			t.Fatalf("%s isn't read back: %s", printed, err.Val)
+		}
+		if !Equal(read, num) {
+			t.Fatalf("%s is read back as %s", printed, WriteString(read))
+		}
+	}
+}
+
+// the integers written in every radix parse back as the same integers
+// with the radix given and with its prefix
+func TestNumberRadixRoundTrip(t *testing.T) {
+	prefixes := map[int]string{2: "#b", 8: "#o", 10: "#d", 16: "#x"}
+	rnd := rand.New(rand.NewSource(2))
+	for n := 0; n < roundTrips; n++ {
+		num := randomInteger(rnd)
+		val := num.Exact
+		if val == nil {
+			val, _ = big.NewFloat(num.Val).Int(nil)
+		}
+
+		for radix, prefix := range prefixes {
+			text := val.Text(radix)
+			if num.Exact == nil {
+				formatted, ok := FormatNumber(num.Val, radix)
+				if !ok || formatted != text {
+					t.Fatalf("FormatNumber(%s, %d) = %q, %t, want %q", WriteString(num), radix, formatted, ok, text)
+				}
+			}
+
+			parsed, err := ParseNumberRadix(text, radix)
+			if err != nil || !Equal(parsed, num) {
+				t.Fatalf("%s in radix %d is %q which parses back as %s", WriteString(num), radix, text, WriteString(parsed))
+			}
+
+			parsed, err = ParseNumber(prefix + text)
+			if err != nil || !Equal(parsed, num) {
+				t.Fatalf("%s parses back as %s", prefix+text, WriteString(parsed))
+			}
+		}
+	}
+}
+
+func TestFormatNumber(t *testing.T) {
+	tests := []struct {
+		val   float64
+		radix int
+		want  string
+		ok    bool
+	}{
+		{255, 16, "ff", true},
+		{-5, 2, "-101", true},
+		{0, 8, "0", true},
+		{1.5, 10, "1.5", true},
+		{1.5, 16, "", false},
+		{math.Inf(1), 2, "+inf.0", true},
+		{math.Inf(-1), 16, "-inf.0", true},
+		{math.NaN(), 8, "+nan.0", true},
+	}
+
+	for _, test := range tests {
+		got, ok := FormatNumber(test.val, test.radix)
+		if got != test.want || ok != test.ok {
+			t.Errorf("FormatNumber(%v, %d) = %q, %t, want %q, %t", test.val, test.radix, got, ok, test.want, test.ok)
+		}
+	}
+}
+
+// returns a random number, an integer that fits in a float64 or a bigger
+// one, a fraction or a real
+func randomNumber(rnd *rand.Rand) *Number {
+	switch rnd.Intn(3) {
+	case 0:
+		return randomInteger(rnd)
+	case 1:
+		return NewRational(big.NewRat(rnd.Int63n(2000000)-1000000, rnd.Int63n(1000000)+1))
+	}
+
+	switch rnd.Intn(10) {
+	case 0:
+		return NewReal(float64(rnd.Int63n(1000) - 500)) // an inexact integer
+	case 1:
+		return NewReal(math.Inf(1 - 2*rnd.Intn(2)))
+	}
+
+	return NewReal(rnd.NormFloat64() * math.Pow(10, float64(rnd.Intn(60)-30)))
+}
+
+// returns a random exact integer, one of 2 fitting in a float64
+func randomInteger(rnd *rand.Rand) *Number {
+	if rnd.Intn(2) == 0 {
+		return NewNumber(float64(rnd.Int63n(1<<53) - 1<<52))
+	}
+
+	val := new(big.Int).Rand(rnd, new(big.Int).Lsh(big.NewInt(1), uint(54+rnd.Intn(200))))
+	if rnd.Intn(2) == 0 {
+		val.Neg(val)
+	}
+
+	return NewInteger(val)
+}
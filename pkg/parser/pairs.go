@@ -0,0 +1,152 @@
+package parser
+
+/// ------------------------------------------------------------------------ ///
+/// -------------------------- Public definitions -------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// scheme pair, the building block of lists
+// a list is a chain of pairs whose last cdr is the null symbol
+type Pair struct {
+	Car Expression
+	Cdr Expression
+}
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------- Public functions and methods --------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// creates a pair of the given expressions
+func Cons(car Expression, cdr Expression) *Pair {
+	return &Pair{Car: car, Cdr: cdr}
+}
+
+// creates a list of the given items
+func List(items ...Expression) Expression {
+	return ListWithTail(items, &NullSym)
+}
+
+// creates a chain of pairs of the given items ending with the tail
+// the result is an improper list unless the tail is a list
+func ListWithTail(items []Expression, tail Expression) Expression {
+	res := tail
+	for i := len(items) - 1; i >= 0; i-- {
+		res = Cons(items[i], res)
+	}
+
+	return res
+}
+
+// returns the items of the given expression if it is a proper list
+// without the terminating null symbol; the null symbol is the empty list
+func AsList(expr Expression) (items []Expression, ok bool) {
+	items = []Expression{}
+	for !IsNullSym(expr) {
+		pair, isPair := expr.(*Pair)
+		if !isPair {
+			return nil, false
+		}
+
+		items = append(items, pair.Car)
+		expr = pair.Cdr
+	}
+
+	return items, true
+}
+
+// returns the value of a quoted expression of the parsed code, e.g. the
+// list of the pairs of '(1 2) or the (quote b) list of ”b
+func Datum(expr Expression) Expression {
+	return datum(expr, 1)
+}
+
+// returns the given code as data like the quote special form does,
+// e.g. the (+ 1 2) code as the list of the symbol + and the numbers
+func Quote(expr Expression) Expression {
+	return datum(expr, 0)
+}
+
+/// ------------------------------------------------------------------------ ///
+/// -------------------- Private functions and methods --------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// returns the data of the expression inside data quoted level times
+// quotes of the expression itself beyond the level become (quote ...) lists
+func datum(expr Expression, level int) Expression {
+	qlevel := level
+	var res Expression = expr
+
+	switch ex := expr.(type) {
+	case *Variable:
+		res = NewSymbol(ex.Val)
+
+	case *Symbol:
+		if IsNullSym(ex) {
+			return ex // the null symbol is read the same way whatever the quotes
+		}
+		if ex.qlevel == 1 && level == 1 {
+			return ex
+		}
+		qlevel = ex.qlevel
+		res = &Symbol{val: ex.val, qlevel: 1}
+
+	case *Number:
+		qlevel = ex.qlevel
+
+	case *Boolean:
+		qlevel = ex.qlevel
+
+	case *ExprList:
+		qlevel = ex.Qlevel
+		if qlevel == level && ex.datum != nil {
+			return ex.datum
+		}
+
+		items := make([]Expression, 0, len(ex.Lst))
+		for _, item := range ex.Lst {
+			items = append(items, datum(item, qlevel))
+		}
+
+		tail := Expression(&NullSym)
+		if len(items) > 0 && qlevel > 0 {
+			tail = items[len(items)-1] // quoted lists end with their tail
+			items = items[:len(items)-1]
+		} else if ex.Tail != nil {
+			tail = datum(ex.Tail, qlevel)
+		}
+
+		res = ListWithTail(items, tail)
+		if qlevel == level {
+			ex.datum = res
+		}
+	}
+
+	for ; qlevel > level; qlevel-- {
+		res = List(NewSymbol("quote"), res)
+	}
+
+	return res
+}
+
+// returns the quoted expression if the pair is a (quote <expression>) list
+func quoted(pair *Pair) (expr Expression, isQuote bool) {
+	if name, isSym := AsSymbolName(pair.Car); !isSym || name != "quote" {
+		return nil, false
+	}
+
+	rest, isPair := pair.Cdr.(*Pair)
+	if !isPair || !IsNullSym(rest.Cdr) {
+		return nil, false
+	}
+
+	return rest.Car, true
+}
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------------- String() methods --------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// printed as a quoted list, (quote <expression>) items are printed
+// as '<expression> and improper lists end with ` . <tail>`
+func (pair *Pair) String(qlevel int) string {
+	return printString(pair, qlevel)
+}
@@ -1,10 +1,27 @@
 // A parser that uses the lexer package and creates
 // a meaningful expression out of the lexed tokens
+//
+// Every expression is a pointer to one of the types of the package,
+// so programs examine them with a type switch:
+//
+//	code    *ExprList (a form, quoted if its Qlevel isn't 0), *Variable
+//	data    *Pair, NullSym, *Symbol, *Number, *String, *Char, *Boolean,
+//	        *Keyword, *Vector, *HashTable
+//	values  *Procedure, *Lambda, *Promise, *Record, *RecordType, *Port,
+//	        *StringBuilder, *MultipleValues, *VoidExpr, *EOFExpr
+//
+// Data is built with the New... functions, List, ListWithTail and Cons,
+// and turned into code with Unquote. The As... functions return the
+// values of data, Datum and Quote turn parsed code into data. Walk and
+// Rewrite traverse the expressions inside lists, pairs and vectors, Equal
+// compares expressions structurally and Clone copies their mutable data.
 package parser
 
 import (
 	"fmt"
-	"strconv"
+	"io"
+	"math"
+	"math/big"
 	"strings"
 
 	"github.com/dimbata23/golang-scheme-interpreter/pkg/lexer"
@@ -16,7 +33,27 @@ import (
 
 // the parser struct
 type Parser struct {
-	lexer *lexer.Lexer
+	lexer    *lexer.Lexer
+	input    string                   // the text being parsed
+	pos      lexer.Position           // where the last expression or error is in the input
+	end      int                      // offset in bytes of the end of the last token read
+	started  bool                     // whether the position of the expression is known
+	readers  map[string]ReaderHandler // handlers of the custom `#<name>` syntax by the names
+	keywords *KeywordTable            // table interning the keywords read
+	symbols  *SymbolTable             // table recording the names of the symbols read, nil if they aren't
+	limits   Limits                   // limits on the input the parser accepts
+	depth    int                      // nesting depth of the expression being parsed
+	open     int                      // number of brackets open around the expression being parsed
+	recovery bool                     // whether errors are collected and parsing goes on after them
+	skipped  bool                     // whether the rest of the expression with an error was skipped
+	errors   []*Error                 // errors collected in recovery mode
+}
+
+// limits on the input a parser accepts, e.g. for servers parsing untrusted
+// input, a limit of 0 means there is no limit
+type Limits struct {
+	MaxDepth  int // maximum nesting depth of lists, vectors, hash tables and quotes
+	MaxLength int // maximum number of items of a list, vector or hash table
 }
 
 // the basic expression interface
@@ -24,33 +61,55 @@ type Expression interface {
 	String(qlevel int) string // returns string representation of the expression
 }
 
-// scheme number, can be a real or an integer
+// scheme number, an exact integer, an exact rational or an inexact real
 type Number struct {
-	Val    float64
-	qlevel int
+	Val     float64
+	Exact   *big.Int // exact value of an integer too big for Val, nil otherwise
+	Ratio   *big.Rat // exact value of a fraction which isn't an integer, nil otherwise
+	Inexact bool     // whether the number is an inexact real, exact numbers are integers or fractions
+	qlevel  int
 }
 
 // identifier (name) of a scheme variable
 type Variable struct {
 	Val string
+	Pos lexer.Position // where the identifier is in the input, if parsed
 }
 
-// generic scheme list
+// list of parsed code, quoted lists become pairs once evaluated
 type ExprList struct {
 	Lst    []interface{ Expression }
 	Qlevel int
+	Pos    lexer.Position // where the list starts in the input, if parsed
+	Tail   Expression     // expression after a `.` in code, e.g. the rest parameter of (a . rest)
+
+	datum Expression // the pairs of a quoted list once it has been evaluated
 }
 
 // scheme procedure
 type Procedure struct {
-	Fn func(*ExprList) (Expression, *Error)
+	Fn   func(*ExprList) (Expression, *Error)
+	Name string // name the procedure is defined with (if given)
 }
 
 // scheme lambda function
 type Lambda struct {
-	Name   string    // name of the lambda (if given)
-	Params *ExprList // list of parameter names
-	Body   *ExprList // list of expressions inside the body
+	Name     string         // name of the lambda (if given)
+	Params   *ExprList      // list of parameter names
+	Body     *ExprList      // list of expressions inside the body
+	Pos      lexer.Position // where the lambda is defined in the input, if known
+	File     string         // name of the file the lambda is defined in, empty for interpreted input
+	Env      Expression     // environment the lambda is created in, its body is evaluated in it
+	FreeVars []string       // variables the body refers to which the lambda doesn't bind, nil until they are needed
+}
+
+// scheme promise of the value of an expression, the expression is
+// evaluated the first time the promise is forced and its value is kept
+type Promise struct {
+	Expr   Expression // the delayed expression, nil once it is forced
+	Env    Expression // environment the expression is evaluated in
+	Val    Expression // value of the expression once it is forced
+	Forced bool       // whether the value is known
 }
 
 // scheme symbol
@@ -59,17 +118,55 @@ type Symbol struct {
 	qlevel int
 }
 
+// scheme string, a mutable sequence of characters
+type String struct {
+	Val       []rune
+	Immutable bool // true for string literals
+}
+
+// scheme character
+type Char struct {
+	Val rune
+}
+
+// scheme boolean, #t or #f
+type Boolean struct {
+	Val    bool
+	qlevel int
+}
+
+// builder used for constructing large strings piece by piece
+type StringBuilder struct {
+	Builder strings.Builder
+}
+
 // scheme void expression
 type VoidExpr struct{}
 
-var NullSym = Symbol{val: "()", qlevel: 1}  // the scheme null symbol
-var FalseSym = Symbol{val: "#f", qlevel: 1} // the scheme false symbol
-var TrueSym = Symbol{val: "#t", qlevel: 1}  // the scheme true symbol
-var Void VoidExpr = VoidExpr{}              // the scheme void expression
+// scheme end-of-file object, returned by reads at the end of the input
+type EOFExpr struct{}
+
+// multiple values returned by (values ...), never a single value
+type MultipleValues struct {
+	Vals []Expression
+}
+
+var NullSym = Symbol{val: "()", qlevel: 1} // the scheme null symbol
+var False = Boolean{Val: false}            // the scheme false value
+var True = Boolean{Val: true}              // the scheme true value
+var Void VoidExpr = VoidExpr{}             // the scheme void expression
+var EOFObject EOFExpr = EOFExpr{}          // the scheme end-of-file object
+
+// limits of new parsers, deep enough for any code while
+// keeping the recursion of the parser far from exhausting the stack
+var DefaultLimits = Limits{MaxDepth: 10000}
 
 // the error type used by the parser package
 type Error struct {
-	Val string // message about occured the error
+	Val   string         // message about occured the error
+	Stack []string       // procedures being applied when the error occured, innermost first
+	Pos   lexer.Position // where the innermost expression the error occured in starts, if known
+	File  string         // name of the file of the position, empty for interpreted input
 }
 
 // special type used for non-scheme related functionality of the parser
@@ -78,11 +175,13 @@ type SpecialType int
 const (
 	SpecialExit         SpecialType = iota // the (exit) command has been parsed
 	SpecialCloseBracket                    // a lonely `)` has been parsed
+	SpecialDot                             // a `.` has been parsed
 )
 
 // special expression used for non-scheme related functionality
 type SpecialExpr struct {
-	typ SpecialType
+	typ  SpecialType
+	code int // exit code of an (exit) command
 }
 
 /// ------------------------------------------------------------------------ ///
@@ -92,14 +191,252 @@ type SpecialExpr struct {
 // creates a parser from the given input
 func NewParser(input string) *Parser {
 	return &Parser{
-		lexer: lexer.NewLexer(input),
+		lexer:    lexer.NewLexer(input),
+		input:    input,
+		keywords: NewKeywordTable(),
+		limits:   DefaultLimits,
+	}
+}
+
+// creates a parser reading its input from the reader as it is needed
+func NewParserFromReader(r io.Reader) *Parser {
+	return &Parser{
+		lexer:    lexer.NewLexerFromReader(r),
+		keywords: NewKeywordTable(),
+		limits:   DefaultLimits,
 	}
 }
 
 // parses and returns the next expression (ex) or nil when the input has ended
 // can return an error (err) containing information about what went wrong
+// in recovery mode errors aren't returned, they are collected and the
+// expression after the one with the error is returned
 func (p *Parser) Next() (ex Expression, err *Error) {
-	return p.next(0)
+	for {
+		p.started, p.skipped = false, false
+		ex, err = p.next(0)
+		if s, isSpec := ex.(*SpecialExpr); isSpec && s.typ == SpecialDot {
+			ex, err = &Void, &Error{Val: "read-syntax: illegal use of `.`"}
+		} else if isSpec && s.typ == SpecialCloseBracket && p.recovery {
+			continue // the lexer reports the unexpected `)` after it
+		}
+
+		if err == nil || !p.recovery {
+			return ex, err
+		}
+
+		err.Pos = p.pos
+		p.errors = append(p.errors, err)
+	}
+}
+
+// parses and returns the next expression as data, like it was quoted,
+// or nil when the input has ended
+func (p *Parser) NextDatum() (ex Expression, err *Error) {
+	p.started = false
+	ex, err = p.next(1)
+	if s, isSpec := ex.(*SpecialExpr); isSpec && s.typ == SpecialDot {
+		return &Void, &Error{Val: "read-syntax: illegal use of `.`"}
+	} else if isSpec && s.typ == SpecialCloseBracket {
+		return &Void, &Error{Val: "read-syntax: unexpected `)`"}
+	}
+
+	if ex == nil || err != nil {
+		return ex, err
+	}
+
+	return Datum(ex), nil
+}
+
+// sets the limits on the input the parser accepts, exceeding
+// one of them is reported as an error
+func (p *Parser) SetLimits(limits Limits) {
+	p.limits = limits
+}
+
+// turns the recovery mode on or off, in it the rest of a top-level expression
+// is skipped after an error in it and the errors are collected, so all
+// the problems of the input are found in one pass, see Errors
+func (p *Parser) SetRecovery(enabled bool) {
+	p.recovery = enabled
+}
+
+// returns the errors collected in recovery mode in the order they were
+// found, their positions are where they occured
+func (p *Parser) Errors() []*Error {
+	return p.errors
+}
+
+// returns the position of the expression or the error last returned by Next
+func (p *Parser) Pos() lexer.Position {
+	return p.pos
+}
+
+// returns the text of the input the expression last returned by Next
+// was parsed from, e.g. "'(1 2)" for the quoted list, the text isn't kept
+// by parsers reading from a reader so it is empty for them
+func (p *Parser) Text() string {
+	if p.pos.Offset > p.end || p.end > len(p.input) {
+		return ""
+	}
+
+	return p.input[p.pos.Offset:p.end]
+}
+
+// creates a scheme number, an exact integer if the value is an integer
+// represented exactly by a float64 and an inexact real otherwise
+func NewNumber(val float64) *Number {
+	if val != math.Trunc(val) || math.Abs(val) > maxExactFloatVal {
+		return NewReal(val)
+	}
+
+	return &Number{Val: val}
+}
+
+// creates an inexact scheme number
+func NewReal(val float64) *Number {
+	return &Number{Val: val, Inexact: true}
+}
+
+// creates a scheme number holding the exact integer, integers too big
+// to be represented exactly by a float64 keep their exact value
+func NewInteger(val *big.Int) *Number {
+	res := &Number{}
+	res.Val, _ = new(big.Float).SetInt(val).Float64()
+	if val.CmpAbs(maxExactFloat) > 0 {
+		res.Exact = new(big.Int).Set(val)
+	}
+
+	return res
+}
+
+// creates a scheme number holding the exact rational,
+// an integer if the denominator is 1
+func NewRational(val *big.Rat) *Number {
+	if val.IsInt() {
+		return NewInteger(val.Num())
+	}
+
+	res := &Number{Ratio: new(big.Rat).Set(val)}
+	res.Val, _ = val.Float64()
+	return res
+}
+
+// creates a quoted scheme symbol with the given name
+func NewSymbol(name string) *Symbol {
+	return &Symbol{val: name, qlevel: 1}
+}
+
+// creates a (mutable) scheme string
+func NewString(val string) *String {
+	return &String{Val: []rune(val)}
+}
+
+// creates a scheme character
+func NewChar(val rune) *Char {
+	return &Char{Val: val}
+}
+
+// creates a scheme boolean
+func NewBoolean(val bool) *Boolean {
+	return &Boolean{Val: val}
+}
+
+// returns the name of the symbol
+func (s *Symbol) Name() string {
+	return s.val
+}
+
+// lowers the quote level of the given expression by one, turning quoted
+// data back into code, e.g. the value of '(+ 1 2) into the call (+ 1 2)
+// and (quote <data>) lists into quote forms
+func Unquote(expr Expression) Expression {
+	switch ex := expr.(type) {
+	case *Symbol:
+		if IsNullSym(ex) {
+			return ex
+		}
+
+		if ex.qlevel <= 1 {
+			return &Variable{Val: ex.val}
+		}
+
+		return &Symbol{val: ex.val, qlevel: ex.qlevel - 1}
+
+	case *Number:
+		if ex.qlevel == 0 {
+			return ex
+		}
+
+		return &Number{Val: ex.Val, Exact: ex.Exact, Ratio: ex.Ratio, Inexact: ex.Inexact, qlevel: ex.qlevel - 1}
+
+	case *Boolean:
+		if ex.qlevel == 0 {
+			return ex
+		}
+
+		return &Boolean{Val: ex.Val, qlevel: ex.qlevel - 1}
+
+	case *Pair:
+		if quotedExpr, isQuote := quoted(ex); isQuote {
+			return &ExprList{Lst: []interface{ Expression }{&Variable{Val: "quote"}, Unquote(quotedExpr)}}
+		}
+
+		res := &ExprList{Lst: make([]interface{ Expression }, 0)}
+		var rest Expression = ex
+		for pair, isPair := rest.(*Pair); isPair; pair, isPair = rest.(*Pair) {
+			res.Lst = append(res.Lst, Unquote(pair.Car))
+			rest = pair.Cdr
+		}
+
+		if !IsNullSym(rest) {
+			res.Tail = Unquote(rest) // improper lists are code like (a . rest)
+		}
+
+		return res
+
+	case *ExprList:
+		res := &ExprList{Lst: make([]interface{ Expression }, 0, len(ex.Lst)), Qlevel: ex.Qlevel - 1}
+		if res.Qlevel < 0 {
+			res.Qlevel = 0
+		}
+
+		lst := ex.Lst
+		if res.Qlevel == 0 && ex.Qlevel == 1 && len(lst) > 0 {
+			if last := lst[len(lst)-1]; !IsNullSym(last) {
+				res.Tail = Unquote(last)
+			}
+			lst = lst[:len(lst)-1] // code lists don't end with the null symbol
+		}
+
+		for _, item := range lst {
+			res.Lst = append(res.Lst, Unquote(item))
+		}
+
+		return res
+	}
+
+	return expr
+}
+
+// returns the given code as it would be written in the source
+// unlike String() code lists aren't printed as dotted pairs
+func CodeString(expr Expression) string {
+	lst, isLst := expr.(*ExprList)
+	if !isLst || lst.Qlevel > 0 {
+		return expr.String(0)
+	}
+
+	items := make([]string, len(lst.Lst))
+	for i, item := range lst.Lst {
+		items[i] = CodeString(item)
+	}
+
+	if lst.Tail != nil {
+		items = append(items, ".", CodeString(lst.Tail))
+	}
+
+	return "(" + strings.Join(items, " ") + ")"
 }
 
 // tests whether the given expression is the scheme null symbol
@@ -111,25 +448,111 @@ func IsNullSym(expr Expression) bool {
 	return false
 }
 
-// tests whether the given expression is the scheme null symbol
+// tests whether the given expression is the scheme false value
 // note: only #f is false, anything else is considered true in scheme
-func IsFalseSym(expr Expression) bool {
-	if s, isSym := expr.(*Symbol); isSym {
-		return *s == FalseSym
+func IsFalse(expr Expression) bool {
+	if b, isBool := expr.(*Boolean); isBool {
+		return !b.Val
 	}
 
 	return false
 }
 
+// returns the value of the given expression if it is a boolean
+func AsBoolean(expr Expression) (val bool, ok bool) {
+	if b, isBool := expr.(*Boolean); isBool {
+		return b.Val, true
+	}
+
+	return false, false
+}
+
+// tests whether the given expression counts as true in a condition
+// note: only #f is false, anything else is considered true in scheme
+func Truthy(expr Expression) bool {
+	return !IsFalse(expr)
+}
+
+// returns the value of the given expression if it is a number
+func AsNumber(expr Expression) (val float64, ok bool) {
+	if n, isNum := expr.(*Number); isNum {
+		return n.Val, true
+	}
+
+	return 0, false
+}
+
+// returns the value of the given expression if it is an exact integer
+func AsInteger(expr Expression) (val *big.Int, ok bool) {
+	n, isNum := expr.(*Number)
+	switch {
+	case !isNum:
+		return nil, false
+	case n.Exact != nil:
+		return new(big.Int).Set(n.Exact), true
+	case n.Inexact, n.Ratio != nil:
+		return nil, false
+	case n.Val != math.Trunc(n.Val) || math.Abs(n.Val) > maxExactFloatVal:
+		return nil, false
+	}
+
+	val, _ = big.NewFloat(n.Val).Int(nil)
+	return val, true
+}
+
+// returns the value of the given expression if it is a character
+func AsChar(expr Expression) (val rune, ok bool) {
+	if c, isChar := expr.(*Char); isChar {
+		return c.Val, true
+	}
+
+	return 0, false
+}
+
+// returns the contents of the given expression if it is a string
+func AsString(expr Expression) (val string, ok bool) {
+	if s, isStr := expr.(*String); isStr {
+		return string(s.Val), true
+	}
+
+	return "", false
+}
+
+// returns the name of the given expression if it is a symbol
+// note: the null symbol is not considered a symbol
+func AsSymbolName(expr Expression) (name string, ok bool) {
+	s, isSym := expr.(*Symbol)
+	if !isSym || IsNullSym(s) {
+		return "", false
+	}
+
+	return s.val, true
+}
+
 // tests whether the given expression is an (exit) command
 func IsSpecialExit(expr Expression) bool {
 	s, isSpec := expr.(*SpecialExpr)
 	return isSpec && s.typ == SpecialExit
 }
 
-// returns the error message
+// returns the code the (exit [code]) command asked to exit with,
+// 0 if it isn't given or the expression isn't an (exit) command
+func ExitCode(expr Expression) int {
+	if !IsSpecialExit(expr) {
+		return 0
+	}
+
+	return expr.(*SpecialExpr).code
+}
+
+// returns the error message, the messages of errors which occured
+// in a loaded file end with where in the file
 func (e *Error) String() string {
-	return e.Val
+	if e.File == "" || e.Pos.Line == 0 {
+		return e.Val
+	}
+
+	return e.Val + "\n  location: " + e.File + ":" + e.Pos.String()
 }
 
 /// ------------------------------------------------------------------------ ///
@@ -143,60 +566,115 @@ func (p *Parser) next(qlevel int) (ex Expression, err *Error) {
 		return nil, nil
 	}
 
+	if !p.started {
+		p.pos = token.Pos
+		p.started = true
+	}
+	if token.Typ != lexer.TokenError {
+		p.end = token.Pos.Offset + len(token.Val) // the value of errors is their message
+	}
+
 	switch token.Typ {
 
 	case lexer.TokenError:
+		p.pos = token.Pos
 		return &Void, &Error{Val: token.Val}
 
 	case lexer.TokenEOF:
 		return nil, nil
 
 	case lexer.TokenNumber:
-		num, err := strconv.ParseFloat(token.Val, 64)
-		if err != nil {
-			return &Void, &Error{Val: err.Error()}
+		num, isNum := parseNumber(token.Val, 10)
+		if !isNum {
+			p.pos = token.Pos
+			return &Void, &Error{Val: "read-syntax: bad number `" + token.Val + "`"}
 		}
-		return &Number{Val: num, qlevel: qlevel}, nil
+		num.qlevel = qlevel
+		return num, nil
 
 	case lexer.TokenIdentifier:
+		// numbers the lexer doesn't recognize, e.g. `1e3`, `#e1.5` or `#xff`
+		if num, isNum := parseNumber(token.Val, 10); isNum {
+			num.qlevel = qlevel
+			return num, nil
+		}
+
+		// parts surrounded by `|` make the name an identifier whatever it is
+		if strings.ContainsRune(token.Val, '|') {
+			name := unescapeIdentifier(token.Val)
+			p.internName(name)
+			if qlevel == 0 {
+				return &Variable{Val: name, Pos: token.Pos}, nil
+			}
+
+			return &Symbol{val: name, qlevel: qlevel}, nil
+		}
+
+		if strings.HasPrefix(token.Val, "#:") && len(token.Val) > 2 {
+			return p.keywords.Keyword(token.Val[2:]), nil
+		}
+
+		if handler, isCustom := p.readerOf(token.Val); isCustom {
+			return p.readCustom(token, handler, qlevel)
+		}
+
+		p.internName(token.Val)
 		if qlevel == 0 {
-			return &Variable{Val: token.Val}, nil
+			return &Variable{Val: token.Val, Pos: token.Pos}, nil
 		}
 
 		return &Symbol{val: token.Val, qlevel: qlevel}, nil
 
 	case lexer.TokenString:
-		return &Symbol{val: token.Val, qlevel: qlevel}, nil
+		val, err := unescapeString(token.Val[1 : len(token.Val)-1])
+		if err != nil {
+			p.pos = token.Pos
+			return &Void, err
+		}
 
-	case lexer.TokenOpenBracket:
-		res := ExprList{Lst: make([]interface{ Expression }, 0), Qlevel: qlevel}
+		return &String{Val: val, Immutable: true}, nil
 
-		for {
-			inexpr, err := p.next(qlevel)
-			if err != nil {
-				return &Void, err
-			}
+	case lexer.TokenBoolean:
+		return &Boolean{Val: booleanLiterals[token.Val], qlevel: qlevel}, nil
 
-			s, isSpec := inexpr.(*SpecialExpr)
-			if isSpec && s.typ == SpecialCloseBracket {
-				break
-			}
+	case lexer.TokenChar:
+		val, isChar := parseChar(token.Val[2:])
+		if !isChar {
+			p.pos = token.Pos
+			return &Void, &Error{Val: fmt.Sprintf("read-syntax: bad character constant `%s`", token.Val)}
+		}
+		return &Char{Val: val}, nil
 
-			if inexpr == nil {
-				return &Void, &Error{Val: "read-syntax: expected a `)` to close `(`"}
+	case lexer.TokenOpenBracket:
+		items, tail, err := p.nextItems(qlevel, token)
+		if err != nil {
+			return &Void, err
+		}
+
+		res := ExprList{Lst: items, Qlevel: qlevel, Pos: token.Pos}
+
+		if tail != nil {
+			if tailLst, isLst := tail.(*ExprList); isLst && tailLst.Qlevel == qlevel {
+				res.Lst = append(res.Lst, tailLst.Lst...)
+				res.Tail = tailLst.Tail
+			} else if qlevel == 0 {
+				res.Tail = tail
+			} else {
+				res.Lst = append(res.Lst, tail)
 			}
 
-			res.Lst = append(res.Lst, inexpr)
+			return &res, nil
 		}
 
 		if len(res.Lst) == 0 && res.Qlevel == 1 {
 			return &NullSym, nil
 		}
 
-		if len(res.Lst) == 1 && res.Qlevel == 0 {
+		if len(res.Lst) >= 1 && len(res.Lst) <= 2 && res.Qlevel == 0 {
 			s, isSpec := res.Lst[0].(*Variable)
-			if isSpec && s.Val == "exit" {
-				return &SpecialExpr{typ: SpecialExit}, nil
+			code, isCode := exitCode(res.Lst[1:])
+			if isSpec && s.Val == "exit" && isCode {
+				return &SpecialExpr{typ: SpecialExit, code: code}, nil
 			}
 		}
 
@@ -206,12 +684,86 @@ func (p *Parser) next(qlevel int) (ex Expression, err *Error) {
 
 		return &res, nil
 
+	case lexer.TokenHashOpen:
+		items, tail, err := p.nextItems(1, token)
+		if err != nil {
+			return &Void, err
+		}
+
+		if tail != nil {
+			p.pos = token.Pos
+			return &Void, &Error{Val: "read-syntax: illegal use of `.` in `#hash(`"}
+		}
+
+		res := NewHashTable()
+		for _, item := range items {
+			pair, isPair := Datum(item).(*Pair)
+			if !isPair {
+				p.pos = token.Pos
+				return &Void, &Error{Val: fmt.Sprintf("read-syntax: expected a (key . value) pair in `#hash(`, given `%s`", item.String(1))}
+			}
+
+			res.Set(pair.Car, pair.Cdr)
+		}
+
+		return res, nil
+
+	case lexer.TokenVectorOpen:
+		items, tail, err := p.nextItems(1, token)
+		if err != nil {
+			return &Void, err
+		}
+
+		if tail != nil {
+			p.pos = token.Pos
+			return &Void, &Error{Val: "read-syntax: illegal use of `.` in `#(`"}
+		}
+
+		res := &Vector{Items: make([]Expression, len(items)), Immutable: true}
+		for i, item := range items {
+			res.Items[i] = Datum(item)
+		}
+
+		return res, nil
+
+	case lexer.TokenDot:
+		return &SpecialExpr{typ: SpecialDot}, nil
+
 	case lexer.TokenCloseBracket:
 		return &SpecialExpr{typ: SpecialCloseBracket}, nil
 
 	case lexer.TokenQuote:
+		p.depth++
+		defer func() { p.depth-- }()
+
+		if err := p.checkDepth(token); err != nil {
+			return &Void, err
+		}
+
 		return p.next(qlevel + 1)
 
+	case lexer.TokenDatumComment:
+		// the datum after `#;` is read and discarded
+		top := p.depth == 0
+		discarded, err := p.next(qlevel)
+		if err != nil {
+			return &Void, err
+		}
+
+		if s, isSpec := discarded.(*SpecialExpr); discarded == nil || isSpec && (s.typ == SpecialDot || s.typ == SpecialCloseBracket) {
+			p.pos = token.Pos
+			if isSpec {
+				p.skipRest(s.typ == SpecialCloseBracket)
+			}
+			return &Void, &Error{Val: "read-syntax: expected a datum after `#;`"}
+		}
+
+		if top {
+			p.started = false // the expression starts after the comment
+		}
+
+		return p.next(qlevel)
+
 	case lexer.TokenSkip:
 		return p.next(qlevel)
 	}
@@ -219,44 +771,164 @@ func (p *Parser) next(qlevel int) (ex Expression, err *Error) {
 	return &Void, &Error{Val: "read-syntax: unknown lex type"}
 }
 
-/// ------------------------------------------------------------------------ ///
-/// --------------------------- String() methods --------------------------- ///
-/// ------------------------------------------------------------------------ ///
+// reads the items of a list up to its closing bracket
+// returns the tail after a `.` separately
+func (p *Parser) nextItems(qlevel int, open *lexer.Token) (items []interface{ Expression }, tail Expression, err *Error) {
+	items = make([]interface{ Expression }, 0)
 
-func (n *Number) String(qlevel int) string {
-	return getQs(n.qlevel, qlevel+1) + strconv.FormatFloat(n.Val, 'f', -1, 64)
+	p.depth++
+	p.open++
+	defer func() { p.depth--; p.open-- }()
+
+	if err := p.checkDepth(open); err != nil {
+		return nil, nil, err
+	}
+
+	for {
+		inexpr, err := p.next(qlevel)
+		if err != nil {
+			if p.recovery && !p.skipped {
+				p.skipOpen() // the rest of the expression with the error
+			}
+			return nil, nil, err
+		}
+
+		if inexpr == nil {
+			p.pos = open.Pos
+			return nil, nil, &Error{Val: "read-syntax: expected a `)` to close `(` opened at " + open.Pos.String()}
+		}
+
+		s, isSpec := inexpr.(*SpecialExpr)
+		if isSpec && s.typ == SpecialCloseBracket {
+			return items, nil, nil
+		}
+
+		if isSpec && s.typ == SpecialDot {
+			break
+		}
+
+		items = append(items, inexpr)
+		if p.limits.MaxLength > 0 && len(items) > p.limits.MaxLength {
+			p.pos = open.Pos
+			p.skipOpen()
+			return nil, nil, &Error{Val: fmt.Sprintf("read-syntax: `%s` opened at %s has more than %d items", open.Val, open.Pos, p.limits.MaxLength)}
+		}
+	}
+
+	// the rest of a malformed dotted list is skipped with the lists it is in
+	// so reading goes on after the top-level expression
+	if len(items) == 0 {
+		p.pos = open.Pos
+		p.skipOpen()
+		return nil, nil, &Error{Val: "read-syntax: illegal use of `.`"}
+	}
+
+	tail, err = p.next(qlevel)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if s, isSpec := tail.(*SpecialExpr); isSpec || tail == nil {
+		p.pos = open.Pos
+		if isSpec {
+			p.skipRest(s.typ == SpecialCloseBracket)
+		}
+		return nil, nil, &Error{Val: "read-syntax: expected an expression after `.`"}
+	}
+
+	closing, err := p.next(qlevel)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if s, isSpec := closing.(*SpecialExpr); !isSpec || s.typ != SpecialCloseBracket {
+		p.pos = open.Pos
+		if closing != nil {
+			p.skipOpen()
+		}
+		return nil, nil, &Error{Val: "read-syntax: expected a `)` after the expression following `.`"}
+	}
+
+	return items, tail, nil
 }
 
-func (v *Variable) String(_ int) string {
-	return v.Val
+// returns an error if the expression started by the token
+// is nested deeper than the limit
+func (p *Parser) checkDepth(token *lexer.Token) *Error {
+	if p.limits.MaxDepth <= 0 || p.depth <= p.limits.MaxDepth {
+		return nil
+	}
+
+	p.pos = token.Pos
+	p.skipOpen()
+	return &Error{Val: fmt.Sprintf("read-syntax: `%s` at %s is nested more than %d levels deep", token.Val, token.Pos, p.limits.MaxDepth)}
 }
 
-func (l *ExprList) String(qlevel int) string {
-	res := getQs(l.Qlevel, qlevel)
+// skips the rest of the top-level expression without recursion after
+// an exceeded limit, up to the closing brackets of the open ones or to
+// the end of the quoted expression if none are open
+func (p *Parser) skipOpen() {
+	p.skipLevels(p.open)
+}
 
-	len := len(l.Lst)
-	if len == 0 {
-		return res + "()"
+// skips the rest of the top-level expression after an error in it,
+// closed tells whether the `)` of the innermost open list was read
+func (p *Parser) skipRest(closed bool) {
+	open := p.open
+	if closed {
+		open--
 	}
 
-	res += "("
+	if open > 0 {
+		p.skipLevels(open)
+	}
+	p.skipped = true
+}
 
-	for i, expr := range l.Lst[0 : len-1] {
-		if i != 0 {
-			res += " "
+// skips the tokens up to the closing brackets of the given number of
+// open lists or to the end of the quoted expression if it is 0
+func (p *Parser) skipLevels(open int) {
+	p.skipped = true
+	for {
+		token := p.lexer.NextToken()
+		if token == nil || token.Typ == lexer.TokenEOF || token.Typ == lexer.TokenError {
+			return
+		}
+
+		switch token.Typ {
+		case lexer.TokenOpenBracket, lexer.TokenHashOpen, lexer.TokenVectorOpen:
+			open++
+		case lexer.TokenCloseBracket:
+			open--
 		}
-		res += expr.String(l.Qlevel + 1)
-	}
 
-	lastExpr := l.Lst[len-1]
-	if !IsNullSym(lastExpr) {
-		res += " . " + lastExpr.String(l.Qlevel+1)
+		if open <= 0 && token.Typ != lexer.TokenQuote {
+			return
+		}
 	}
+}
 
-	return res + ")"
+/// ------------------------------------------------------------------------ ///
+/// --------------------------- String() methods --------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+func (n *Number) String(qlevel int) string {
+	return getQs(n.qlevel, qlevel+1) + FormatNumberWith(n, NumberFormat{})
+}
+
+func (v *Variable) String(_ int) string {
+	return escapeIdentifier(v.Val)
+}
+
+func (l *ExprList) String(qlevel int) string {
+	return printString(l, qlevel)
 }
 
 func (proc *Procedure) String(_ int) string {
+	if len(proc.Name) != 0 {
+		return fmt.Sprintf("#<procedure:%s>", proc.Name)
+	}
+
 	return "#<procedure>"
 }
 
@@ -269,11 +941,31 @@ func (lambda *Lambda) String(_ int) string {
 }
 
 func (s *Symbol) String(qlevel int) string {
-	qs := s.qlevel
-	if len(s.val) > 0 && s.val[0] == '#' {
-		qs -= 1
+	if IsNullSym(s) {
+		return getQs(s.qlevel, qlevel) + s.val
+	}
+
+	return getQs(s.qlevel, qlevel) + escapeIdentifier(s.val)
+}
+
+func (s *String) String(_ int) string {
+	return escapeString(s.Val)
+}
+
+func (b *Boolean) String(qlevel int) string {
+	if b.Val {
+		return getQs(b.qlevel, qlevel+1) + "#t"
 	}
-	return getQs(qs, qlevel) + s.val
+
+	return getQs(b.qlevel, qlevel+1) + "#f"
+}
+
+func (c *Char) String(_ int) string {
+	return "#\\" + charText(c.Val)
+}
+
+func (sb *StringBuilder) String(_ int) string {
+	return "#<string-builder>"
 }
 
 func (s *SpecialExpr) String(_ int) string {
@@ -282,6 +974,8 @@ func (s *SpecialExpr) String(_ int) string {
 		return "#<exit>"
 	case SpecialCloseBracket:
 		return "Unexpected `)`"
+	case SpecialDot:
+		return "Unexpected `.`"
 	}
 
 	return "Unknown special expression"
@@ -291,6 +985,32 @@ func (ve *VoidExpr) String(_ int) string {
 	return "#<void>"
 }
 
+func (pr *Promise) String(_ int) string {
+	return "#<promise>"
+}
+
+func (eof *EOFExpr) String(_ int) string {
+	return "#<eof>"
+}
+
+// printed like the REPL prints them, every value on a line of its own
+func (mv *MultipleValues) String(qlevel int) string {
+	strs := make([]string, len(mv.Vals))
+	for i, val := range mv.Vals {
+		strs[i] = val.String(qlevel)
+	}
+
+	return strings.Join(strs, "\n")
+}
+
+func (port *Port) String(_ int) string {
+	if port.IsOutput() {
+		return fmt.Sprintf("#<output-port:%s>", port.Name)
+	}
+
+	return fmt.Sprintf("#<input-port:%s>", port.Name)
+}
+
 /// ------------------------------------------------------------------------ ///
 /// -------------------------- Utility functions --------------------------- ///
 /// ------------------------------------------------------------------------ ///
@@ -306,3 +1026,18 @@ func getQs(exQLevel int, currQLevel int) string {
 
 	return qs
 }
+
+// returns the code given to an (exit) command by the arguments after `exit`,
+// 0 without arguments, ok is false unless they are nothing or an exact integer
+func exitCode(args []interface{ Expression }) (code int, ok bool) {
+	if len(args) == 0 {
+		return 0, true
+	}
+
+	num, isNum := args[0].(*Number)
+	if !isNum || num.Inexact || num.Exact != nil || num.Ratio != nil {
+		return 0, false
+	}
+
+	return int(num.Val), true
+}
@@ -0,0 +1,289 @@
+package parser
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+/// ------------------------------------------------------------------------ ///
+/// -------------------------- Public definitions -------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// scheme port reading characters from an io.Reader
+// or writing them to an io.Writer
+type Port struct {
+	Name    string        // name of the port, e.g. the name of the file
+	Timeout time.Duration // how long reads wait for input, 0 waits forever
+
+	writer   io.Writer        // the destination of an output port
+	reader   *bufio.Reader    // the source of an input port
+	closer   io.Closer        // closes the source or the destination, nil if it needn't be
+	buffer   *strings.Builder // the destination of a string output port
+	closed   bool             // whether the port has been closed
+	done     chan struct{}    // closed with the port to stop the reading goroutine
+	runes    chan portRune    // runes read ahead from the source, nil until reading ahead starts
+	pending  *portRune        // a read rune that hasn't been consumed yet
+	once     sync.Once        // starts the reading goroutine only once
+	inMemory bool             // whether the source is in memory so reading never blocks
+}
+
+// returned by reads which waited longer than the port's timeout
+var ErrTimeout = errors.New("port: timed out waiting for input")
+
+// returned by reads and writes of a closed port
+var ErrClosed = errors.New("port: the port is closed")
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------- Public functions and methods --------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// creates an input port reading from the given reader
+func NewInputPort(name string, r io.Reader) *Port {
+	return &Port{
+		Name:   name,
+		reader: bufio.NewReader(r),
+		done:   make(chan struct{}),
+	}
+}
+
+// creates an input port reading the characters of the string,
+// reading from it never blocks so it never reads ahead
+func NewStringInputPort(str string) *Port {
+	port := NewInputPort("string", strings.NewReader(str))
+	port.inMemory = true
+	return port
+}
+
+// creates an output port writing to the given writer
+func NewOutputPort(name string, w io.Writer) *Port {
+	return &Port{
+		Name:   name,
+		writer: w,
+	}
+}
+
+// creates an input port reading from the given reader,
+// which is closed when the port is closed, e.g. a file
+func NewClosingInputPort(name string, r io.ReadCloser) *Port {
+	port := NewInputPort(name, r)
+	port.closer = r
+	return port
+}
+
+// creates an output port writing to the given writer,
+// which is closed when the port is closed, e.g. a file
+func NewClosingOutputPort(name string, w io.WriteCloser) *Port {
+	port := NewOutputPort(name, w)
+	port.closer = w
+	return port
+}
+
+// creates an output port collecting what is written to it in a string
+func NewStringOutputPort() *Port {
+	buffer := &strings.Builder{}
+	port := NewOutputPort("string", buffer)
+	port.buffer = buffer
+	return port
+}
+
+// returns what has been written to a string output port
+func (port *Port) Contents() (str string, ok bool) {
+	if port.buffer == nil {
+		return "", false
+	}
+
+	return port.buffer.String(), true
+}
+
+// closes the port and what it reads from or writes to, if it needs closing,
+// closing a port again does nothing
+func (port *Port) Close() error {
+	if port.closed {
+		return nil
+	}
+	port.closed = true
+
+	if port.done != nil {
+		close(port.done)
+	}
+
+	if port.closer != nil {
+		return port.closer.Close()
+	}
+
+	return nil
+}
+
+// tests whether the port has been closed
+func (port *Port) IsClosed() bool {
+	return port.closed
+}
+
+// tests whether the port is an input port
+func (port *Port) IsInput() bool {
+	return port.reader != nil
+}
+
+// tests whether the port is an output port
+func (port *Port) IsOutput() bool {
+	return port.writer != nil
+}
+
+// writes the string to the output port
+func (port *Port) WriteString(str string) error {
+	if port.closed {
+		return ErrClosed
+	}
+
+	_, err := io.WriteString(port.writer, str)
+	return err
+}
+
+// tests whether a character can be read from the port without blocking
+// note: a port which has reached its end or is closed is always ready
+func (port *Port) Ready() bool {
+	if port.closed {
+		return true
+	}
+
+	if port.pending != nil || port.inMemory || port.runes == nil && port.bufferedRune() {
+		return true
+	}
+
+	port.start()
+	select {
+	case r := <-port.runes:
+		port.pending = &r
+		return true
+	default:
+		return false
+	}
+}
+
+// returns the next character of the port without consuming it
+// err is io.EOF at the end of the input and ErrTimeout if the port timed out
+func (port *Port) PeekChar() (r rune, err error) {
+	if port.closed {
+		return 0, ErrClosed
+	}
+
+	if port.pending == nil {
+		next, err := port.next()
+		if err != nil {
+			return 0, err
+		}
+		port.pending = next
+	}
+
+	return port.pending.r, port.pending.err
+}
+
+// returns and consumes the next character of the port
+// err is io.EOF at the end of the input and ErrTimeout if the port timed out
+func (port *Port) ReadChar() (r rune, err error) {
+	r, err = port.PeekChar()
+	if err == nil {
+		port.pending = nil
+	}
+
+	return r, err
+}
+
+// returns and consumes the characters up to the next newline without it
+// err is io.EOF if the port has no more characters and ErrTimeout if
+// the port timed out, the characters read before it are consumed then
+func (port *Port) ReadLine() (line string, err error) {
+	var sb strings.Builder
+	for {
+		r, err := port.ReadChar()
+		if err == io.EOF && sb.Len() != 0 {
+			return sb.String(), nil
+		}
+		if err != nil {
+			return sb.String(), err
+		}
+		if r == '\n' {
+			return sb.String(), nil
+		}
+		sb.WriteRune(r)
+	}
+}
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------------- Inner structure ---------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// a rune read from the source of a port or the error that stopped reading
+type portRune struct {
+	r   rune
+	err error
+}
+
+/// ------------------------------------------------------------------------ ///
+/// -------------------- Private functions and methods --------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// reads the next rune, directly from the source unless a read could wait
+// longer than the timeout of the port or reading ahead has already started,
+// a port whose readiness is never polled never starts the reading goroutine
+func (port *Port) next() (r *portRune, err error) {
+	if port.runes == nil && (port.Timeout <= 0 || port.inMemory || port.bufferedRune()) {
+		r, _, err := port.reader.ReadRune()
+		return &portRune{r: r, err: err}, nil
+	}
+
+	return port.wait()
+}
+
+// tests whether a whole rune is buffered, so reading it doesn't read from
+// the source, only a part of a multibyte rune may be buffered
+func (port *Port) bufferedRune() bool {
+	buffered, _ := port.reader.Peek(port.reader.Buffered())
+	return len(buffered) > 0 && utf8.FullRune(buffered)
+}
+
+// starts reading ahead from the source of the port
+// the goroutine stops on the first error, e.g. at the end of the input
+func (port *Port) start() {
+	port.once.Do(func() {
+		port.runes = make(chan portRune)
+		go func() {
+			for {
+				r, _, err := port.reader.ReadRune()
+				select {
+				case port.runes <- portRune{r: r, err: err}:
+				case <-port.done:
+					return
+				}
+
+				if err != nil {
+					return
+				}
+			}
+		}()
+	})
+}
+
+// waits for the next read ahead rune respecting the timeout of the port
+func (port *Port) wait() (r *portRune, err error) {
+	port.start()
+	if port.Timeout <= 0 {
+		next := <-port.runes
+		return &next, nil
+	}
+
+	timer := time.NewTimer(port.Timeout)
+	defer timer.Stop()
+
+	select {
+	case next := <-port.runes:
+		return &next, nil
+	case <-timer.C:
+		return nil, ErrTimeout
+	}
+}
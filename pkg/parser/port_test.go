@@ -0,0 +1,113 @@
+package parser
+
+import (
+	"io"
+	"testing"
+	"time"
+)
+
+// reads a character from the port in a goroutine, failing the test if the
+// read doesn't return in time, e.g. because it ignores the timeout
+func readCharWithin(t *testing.T, port *Port, limit time.Duration) (r rune, err error) {
+	t.Helper()
+
+	type read struct {
+		r   rune
+		err error
+	}
+	reads := make(chan read, 1)
+	go func() {
+		r, err := port.ReadChar()
+		reads <- read{r, err}
+	}()
+
+	select {
+	case res := <-reads:
+		return res.r, res.err
+	case <-time.After(limit):
+		t.Fatalf("reading a character of %s didn't return in %s", port.Name, limit)
+		return 0, nil
+	}
+}
+
+// a read of a rune only partly buffered times out instead of waiting for
+// the rest of the rune
+func TestPortTimeoutSplitRune(t *testing.T) {
+	r, w := io.Pipe()
+	defer w.Close()
+
+	port := NewInputPort("pipe", r)
+	defer port.Close()
+
+	// read without a timeout, so directly, buffering the first byte of λ
+	lambda := []byte("λ")
+	go w.Write(append([]byte("a"), lambda[0]))
+
+	if got, err := readCharWithin(t, port, time.Second); got != 'a' || err != nil {
+		t.Fatalf("the first character is %q, %v, want 'a'", got, err)
+	}
+	if port.reader.Buffered() != 1 {
+		t.Fatalf("%d bytes are buffered, want the first byte of λ", port.reader.Buffered())
+	}
+
+	port.Timeout = 20 * time.Millisecond
+	if _, err := readCharWithin(t, port, time.Second); err != ErrTimeout {
+		t.Fatalf("reading the split rune returned %v, want a timeout", err)
+	}
+
+	if port.Ready() {
+		t.Errorf("the port is ready with only a part of a rune buffered")
+	}
+
+	go w.Write(lambda[1:])
+	if got, err := readCharWithin(t, port, time.Second); got != 'λ' || err != nil {
+		t.Errorf("the rune completed after the timeout is %q, %v, want 'λ'", got, err)
+	}
+}
+
+func TestPortTimeout(t *testing.T) {
+	r, w := io.Pipe()
+	defer w.Close()
+
+	port := NewInputPort("pipe", r)
+	port.Timeout = 20 * time.Millisecond
+	defer port.Close()
+
+	if _, err := readCharWithin(t, port, time.Second); err != ErrTimeout {
+		t.Fatalf("reading an empty pipe returned %v, want a timeout", err)
+	}
+
+	go w.Write([]byte("x\n"))
+	if line, err := port.ReadLine(); line != "x" || err != nil {
+		t.Errorf("the line written after the timeout is %q, %v, want %q", line, err, "x")
+	}
+}
+
+// string ports never start reading ahead
+func TestStringPortReadsDirectly(t *testing.T) {
+	port := NewStringInputPort("λa\nb")
+	port.Timeout = time.Millisecond
+
+	if !port.Ready() {
+		t.Errorf("a string port isn't ready")
+	}
+
+	var got []rune
+	for {
+		r, err := port.ReadChar()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("reading the string port failed: %v", err)
+		}
+		got = append(got, r)
+	}
+
+	if string(got) != "λa\nb" {
+		t.Errorf("the string port reads %q, want %q", string(got), "λa\nb")
+	}
+	if port.runes != nil {
+		t.Errorf("the string port started reading ahead")
+	}
+}
@@ -0,0 +1,431 @@
+package parser
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+/// ------------------------------------------------------------------------ ///
+/// -------------------------- Public definitions -------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// options of the printed form of expressions, zero means no limit
+// cycles are always printed with datum labels, e.g. `#0=(1 . #0#)`
+type PrintOptions struct {
+	MaxDepth  int          // deepest nesting of compound values, deeper ones are `...`
+	MaxLength int          // most items printed of a compound value, the rest are `...`
+	Limit     int          // most bytes printed
+	Numbers   NumberFormat // how numbers are printed
+}
+
+// options used for the values shown in error messages
+var ErrorPrintOptions = PrintOptions{MaxDepth: 8, MaxLength: 32, Limit: 1000}
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------------- Inner structure ---------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// builder of the printed form of expressions which stops at a limit
+// compound expressions are printed piece by piece so printing
+// a huge expression stops once the limit is reached
+type printer struct {
+	sb        strings.Builder
+	opts      PrintOptions
+	truncated bool               // whether the limit has been reached
+	elided    bool               // whether values deeper or longer than the limits were left out
+	depth     int                // nesting of the compound value being printed
+	labels    map[Expression]int // labels of the values on cycles, unset ones are -1
+	nextLabel int
+	display   bool // whether strings and characters are printed as their characters
+}
+
+// state of a compound value while looking for cycles
+const (
+	visiting = iota + 1
+	visited
+)
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------- Public functions and methods --------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// returns the printed form of the expression cut to at most limit bytes
+// and whether it has been cut, a negative limit means no limit
+func StringLimited(expr Expression, limit int) (str string, truncated bool) {
+	if limit == 0 {
+		return "", true
+	} else if limit < 0 {
+		limit = 0
+	}
+
+	return StringWith(expr, PrintOptions{Limit: limit})
+}
+
+// returns the printed form of the expression with the given options
+// and whether some of it has been left out
+func StringWith(expr Expression, opts PrintOptions) (str string, truncated bool) {
+	pr := newPrinter(expr, opts)
+	pr.expr(expr, 0)
+	return pr.sb.String(), pr.truncated || pr.elided
+}
+
+// returns the expression as write prints it, in the syntax
+// read reads back as the same datum, e.g. `(a "b" #\c)`
+func WriteString(expr Expression) string {
+	pr := newPrinter(expr, PrintOptions{})
+	pr.expr(expr, 1)
+	return pr.sb.String()
+}
+
+// returns the expression as display prints it, like write does
+// but with strings and characters as their characters, e.g. `(a b c)`
+func DisplayString(expr Expression) string {
+	pr := newPrinter(expr, PrintOptions{})
+	pr.display = true
+	pr.expr(expr, 1)
+	return pr.sb.String()
+}
+
+/// ------------------------------------------------------------------------ ///
+/// -------------------- Private functions and methods --------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// returns the printed form of the expression without a limit
+func printString(expr Expression, qlevel int) string {
+	pr := newPrinter(expr, PrintOptions{})
+	pr.expr(expr, qlevel)
+	return pr.sb.String()
+}
+
+// creates a printer of the expression, finding the values on its cycles
+func newPrinter(expr Expression, opts PrintOptions) *printer {
+	pr := &printer{opts: opts, labels: make(map[Expression]int)}
+	pr.findCycles(expr, make(map[Expression]int))
+	return pr
+}
+
+// marks the compound values reached again while their items are visited
+func (pr *printer) findCycles(expr Expression, state map[Expression]int) {
+	switch ex := expr.(type) {
+	case *Pair:
+		var chain []Expression
+		for pair := ex; pair != nil; {
+			if state[pair] == visiting {
+				pr.labels[pair] = -1
+				break
+			}
+			if state[pair] == visited {
+				break
+			}
+
+			state[pair] = visiting
+			chain = append(chain, pair)
+			pr.findCycles(pair.Car, state)
+			pair, _ = pair.Cdr.(*Pair)
+		}
+
+		for _, pair := range chain {
+			state[pair] = visited
+		}
+
+	case *HashTable, *Record, *Vector:
+		if state[ex] == visiting {
+			pr.labels[ex] = -1
+			return
+		}
+		if state[ex] == visited {
+			return
+		}
+
+		state[ex] = visiting
+		switch compound := ex.(type) {
+		case *HashTable:
+			for _, bucket := range compound.entries {
+				for _, entry := range bucket {
+					pr.findCycles(entry.key, state)
+					pr.findCycles(entry.val, state)
+				}
+			}
+		case *Record:
+			for _, val := range compound.Vals {
+				pr.findCycles(val, state)
+			}
+		case *Vector:
+			for _, item := range compound.Items {
+				pr.findCycles(item, state)
+			}
+		}
+		state[ex] = visited
+	}
+}
+
+// adds the string to the printed form unless the limit has been reached
+func (pr *printer) write(str string) {
+	if pr.truncated {
+		return
+	}
+
+	if pr.opts.Limit > 0 && pr.sb.Len()+len(str) > pr.opts.Limit {
+		end := pr.opts.Limit - pr.sb.Len()
+		for end > 0 && !utf8.RuneStart(str[end]) {
+			end-- // don't split a character
+		}
+		pr.sb.WriteString(str[:end])
+		pr.truncated = true
+		return
+	}
+
+	pr.sb.WriteString(str)
+}
+
+// adds `...` in place of the items left out
+func (pr *printer) elide() {
+	pr.write("...")
+	pr.elided = true
+}
+
+// adds the label of the value if it's on a cycle
+// returns whether the value has already been printed
+func (pr *printer) label(expr Expression) (printed bool) {
+	label, onCycle := pr.labels[expr]
+	if !onCycle {
+		return false
+	}
+
+	if label >= 0 {
+		pr.write("#" + strconv.Itoa(label) + "#")
+		return true
+	}
+
+	pr.labels[expr] = pr.nextLabel
+	pr.write("#" + strconv.Itoa(pr.nextLabel) + "=")
+	pr.nextLabel++
+	return false
+}
+
+// returns whether the items after the first count ones are left out
+func (pr *printer) tooLong(count int) bool {
+	return pr.opts.MaxLength > 0 && count >= pr.opts.MaxLength
+}
+
+// adds the printed form of the expression
+func (pr *printer) expr(expr Expression, qlevel int) {
+	if pr.truncated {
+		return
+	}
+
+	switch expr.(type) {
+	case *ExprList, *Pair, *HashTable, *Record, *Vector:
+		if pr.opts.MaxDepth > 0 && pr.depth >= pr.opts.MaxDepth {
+			pr.elide()
+			return
+		}
+	}
+
+	pr.depth++
+	defer func() { pr.depth-- }()
+
+	switch ex := expr.(type) {
+	case *ExprList:
+		pr.list(ex, qlevel)
+	case *Pair:
+		pr.pair(ex, qlevel)
+	case *HashTable:
+		pr.hash(ex)
+	case *Record:
+		pr.record(ex)
+	case *Vector:
+		pr.vector(ex)
+	case *Number:
+		pr.write(getQs(ex.qlevel, qlevel+1) + FormatNumberWith(ex, pr.opts.Numbers))
+	case *String:
+		if pr.display {
+			pr.write(string(ex.Val))
+		} else {
+			pr.write(ex.String(qlevel))
+		}
+	case *Char:
+		if pr.display {
+			pr.write(string(ex.Val))
+		} else {
+			pr.write(ex.String(qlevel))
+		}
+	default:
+		pr.write(expr.String(qlevel))
+	}
+}
+
+// adds the printed form of the list
+func (pr *printer) list(l *ExprList, qlevel int) {
+	pr.write(getQs(l.Qlevel, qlevel))
+
+	items, lastExpr := l.Lst, Expression(nil)
+	if l.Tail != nil {
+		lastExpr = l.Tail
+	} else if len(items) > 0 && l.Qlevel > 0 {
+		items, lastExpr = items[:len(items)-1], items[len(items)-1]
+	} else if len(items) > 0 {
+		lastExpr = &NullSym // code lists have no terminating null symbol
+	}
+
+	if lastExpr == nil {
+		pr.write("()")
+		return
+	}
+
+	pr.write("(")
+
+	for i, expr := range items {
+		if pr.truncated {
+			return
+		}
+
+		if i != 0 {
+			pr.write(" ")
+		}
+		if pr.tooLong(i) {
+			pr.elide()
+			pr.write(")")
+			return
+		}
+		pr.expr(expr, l.Qlevel+1)
+	}
+
+	if !IsNullSym(lastExpr) {
+		pr.write(" . ")
+		pr.expr(lastExpr, l.Qlevel+1)
+	}
+
+	pr.write(")")
+}
+
+// adds the printed form of the pair, it's quoted data like quoted lists
+// a pair on a cycle is labeled and printed again only as its label
+func (pr *printer) pair(pair *Pair, qlevel int) {
+	pr.write(getQs(1, qlevel))
+	if pr.label(pair) {
+		return
+	}
+
+	if quotedExpr, isQuote := quoted(pair); isQuote {
+		if _, onCycle := pr.labels[pair.Cdr]; !onCycle {
+			pr.write("'")
+			pr.expr(quotedExpr, 2)
+			return
+		}
+	}
+
+	pr.write("(")
+	pr.expr(pair.Car, 2)
+
+	for count := 1; ; count++ {
+		if pr.truncated {
+			return
+		}
+
+		next, isPair := pair.Cdr.(*Pair)
+		if !isPair {
+			break
+		}
+		if _, onCycle := pr.labels[next]; onCycle {
+			break // printed as the tail so its label can be shown
+		}
+
+		pr.write(" ")
+		if pr.tooLong(count) {
+			pr.elide()
+			pr.write(")")
+			return
+		}
+		pr.expr(next.Car, 2)
+		pair = next
+	}
+
+	if !IsNullSym(pair.Cdr) {
+		pr.write(" . ")
+		pr.expr(pair.Cdr, 2)
+	}
+
+	pr.write(")")
+}
+
+// adds the printed form of the hash table
+func (pr *printer) hash(h *HashTable) {
+	if pr.label(h) {
+		return
+	}
+
+	pr.write("#hash(")
+
+	for i, pair := range h.Pairs() {
+		if pr.truncated {
+			return
+		}
+
+		if i != 0 {
+			pr.write(" ")
+		}
+		if pr.tooLong(i) {
+			pr.elide()
+			pr.write(")")
+			return
+		}
+		pr.expr(pair, 1)
+	}
+
+	pr.write(")")
+}
+
+// adds the printed form of the vector
+func (pr *printer) vector(v *Vector) {
+	if pr.label(v) {
+		return
+	}
+
+	pr.write("#(")
+
+	for i, item := range v.Items {
+		if pr.truncated {
+			return
+		}
+
+		if i != 0 {
+			pr.write(" ")
+		}
+		if pr.tooLong(i) {
+			pr.elide()
+			pr.write(")")
+			return
+		}
+		pr.expr(item, 1)
+	}
+
+	pr.write(")")
+}
+
+// adds the printed form of the record
+func (pr *printer) record(r *Record) {
+	if pr.label(r) {
+		return
+	}
+
+	pr.write("#<record:" + r.Type.Name)
+
+	for i, field := range r.Type.Fields {
+		if pr.truncated {
+			return
+		}
+
+		if pr.tooLong(i) {
+			pr.write(" ")
+			pr.elide()
+			pr.write(">")
+			return
+		}
+		pr.write(" " + field + "=")
+		pr.expr(r.Vals[i], 1)
+	}
+
+	pr.write(">")
+}
@@ -0,0 +1,69 @@
+package parser
+
+import (
+	"github.com/dimbata23/golang-scheme-interpreter/pkg/lexer"
+)
+
+/// ------------------------------------------------------------------------ ///
+/// -------------------------- Public definitions -------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// handler of a custom `#<name>` reader syntax, given the datum following
+// the name, e.g. the string of #date"2024-01-01", returns the expression
+// which is read instead
+type ReaderHandler func(datum Expression) (ex Expression, err *Error)
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------- Public functions and methods --------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// registers the handler of the `#<name>` syntax, e.g. "date" for #date"..."
+// the standard syntax such as numbers like #x1f or booleans takes
+// precedence, a nil handler removes the syntax
+func (p *Parser) SetReaderHandler(name string, handler ReaderHandler) {
+	if handler == nil {
+		delete(p.readers, name)
+		return
+	}
+
+	if p.readers == nil {
+		p.readers = map[string]ReaderHandler{}
+	}
+	p.readers[name] = handler
+}
+
+/// ------------------------------------------------------------------------ ///
+/// -------------------- Private functions and methods --------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// returns the handler of the custom syntax of the identifier, if any
+func (p *Parser) readerOf(ident string) (handler ReaderHandler, isCustom bool) {
+	if len(ident) < 2 || ident[0] != '#' {
+		return nil, false
+	}
+
+	handler, isCustom = p.readers[ident[1:]]
+	return handler, isCustom
+}
+
+// reads the datum after the `#<name>` token and returns what the handler
+// of the syntax makes of it
+func (p *Parser) readCustom(token *lexer.Token, handler ReaderHandler, qlevel int) (ex Expression, err *Error) {
+	arg, err := p.next(qlevel)
+	if err != nil {
+		return &Void, err
+	}
+
+	if _, isSpec := arg.(*SpecialExpr); isSpec || arg == nil {
+		p.pos = token.Pos
+		return &Void, &Error{Val: "read-syntax: expected an expression after `" + token.Val + "`"}
+	}
+
+	ex, err = handler(datum(arg, qlevel))
+	if err != nil {
+		p.pos = token.Pos
+		return &Void, &Error{Val: "read-syntax: " + token.Val + ": " + err.Val}
+	}
+
+	return ex, nil
+}
@@ -0,0 +1,62 @@
+package parser_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"testing"
+
+	"github.com/dimbata23/golang-scheme-interpreter/pkg/interpreter"
+	"github.com/dimbata23/golang-scheme-interpreter/pkg/parser"
+)
+
+// checks that the data of testdata/roundtrip.scm read back the same
+// after being printed, reporting every datum which doesn't
+func TestRoundTrip(t *testing.T) {
+	const file = "testdata/roundtrip.scm"
+	str, err := ioutil.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	i := interpreter.NewInterpreter()
+	results := i.Eval(string(str))
+	if len(results) == 0 {
+		t.Fatalf("%s has no data", file)
+	}
+
+	for _, res := range results {
+		if msg := roundTrip(i, res); msg != "" {
+			t.Errorf("%s:%s: %s", file, res.Pos, msg)
+		}
+	}
+}
+
+// prints the value of the result, reads it back and compares the two
+// returns what went wrong or an empty string if it read back the same
+func roundTrip(i *interpreter.Interpreter, res interpreter.Result) string {
+	if res.Err != nil {
+		return "error: " + res.Err.String()
+	}
+
+	printed, _ := parser.StringWith(res.Value, parser.PrintOptions{})
+	back := i.Eval(printed)
+	switch {
+	case len(back) != 1:
+		return fmt.Sprintf("`%s` printed as `%s` reads back as %d expressions", res.Source, printed, len(back))
+	case back[0].Err != nil:
+		return fmt.Sprintf("`%s` printed as `%s` doesn't read back: %s", res.Source, printed, back[0].Err.String())
+	}
+
+	reprinted, _ := parser.StringWith(back[0].Value, parser.PrintOptions{})
+	if reprinted != printed {
+		return fmt.Sprintf("`%s` printed as `%s` reads back printed as `%s`", res.Source, printed, reprinted)
+	}
+
+	i.Define("expected", res.Value)
+	i.Define("actual", back[0].Value)
+	if same := i.Eval("(equal? expected actual)"); len(same) != 1 || !parser.Truthy(same[0].Value) {
+		return fmt.Sprintf("`%s` printed as `%s` reads back as a datum which isn't equal?", res.Source, printed)
+	}
+
+	return ""
+}
@@ -0,0 +1,119 @@
+package parser
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------------- Inner structure ---------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// characters by the letters of their `\<letter>` escape sequences in strings
+var stringEscapes = map[rune]rune{
+	'a':  7,
+	'b':  8,
+	't':  '\t',
+	'n':  '\n',
+	'r':  '\r',
+	'0':  0,
+	'"':  '"',
+	'\\': '\\',
+}
+
+// escape sequences the characters are printed with in strings
+var stringEscapeTexts = map[rune]string{
+	'\t': `\t`,
+	'\n': `\n`,
+	'\r': `\r`,
+	'"':  `\"`,
+	'\\': `\\`,
+}
+
+// the message of a `\x` escape sequence without a code point
+const hexEscapeMsg = "read-syntax: bad escape sequence `\\x` in a string, expected `\\x<hex digits>;`"
+
+/// ------------------------------------------------------------------------ ///
+/// -------------------- Private functions and methods --------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// returns the characters of the text between the quotes of a string literal
+// with its escape sequences replaced, `\n`, `\"`, a code point in hexadecimal
+// such as `\x41;` and a `\` before a line ending, which skips the line ending
+// and the whitespace around it
+func unescapeString(text string) (val []rune, err *Error) {
+	val = make([]rune, 0, len(text))
+	for i := 0; i < len(text); {
+		r, width := utf8.DecodeRuneInString(text[i:])
+		i += width
+		if r != '\\' {
+			val = append(val, r)
+			continue
+		}
+
+		if i == len(text) {
+			return nil, &Error{Val: "read-syntax: expected a character after `\\` in a string"}
+		}
+
+		r, width = utf8.DecodeRuneInString(text[i:])
+		i += width
+
+		if ch, isEscape := stringEscapes[r]; isEscape {
+			val = append(val, ch)
+			continue
+		}
+
+		switch {
+		case r == 'x' || r == 'X':
+			end := strings.IndexRune(text[i:], ';')
+			if end <= 0 {
+				return nil, &Error{Val: hexEscapeMsg}
+			}
+
+			code, convErr := strconv.ParseUint(text[i:i+end], 16, 32)
+			if convErr != nil || !utf8.ValidRune(rune(code)) {
+				return nil, &Error{Val: hexEscapeMsg}
+			}
+			val = append(val, rune(code))
+			i += end + 1
+
+		case r == ' ' || r == '\t' || r == '\n' || r == '\r':
+			// a line continuation, the whitespace before the line ending,
+			// the line ending and the whitespace after it are skipped
+			i -= width
+			rest := strings.TrimLeft(text[i:], " \t")
+			if !strings.HasPrefix(rest, "\n") && !strings.HasPrefix(rest, "\r\n") {
+				return nil, &Error{Val: "read-syntax: expected a line ending after `\\` and whitespace in a string"}
+			}
+			rest = strings.TrimPrefix(strings.TrimPrefix(rest, "\r"), "\n")
+			rest = strings.TrimLeft(rest, " \t")
+			i = len(text) - len(rest)
+
+		default:
+			return nil, &Error{Val: "read-syntax: unknown escape sequence `\\" + string(r) + "` in a string"}
+		}
+	}
+
+	return val, nil
+}
+
+// returns the string literal of the characters, which read reads back
+// as the same characters
+func escapeString(val []rune) string {
+	var sb strings.Builder
+	sb.WriteRune('"')
+	for _, r := range val {
+		if text, isEscaped := stringEscapeTexts[r]; isEscaped {
+			sb.WriteString(text)
+		} else if !unicode.IsPrint(r) && !unicode.IsSpace(r) {
+			sb.WriteString(`\x` + strconv.FormatInt(int64(r), 16) + ";")
+		} else {
+			sb.WriteRune(r)
+		}
+	}
+	sb.WriteRune('"')
+
+	return sb.String()
+}
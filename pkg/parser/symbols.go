@@ -0,0 +1,132 @@
+package parser
+
+import (
+	"strings"
+	"sync"
+	"unicode"
+)
+
+/// ------------------------------------------------------------------------ ///
+/// -------------------------- Public definitions -------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// the names of the symbols and identifiers read so far by the parsers
+// sharing the table, e.g. an interpreter and the parsers of its input,
+// so names made up at run time can be told apart from them
+type SymbolTable struct {
+	mu    sync.Mutex
+	names map[string]bool
+}
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------------- Inner structure ---------------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// values of the boolean literals by their text
+var booleanLiterals = map[string]bool{
+	"#t":     true,
+	"#true":  true,
+	"#f":     false,
+	"#false": false,
+}
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------- Public functions and methods --------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// creates an empty symbol table
+func NewSymbolTable() *SymbolTable {
+	return &SymbolTable{names: make(map[string]bool)}
+}
+
+// adds the name to the table, returns false if it was already in it
+func (st *SymbolTable) Intern(name string) (isNew bool) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	if st.names[name] {
+		return false
+	}
+
+	st.names[name] = true
+	return true
+}
+
+// tests whether the name is in the table
+func (st *SymbolTable) Contains(name string) bool {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	return st.names[name]
+}
+
+// sets the table recording the names of the symbols and identifiers
+// the parser reads, parsers don't record them unless it is set
+func (p *Parser) SetSymbolTable(st *SymbolTable) {
+	p.symbols = st
+}
+
+/// ------------------------------------------------------------------------ ///
+/// -------------------- Private functions and methods --------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// returns the name of an identifier as it can be read back
+// names which wouldn't be read as an identifier are surrounded by `|`
+func escapeIdentifier(name string) string {
+	if !needsEscaping(name) {
+		return name
+	}
+
+	name = strings.ReplaceAll(name, "\\", "\\\\")
+	name = strings.ReplaceAll(name, "|", "\\|")
+	return "|" + name + "|"
+}
+
+// returns the name of an identifier with parts surrounded by `|`,
+// e.g. `|a b|` or `a|b c|d`, inside of which `\` escapes the next character
+func unescapeIdentifier(token string) string {
+	var sb strings.Builder
+	inPipes, escaped := false, false
+	for _, r := range token {
+		switch {
+		case escaped:
+			escaped = false
+			sb.WriteRune(r)
+		case inPipes && r == '\\':
+			escaped = true
+		case r == '|':
+			inPipes = !inPipes
+		default:
+			sb.WriteRune(r)
+		}
+	}
+
+	return sb.String()
+}
+
+// tests whether the name has to be surrounded by `|` to be read as an identifier
+func needsEscaping(name string) bool {
+	if len(name) == 0 || name == "." || strings.HasPrefix(name, "#\\") {
+		return true
+	}
+
+	if _, isBool := booleanLiterals[name]; isBool || strings.HasPrefix(name, "#:") {
+		return true
+	}
+
+	for _, r := range name {
+		if unicode.IsSpace(r) || strings.ContainsRune("()'\";|\\", r) {
+			return true
+		}
+	}
+
+	_, isNum := parseNumber(name, 10)
+	return isNum
+}
+
+// records the name read in the symbol table of the parser, if it has one
+func (p *Parser) internName(name string) {
+	if p.symbols != nil {
+		p.symbols.Intern(name)
+	}
+}
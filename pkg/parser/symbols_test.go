@@ -0,0 +1,97 @@
+package parser
+
+import "testing"
+
+// names of symbols which read as something else unless they are escaped,
+// and names which look odd but read back as they are
+var exoticSymbols = []string{
+	"", ".", "..", "...", "1", "-1.5", "1/2", "1e3", "+inf.0", "#x10", "#b101",
+	"hello world", "a\tb", "a|b", "a\\b", "(", "#(", "#()", "#hash(", "a;b",
+	"'q", "\"s", "#t", "#false", "#:kw", "#\\a", "#|x", "#;x",
+	"abc", "Abc", "1abc", "#foo", "#", "a#", "λ", "+", "-", "->x", "x.y",
+	"`x", ",x", ",@x", "[a]", "{b}", "#&x", "#!eof",
+}
+
+func TestEscapeIdentifier(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"abc", "abc"},
+		{"Abc", "Abc"},
+		{"1abc", "1abc"},
+		{"...", "..."},
+		{"λ", "λ"},
+		{"->x", "->x"},
+		{"#foo", "#foo"},
+		{"", "||"},
+		{".", "|.|"},
+		{"hello world", "|hello world|"},
+		{"1", "|1|"},
+		{"-1.5", "|-1.5|"},
+		{"1/2", "|1/2|"},
+		{"+inf.0", "|+inf.0|"},
+		{"#x10", "|#x10|"},
+		{"a|b", "|a\\|b|"},
+		{"a\\b", "|a\\\\b|"},
+		{"(", "|(|"},
+		{"a;b", "|a;b|"},
+		{"'q", "|'q|"},
+		{"#t", "|#t|"},
+		{"#false", "|#false|"},
+		{"#:kw", "|#:kw|"},
+		{"#\\a", "|#\\\\a|"},
+	}
+
+	for _, test := range tests {
+		if got := escapeIdentifier(test.name); got != test.want {
+			t.Errorf("escapeIdentifier(%q) = %s, want %s", test.name, got, test.want)
+		}
+		if got := WriteString(NewSymbol(test.name)); got != test.want {
+			t.Errorf("the symbol %q is printed as %s, want %s", test.name, got, test.want)
+		}
+	}
+}
+
+func TestUnescapeIdentifier(t *testing.T) {
+	tests := []struct {
+		token string
+		want  string
+	}{
+		{"abc", "abc"},
+		{"|a b|", "a b"},
+		{"a|b c|d", "ab cd"},
+		{"|a\\|b|", "a|b"},
+		{"|a\\\\b|", "a\\b"},
+		{"||", ""},
+		{"|1|", "1"},
+	}
+
+	for _, test := range tests {
+		if got := unescapeIdentifier(test.token); got != test.want {
+			t.Errorf("unescapeIdentifier(%q) = %q, want %q", test.token, got, test.want)
+		}
+	}
+}
+
+// the printed symbols are read back as one symbol of the same name
+func TestSymbolRoundTrip(t *testing.T) {
+	for _, name := range exoticSymbols {
+		printed := WriteString(NewSymbol(name))
+		par := NewParser(printed)
+		read, err := par.NextDatum()
+		if err != nil {
+			t.Errorf("the symbol %q printed as %s isn't read back: %s", name, printed, err.Val)
+			continue
+		}
+
+		if got, ok := AsSymbolName(read); !ok || got != name {
+			t.Errorf("the symbol %q printed as %s is read back as %s", name, printed, WriteString(read))
+			continue
+		}
+
+		if rest, _ := par.NextDatum(); rest != nil {
+			t.Errorf("the symbol %q printed as %s is read back as more than one datum", name, printed)
+		}
+	}
+}
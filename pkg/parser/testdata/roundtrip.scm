@@ -0,0 +1,400 @@
+'0
+'1
+'-1
+'42
+'-17
+'9007199254740993
+'123456789012345678901234567890
+'-98765432109876543210
+'1.5
+'-0.25
+'0.0
+'-0.0
+'3.0
+'1e21
+'6.02e23
+'1e-7
+'-1.5e-3
+'1E3
+'(1e10 . 2.5e+2)
+'+inf.0
+'-inf.0
+'+nan.0
+'#xff
+'#b-101
+'1/2
+'6/3
+'-3/4
+'12345678901234567890/7
+'#e0.5
+'#i1/3
+'#e2.0
+'#i7
+'a
+'foo
+'list->vector
+'x1
+'+
+'-
+'...
+'|hello world|
+'|a\|b|
+'||
+'|1|
+'|#t|
+'|#:k|
+'a|b c|d
+'|λ x|
+'日本語
+'|a\\b|
+'|.|
+'#t
+'#f
+'#true
+'#false
+'|#true|
+'(#t . #false)
+'#\a
+'#\A
+'#\space
+'#\newline
+'#\tab
+'#\nul
+'#\x41
+'#\x3bb
+'#\(
+'#\)
+'#\;
+'#\"
+'""
+'"str"
+'"two words"
+'"(not a list)"
+'"say \"hi\""
+'"a\nb"
+'"tab\tx"
+'"back\\slash"
+'"bell\x7;"
+'"#t"
+'"λ"
+'#:key
+'#:k2
+
+'()
+'(())
+'((()))
+'(() ())
+'(a)
+'(a b c)
+'(1 2 3)
+'(1 (2 (3 (4))))
+'((a b) (c d))
+'(a "b" #\c 1.5 #t)
+'(#t #f ())
+'(define (f x) (+ x 1))
+'(lambda (x . rest) rest)
+'(let ((x 1) (y 2)) (* x y))
+'(if #f #f)
+'(|a b| |c|)
+'(#:a 1 #:b 2)
+'(+inf.0 -inf.0 +nan.0)
+'(123456789012345678901234567890 -1)
+'(1/2 3/4)
+
+'(1 . 2)
+'(a . b)
+'(1 2 . 3)
+'(a b c . d)
+'((1 . 2) . 3)
+'((1 . 2) (3 . 4))
+'((a . b) . (c . d))
+'(1 . (2 . (3 . ())))
+'(1 . (2 . 3))
+'(() . ())
+'(() . 1)
+'(1 . ())
+'(a . "s")
+'(a . #\b)
+'(a . #t)
+'(1 . (2 . (3 . ())))
+'((a . 1) (b . 2))
+'(1 (2 . 3) . 4)
+'(a . #f)
+'(1 . #(2 3))
+'(1 . #:k)
+'(x . 1.5)
+'(x . +nan.0)
+'(1/3 . 2/3)
+'((((a . b) . c) . d) . e)
+'(a (b . c) d . e)
+'(1 . (2 3))
+'("a" . "b")
+'(#\a . #\b)
+'(|a b| . |c d|)
+'((()) . (()))
+
+''a
+'''a
+''()
+''(1 . 2)
+'(quote a)
+'(quote (quote a))
+'(a 'b)
+'(a ''b)
+'('a 'b . 'c)
+'((quote . a))
+'(quote)
+'(quote a b)
+'(quote . a)
+'(1 . (quote a))
+'(1 quote a)
+'(quote 1 . 2)
+''#(1 2)
+'#('a)
+''"s"
+''#\a
+'(unquote x)
+'(quasiquote (a (unquote b)))
+'(unquote-splicing x)
+''#t
+
+#()
+'#()
+#(1)
+'#(1)
+#(1 2 3)
+'#(1 2 3)
+#(a b c)
+'#(a b c)
+#(#(1) #(2 #(3)))
+'#(#(1) #(2 #(3)))
+#((1 . 2) (3 . 4))
+'#((1 . 2) (3 . 4))
+#(() ())
+'#(() ())
+#("a" #\b 1.5)
+'#("a" #\b 1.5)
+#(#t #f)
+'#(#t #f)
+#(+inf.0 +nan.0)
+'#(+inf.0 +nan.0)
+#(|a b|)
+'#(|a b|)
+#(#:k v)
+'#(#:k v)
+'(#() . #())
+'(a . #(b))
+'((#(1) . #(2)) #(3))
+
+#hash()
+'#hash()
+#hash((a . 1))
+'#hash((a . 1))
+#hash((a . 1) (b . 2))
+'#hash((a . 1) (b . 2))
+#hash((1 . (2 3)) ("s" . #(4)))
+'#hash((1 . (2 3)) ("s" . #(4)))
+#hash(((1 . 2) . x))
+'#hash(((1 . 2) . x))
+
+'(1 . 1)
+'(1 . a)
+'(1 . "s")
+'(1 . #\c)
+'(1 . ())
+'(1 . #t)
+'(1 . 1.5)
+'(1 . #(1))
+'(1 . (1 . 2))
+'(1 . 'q)
+'(a . 1)
+'(a . a)
+'(a . "s")
+'(a . #\c)
+'(a . ())
+'(a . #t)
+'(a . 1.5)
+'(a . #(1))
+'(a . (1 . 2))
+'(a . 'q)
+'("s" . 1)
+'("s" . a)
+'("s" . "s")
+'("s" . #\c)
+'("s" . ())
+'("s" . #t)
+'("s" . 1.5)
+'("s" . #(1))
+'("s" . (1 . 2))
+'("s" . 'q)
+'(#\c . 1)
+'(#\c . a)
+'(#\c . "s")
+'(#\c . #\c)
+'(#\c . ())
+'(#\c . #t)
+'(#\c . 1.5)
+'(#\c . #(1))
+'(#\c . (1 . 2))
+'(#\c . 'q)
+'(() . 1)
+'(() . a)
+'(() . "s")
+'(() . #\c)
+'(() . ())
+'(() . #t)
+'(() . 1.5)
+'(() . #(1))
+'(() . (1 . 2))
+'(() . 'q)
+'(#t . 1)
+'(#t . a)
+'(#t . "s")
+'(#t . #\c)
+'(#t . ())
+'(#t . #t)
+'(#t . 1.5)
+'(#t . #(1))
+'(#t . (1 . 2))
+'(#t . 'q)
+'(1.5 . 1)
+'(1.5 . a)
+'(1.5 . "s")
+'(1.5 . #\c)
+'(1.5 . ())
+'(1.5 . #t)
+'(1.5 . 1.5)
+'(1.5 . #(1))
+'(1.5 . (1 . 2))
+'(1.5 . 'q)
+'(#(1) . 1)
+'(#(1) . a)
+'(#(1) . "s")
+'(#(1) . #\c)
+'(#(1) . ())
+'(#(1) . #t)
+'(#(1) . 1.5)
+'(#(1) . #(1))
+'(#(1) . (1 . 2))
+'(#(1) . 'q)
+'((1 . 2) . 1)
+'((1 . 2) . a)
+'((1 . 2) . "s")
+'((1 . 2) . #\c)
+'((1 . 2) . ())
+'((1 . 2) . #t)
+'((1 . 2) . 1.5)
+'((1 . 2) . #(1))
+'((1 . 2) . (1 . 2))
+'((1 . 2) . 'q)
+'('q . 1)
+'('q . a)
+'('q . "s")
+'('q . #\c)
+'('q . ())
+'('q . #t)
+'('q . 1.5)
+'('q . #(1))
+'('q . (1 . 2))
+'('q . 'q)
+
+'(1 1)
+'(1 a)
+'(1 "s")
+'(1 #\c)
+'(1 ())
+'(1 #t)
+'(1 1.5)
+'(1 #(1))
+'(1 (1 . 2))
+'(1 'q)
+'(a 1)
+'(a a)
+'(a "s")
+'(a #\c)
+'(a ())
+'(a #t)
+'(a 1.5)
+'(a #(1))
+'(a (1 . 2))
+'(a 'q)
+'("s" 1)
+'("s" a)
+'("s" "s")
+'("s" #\c)
+'("s" ())
+'("s" #t)
+'("s" 1.5)
+'("s" #(1))
+'("s" (1 . 2))
+'("s" 'q)
+'(#\c 1)
+'(#\c a)
+'(#\c "s")
+'(#\c #\c)
+'(#\c ())
+'(#\c #t)
+'(#\c 1.5)
+'(#\c #(1))
+'(#\c (1 . 2))
+'(#\c 'q)
+'(() 1)
+'(() a)
+'(() "s")
+'(() #\c)
+'(() ())
+'(() #t)
+'(() 1.5)
+'(() #(1))
+'(() (1 . 2))
+'(() 'q)
+'(#t 1)
+'(#t a)
+'(#t "s")
+'(#t #\c)
+'(#t ())
+'(#t #t)
+'(#t 1.5)
+'(#t #(1))
+'(#t (1 . 2))
+'(#t 'q)
+'(1.5 1)
+'(1.5 a)
+'(1.5 "s")
+'(1.5 #\c)
+'(1.5 ())
+'(1.5 #t)
+'(1.5 1.5)
+'(1.5 #(1))
+'(1.5 (1 . 2))
+'(1.5 'q)
+'(#(1) 1)
+'(#(1) a)
+'(#(1) "s")
+'(#(1) #\c)
+'(#(1) ())
+'(#(1) #t)
+'(#(1) 1.5)
+'(#(1) #(1))
+'(#(1) (1 . 2))
+'(#(1) 'q)
+'((1 . 2) 1)
+'((1 . 2) a)
+'((1 . 2) "s")
+'((1 . 2) #\c)
+'((1 . 2) ())
+'((1 . 2) #t)
+'((1 . 2) 1.5)
+'((1 . 2) #(1))
+'((1 . 2) (1 . 2))
+'((1 . 2) 'q)
+'('q 1)
+'('q a)
+'('q "s")
+'('q #\c)
+'('q ())
+'('q #t)
+'('q 1.5)
+'('q #(1))
+'('q (1 . 2))
+'('q 'q)
@@ -0,0 +1,102 @@
+package parser
+
+/// ------------------------------------------------------------------------ ///
+/// --------------------- Public functions and methods --------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// calls visit with the expression and, if it returns true, walks the
+// expressions inside it in order: the items and the tail of a list of code,
+// the car and the cdr of a pair and the items of a vector
+// other expressions have nothing inside them, the data mustn't be cyclic
+func Walk(expr Expression, visit func(expr Expression) bool) {
+	if expr == nil || !visit(expr) {
+		return
+	}
+
+	switch ex := expr.(type) {
+	case *ExprList:
+		for _, item := range ex.Lst {
+			Walk(item, visit)
+		}
+		Walk(ex.Tail, visit)
+
+	case *Pair:
+		Walk(ex.Car, visit)
+		Walk(ex.Cdr, visit)
+
+	case *Vector:
+		for _, item := range ex.Items {
+			Walk(item, visit)
+		}
+	}
+}
+
+// returns the expression with the expressions inside it, the ones Walk
+// visits, rewritten first and then itself replaced by what rewrite returns
+// for it, lists, pairs and vectors whose insides change are copied so the
+// given expression stays the same, the data mustn't be cyclic
+func Rewrite(expr Expression, rewrite func(expr Expression) Expression) Expression {
+	if expr == nil {
+		return nil
+	}
+
+	switch ex := expr.(type) {
+	case *ExprList:
+		var res *ExprList
+		for j, item := range ex.Lst {
+			if sub := Rewrite(item, rewrite); sub != item {
+				if res == nil {
+					res = copyList(ex)
+				}
+				res.Lst[j] = sub
+			}
+		}
+
+		if tail := Rewrite(ex.Tail, rewrite); tail != ex.Tail {
+			if res == nil {
+				res = copyList(ex)
+			}
+			res.Tail = tail
+		}
+
+		if res != nil {
+			expr = res
+		}
+
+	case *Pair:
+		car, cdr := Rewrite(ex.Car, rewrite), Rewrite(ex.Cdr, rewrite)
+		if car != ex.Car || cdr != ex.Cdr {
+			expr = Cons(car, cdr)
+		}
+
+	case *Vector:
+		var items []Expression
+		for j, item := range ex.Items {
+			if sub := Rewrite(item, rewrite); sub != item {
+				if items == nil {
+					items = append([]Expression{}, ex.Items...)
+				}
+				items[j] = sub
+			}
+		}
+
+		if items != nil {
+			expr = &Vector{Items: items, Immutable: ex.Immutable}
+		}
+	}
+
+	return rewrite(expr)
+}
+
+/// ------------------------------------------------------------------------ ///
+/// -------------------- Private functions and methods --------------------- ///
+/// ------------------------------------------------------------------------ ///
+
+// returns a copy of the list with its own items and without
+// the data of the original
+func copyList(lst *ExprList) *ExprList {
+	res := *lst
+	res.Lst = append([]interface{ Expression }{}, lst.Lst...)
+	res.datum = nil
+	return &res
+}